@@ -4,28 +4,37 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // store business hours need timezones on hosts without zoneinfo
 
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/cors"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	docs "github.com/falasefemi2/vendorhub/docs"
 
+	"github.com/falasefemi2/vendorhub/internal/cache"
 	"github.com/falasefemi2/vendorhub/internal/config"
 	"github.com/falasefemi2/vendorhub/internal/db"
 	"github.com/falasefemi2/vendorhub/internal/handlers"
+	"github.com/falasefemi2/vendorhub/internal/logging"
+	"github.com/falasefemi2/vendorhub/internal/metrics"
 	"github.com/falasefemi2/vendorhub/internal/middleware"
+	"github.com/falasefemi2/vendorhub/internal/payment"
+	"github.com/falasefemi2/vendorhub/internal/ratelimit"
 	"github.com/falasefemi2/vendorhub/internal/repository"
 	"github.com/falasefemi2/vendorhub/internal/service"
 	"github.com/falasefemi2/vendorhub/internal/storage"
+	"github.com/falasefemi2/vendorhub/internal/tracing"
 )
 
 // @title VendorHub API
@@ -43,49 +52,207 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
+// @securityDefinitions.apikey VendorAPIKey
+// @in header
+// @name X-API-Key
 func main() {
-	config.Load()
-	connString := config.GetDBURL()
+	// Every setting is checked before anything starts, reporting all the
+	// invalid ones at once
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+
+	// Structured logs; the standard log package writes through it too
+	logger, err := logging.New(os.Stdout, cfg.Log.Format, cfg.Log.Level)
+	if err != nil {
+		panic(err)
+	}
+	slog.SetDefault(logger)
+	if cfg.EnvFile == "" {
+		logger.Info(".env file not found, using system environment variables")
+	}
+
 	ctx := context.Background()
 
-	pool, err := db.ConnectAndMigrate(ctx, connString)
+	// OpenTelemetry tracing, off unless OTEL_TRACES_EXPORTER is set
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing.Exporter)
+	if err != nil {
+		panic(fmt.Errorf("failed to set up tracing: %w", err))
+	}
+
+	pool, err := db.ConnectAndMigrate(ctx, cfg.Database.URL, logger)
 	if err != nil {
 		panic(fmt.Errorf("failed to migrate: %w", err))
 	}
 	defer pool.Close()
 
-	fmt.Println("Database ready")
+	logger.Info("database ready")
+
+	// Prometheus metrics: runtime, connection pool and platform-wide counts
+	registry := metrics.NewRegistry()
+	registry.MustRegister(
+		metrics.NewPoolCollector(pool),
+		metrics.NewPlatformCollector(repository.NewStatsRepository(pool)),
+	)
+
+	// Initialize Supabase storage
+	supabaseStorage, err := storage.NewSupabaseStorage(cfg.Storage.SupabaseURL, cfg.Storage.SupabaseKey, cfg.Storage.Bucket, logger)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize Supabase storage: %w", err))
+	}
+	fileStorage := storage.WithTracing(storage.WithMetrics(supabaseStorage, registry))
+
+	// Liveness and readiness probes; readiness checks the database, file
+	// storage and that this build's schema has been applied
+	schemaChecker, err := db.NewSchemaChecker(pool)
+	if err != nil {
+		panic(fmt.Errorf("failed to set up schema check: %w", err))
+	}
+	healthService := service.NewHealthService(cfg.Health.CheckTimeout, logger)
+	healthService.AddCheck("postgres", service.HealthCheckFunc(pool.Ping))
+	healthService.AddCheck("storage", service.HealthCheckFunc(supabaseStorage.Ping))
+	healthService.AddCheck("schema", schemaChecker)
+	healthHandler := handlers.NewHealthHandler(healthService)
+
+	// Public catalog responses, cached in memory or shared between
+	// replicas through Redis when CACHE_REDIS_URL is set
+	var catalogCacheBackend cache.Cache
+	if cfg.Cache.Enabled {
+		catalogCacheBackend = cache.NewLRU(cfg.Cache.MaxEntries)
+		if cfg.Cache.RedisURL != "" {
+			redisOptions, err := redis.ParseURL(cfg.Cache.RedisURL)
+			if err != nil {
+				panic(fmt.Errorf("failed to parse CACHE_REDIS_URL: %w", err))
+			}
+			redisClient := redis.NewClient(redisOptions)
+			defer redisClient.Close()
+
+			redisCache := cache.NewRedis(redisClient)
+			healthService.AddCheck("cache", service.HealthCheckFunc(redisCache.Ping))
+			catalogCacheBackend = redisCache
+		}
+	}
+	catalogCache := service.NewCatalogCache(catalogCacheBackend, cfg.Cache.TTL, logger)
 
 	userRepo := repository.NewUserRepository(pool)
-	authService := service.NewAuthService(userRepo, os.Getenv("JWT_SECRET"))
+	storeRepo := repository.NewStoreRepository(pool)
+	storeService := service.NewStoreService(storeRepo, userRepo, fileStorage, cfg.Server.FrontendURL, catalogCache, logger)
+	authService := service.NewAuthService(userRepo, storeService, cfg.Auth.JWTSecret)
 	authHandler := handlers.NewAuthHandler(authService)
 
-	adminService := service.NewAdminService(userRepo)
+	adminService := service.NewAdminService(userRepo, storeService)
 	adminHandler := handlers.NewAdminHandler(adminService)
 
 	productRepo := repository.NewProductRepository(pool)
 
-	// Initialize Supabase storage
-	supabaseURL := config.GetSupabaseURL()
-	supabaseKey := config.GetSupabaseKey()
-	supabaseBucket := config.GetSupabaseBucket()
-
-	supabaseStorage, err := storage.NewSupabaseStorage(supabaseURL, supabaseKey, supabaseBucket)
-	if err != nil {
-		fmt.Printf("error: failed to initialize Supabase storage: %v\n", err)
-		panic(fmt.Errorf("failed to initialize Supabase storage: %w", err))
+	storeMemberRepo := repository.NewStoreMemberRepository(pool)
+	staffService := service.NewStaffService(storeMemberRepo, userRepo, storeService, cfg.Auth.JWTSecret)
+	staffHandler := handlers.NewStaffHandler(staffService)
+
+	// Vendor API keys, accepted alongside JWTs on catalog and order routes
+	apiKeyRepo := repository.NewAPIKeyRepository(pool)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo, storeService, logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+
+	// Outbound webhooks to vendors' integrations
+	webhookRepo := repository.NewWebhookRepository(pool)
+	webhookService := service.NewWebhookService(webhookRepo, storeService, cfg.Webhooks.AllowPrivateNetworks, logger)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+	// Meta and Google catalog feeds, cleared when products change
+	catalogFeedService := service.NewCatalogFeedService(productRepo, storeService)
+	catalogFeedHandler := handlers.NewCatalogFeedHandler(catalogFeedService, logger)
+	productEvents := service.EventPublishers{webhookService, catalogFeedService}
+
+	productService := service.NewProductService(productRepo, fileStorage, staffService, storeService, productEvents, catalogCache, logger)
+	productHandler := handlers.NewProductHandler(productService, fileStorage)
+
+	// CSV product import and export
+	productImportRepo := repository.NewProductImportRepository(pool)
+	productImportService := service.NewProductImportService(productImportRepo, productRepo, storeService, staffService, productEvents, catalogCache, logger)
+	productImportHandler := handlers.NewProductImportHandler(productImportService, logger)
+
+	reviewRepo := repository.NewReviewRepository(pool)
+	reviewService := service.NewReviewService(reviewRepo, productRepo, staffService)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+
+	couponRepo := repository.NewCouponRepository(pool)
+	couponService := service.NewCouponService(couponRepo, productRepo, storeService)
+	couponHandler := handlers.NewCouponHandler(couponService)
+
+	orderRepo := repository.NewOrderRepository(pool)
+	paymentRepo := repository.NewPaymentRepository(pool)
+	orderService := service.NewOrderService(orderRepo, paymentRepo, couponService, storeService, staffService, webhookService)
+	orderHandler := handlers.NewOrderHandler(orderService)
+
+	// Online payments are opt-in; without a provider orders are settled by
+	// hand. The fake provider lets checkout run locally without keys, and
+	// config only allows it with APP_ENV=development.
+	var paymentProvider payment.Provider
+	switch cfg.Payment.Provider {
+	case "paystack":
+		paymentProvider = payment.NewPaystackProvider(cfg.Payment.PaystackSecretKey)
+	case "fake":
+		logger.Warn("using the fake payment provider; payments are not real")
+		paymentProvider = payment.NewFakeProvider()
+	default:
+		logger.Info("online payments are disabled; set PAYMENT_PROVIDER to take them")
 	}
 
-	productService := service.NewProductService(productRepo, supabaseStorage)
-	productHandler := handlers.NewProductHandler(productService, supabaseStorage)
+	var paymentHandler *handlers.PaymentHandler
+	if paymentProvider != nil {
+		paymentService := service.NewPaymentService(paymentProvider, paymentRepo, orderRepo, cfg.Payment.CallbackURL, webhookService, logger)
+		paymentHandler = handlers.NewPaymentHandler(paymentService)
+	}
 
-	storeHandler := handlers.NewStoreHandler(authService, productService)
+	analyticsRepo := repository.NewAnalyticsRepository(pool)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, productRepo, storeService, cfg.Auth.AnalyticsSalt, logger)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, cfg.Server.ClientIPHeader)
+
+	// Background jobs: roll raw analytics events up into daily aggregates,
+	// send queued webhook deliveries and run product imports
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	analyticsService.StartRollupJob(jobsCtx, 10*time.Minute)
+	webhookService.StartDeliveryJob(jobsCtx, 5*time.Second)
+	productImportService.StartImportJob(jobsCtx, 5*time.Second)
+
+	storeHandler := handlers.NewStoreHandler(storeService, productService, fileStorage)
+
+	// Link preview metadata and sitemap.xml for storefront pages
+	seoService := service.NewSEOService(productRepo, storeService)
+	seoHandler := handlers.NewSEOHandler(seoService, logger)
+
+	// Printable QR codes and flyers for storefront links
+	qrCodeService := service.NewQRCodeService(productRepo, storeService, logger)
+	qrCodeHandler := handlers.NewQRCodeHandler(qrCodeService, logger)
+
+	// Rate limits, counted in memory or shared between replicas through
+	// Redis when RATE_LIMIT_REDIS_URL is set
+	var rateLimitStore ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		rateLimitStore = ratelimit.NewMemoryStore()
+		if cfg.RateLimit.RedisURL != "" {
+			redisOptions, err := redis.ParseURL(cfg.RateLimit.RedisURL)
+			if err != nil {
+				panic(fmt.Errorf("failed to parse RATE_LIMIT_REDIS_URL: %w", err))
+			}
+			redisClient := redis.NewClient(redisOptions)
+			defer redisClient.Close()
+
+			redisStore := ratelimit.NewRedisStore(redisClient)
+			healthService.AddCheck("redis", service.HealthCheckFunc(redisStore.Ping))
+			rateLimitStore = redisStore
+		}
+	}
+	limiter := middleware.NewRateLimiter(rateLimitStore, cfg.Server.ClientIPHeader, logger)
 
 	// Configure Swagger host/schemes at runtime so local testing uses localhost:8080
-	baseURL := os.Getenv("BASE_URL")
-	if baseURL == "" {
+	if baseURL := cfg.Server.BaseURL; baseURL == "" {
 		// running locally
-		docs.SwaggerInfo.Host = "localhost:8080"
+		docs.SwaggerInfo.Host = "localhost" + cfg.Server.Addr()
 		docs.SwaggerInfo.Schemes = []string{"http"}
 	} else {
 		// remove scheme and trailing slash
@@ -102,41 +269,88 @@ func main() {
 
 	r := chi.NewRouter()
 
+	// Vendor and admin routes require a token signed with JWT_SECRET
+	jwtAuth := middleware.JWTAuth(cfg.Auth.JWTSecret)
+
+	// Request traces, request IDs for correlating log lines, request
+	// metrics, then one access log line per request
+	r.Use(middleware.Tracing)
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Metrics(registry))
+	r.Use(middleware.AccessLog(logger))
+
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		if _, err := w.Write([]byte("OK")); err != nil {
-			log.Printf("Error writing health check response: %v", err)
+			logger.WarnContext(r.Context(), "failed to write health check response", "error", err)
 		}
 	})
 
+	// GET /healthz - Liveness, GET /readyz - Readiness with dependency checks
+	r.Get("/healthz", healthHandler.Liveness)
+	r.Get("/readyz", healthHandler.Readiness)
+
+	// GET /metrics - Prometheus scrape endpoint, behind METRICS_TOKEN when set
+	r.Method(http.MethodGet, "/metrics", metrics.Handler(registry, cfg.Metrics.Token, logger))
+
+	// GET /sitemap.xml - Store and product pages for search engines
+	r.Get("/sitemap.xml", seoHandler.Sitemap)
+
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
+	// Rate limit policies; routes sharing a policy share each client's
+	// allowance
+	signupLimit := limiter.Limit(middleware.RateLimitPolicy{Name: "signup", Limit: ratelimit.PerHour(10), Key: middleware.ByIP})
+	loginLimit := limiter.Limit(middleware.RateLimitPolicy{Name: "login", Limit: ratelimit.PerMinute(10), Key: middleware.ByIP})
+	searchLimit := limiter.Limit(middleware.RateLimitPolicy{Name: "search", Limit: ratelimit.PerMinute(60), Key: middleware.ByIP})
+	checkoutLimit := limiter.Limit(middleware.RateLimitPolicy{Name: "checkout", Limit: ratelimit.PerMinute(20), Key: middleware.ByIP})
+	reviewLimit := limiter.Limit(middleware.RateLimitPolicy{Name: "reviews", Limit: ratelimit.PerHour(20), Key: middleware.ByIP})
+	eventLimit := limiter.Limit(middleware.RateLimitPolicy{Name: "events", Limit: ratelimit.PerMinute(120), Key: middleware.ByIP})
+	vendorAPILimit := limiter.Limit(middleware.RateLimitPolicy{Name: "vendor-api", Limit: ratelimit.PerMinute(300), Key: middleware.ByAPIKey})
+
 	r.Route("/auth", func(r chi.Router) {
-		r.Post("/signup", authHandler.SignUp)
-		r.Post("/login", authHandler.Login)
+		r.With(signupLimit).Post("/signup", authHandler.SignUp)
+		r.With(loginLimit).Post("/login", authHandler.Login)
+		r.With(loginLimit).Post("/accept-invite", staffHandler.AcceptInvite)
 	})
 
 	r.Route("/admin", func(r chi.Router) {
-		r.Use(middleware.JWTAuth)
+		r.Use(jwtAuth)
 		r.Use(middleware.AdminOnly)
 
 		r.Post("/vendors/{id}/approve", adminHandler.ApproveVendor)
 		r.Get("/vendors/pending", adminHandler.ListPendingVendors)
 		r.Get("/vendors/approved", adminHandler.ListApprovedVendors)
+
+		// Review moderation
+		r.Get("/reviews", reviewHandler.ListReviews)
+		r.Put("/reviews/{reviewId}/hide", reviewHandler.HideReview)
+		r.Put("/reviews/{reviewId}/publish", reviewHandler.PublishReview)
+
+		// Profiling, only when PPROF_ENABLED=true
+		if cfg.Metrics.PprofEnabled {
+			r.Mount("/debug", chimiddleware.Profiler())
+		}
 	})
 
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.JWTAuth)
+		r.Use(jwtAuth)
 		r.Get("/me", authHandler.GetMyProfile)
+		r.Get("/me/stores", staffHandler.GetMyMemberships)
 	})
 
 	r.Route("/products", func(r chi.Router) {
 		r.Get("/active", productHandler.GetActiveProducts)
-		r.Get("/search", productHandler.SearchProducts)
+		r.With(searchLimit).Get("/search", productHandler.SearchProducts)
 		r.Get("/price", productHandler.GetProductsByPriceRange)
 		r.Get("/", productHandler.GetProduct)
 
+		// Buyer reviews
+		r.Get("/{productId}/reviews", reviewHandler.ListProductReviews)
+		r.With(reviewLimit).Post("/{productId}/reviews", reviewHandler.CreateReview)
+
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.JWTAuth)
+			r.Use(middleware.JWTOrAPIKeyAuth(cfg.Auth.JWTSecret, apiKeyService))
+			r.Use(vendorAPILimit)
 
 			// Vendor-only operations
 			r.Post("/", productHandler.CreateProduct)
@@ -145,6 +359,11 @@ func main() {
 			r.Put("/{id}/status", productHandler.ToggleProductStatus)
 			r.Get("/my", productHandler.GetUserProducts)
 
+			// Bulk CSV import and export
+			r.Post("/import", productImportHandler.ImportProducts)
+			r.Get("/import/{jobId}", productImportHandler.GetImportJob)
+			r.Get("/export", productImportHandler.ExportProducts)
+
 			// Product image operations
 			r.Post("/{productId}/images", productHandler.UploadProductImage)
 		})
@@ -152,7 +371,8 @@ func main() {
 
 	// Image management routes (vendor-only)
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.JWTAuth)
+		r.Use(middleware.JWTOrAPIKeyAuth(cfg.Auth.JWTSecret, apiKeyService))
+		r.Use(vendorAPILimit)
 		r.Route("/images", func(r chi.Router) {
 			r.Delete("/{imageId}", productHandler.DeleteProductImage)
 			r.Put("/{imageId}/position", productHandler.UpdateProductImagePosition)
@@ -161,13 +381,13 @@ func main() {
 
 	r.Route("/stores", func(r chi.Router) {
 		// Public store endpoints
-		// GET /stores - All vendors with stores
+		// GET /stores - All stores of approved vendors
 		r.Get("/", storeHandler.GetAllStores)
 
-		// GET /stores/search?q=pizza - Search vendors
-		r.Get("/search", storeHandler.SearchStores)
+		// GET /stores/search?q=pizza - Search stores
+		r.With(searchLimit).Get("/search", storeHandler.SearchStores)
 
-		// GET /stores/vendor?id={vendorId} - Get vendor's store by ID
+		// GET /stores/vendor?id={vendorId} - Get vendor's primary store
 		r.Get("/vendor", storeHandler.GetStoreByVendorID)
 
 		// WHATSAPP SHAREABLE LINK
@@ -175,57 +395,142 @@ func main() {
 		// Example: GET /stores/@pizzahut-lagos
 		r.Get("/{slug}", storeHandler.GetStoreBySlug)
 
+		// GET /stores/{slug}/products/{productSlug} - Product page by slugs
+		r.Get("/{slug}/products/{productSlug}", storeHandler.GetStoreProduct)
+
+		// Open Graph and Twitter card metadata for store and product pages
+		r.Get("/{slug}/meta", seoHandler.GetStoreMeta)
+		r.Get("/{slug}/products/{productSlug}/meta", seoHandler.GetProductMeta)
+
+		// QR codes as PNG or SVG, or an A4 PDF flyer with ?format=pdf
+		r.Get("/{slug}/qr", qrCodeHandler.GetStoreQRCode)
+		r.Get("/{slug}/products/{productSlug}/qr", qrCodeHandler.GetProductQRCode)
+
+		// Product feeds for Meta Commerce (WhatsApp and Instagram) and Google Merchant
+		r.Get("/{slug}/feeds/meta.csv", catalogFeedHandler.GetMetaCSVFeed)
+		r.Get("/{slug}/feeds/meta.xml", catalogFeedHandler.GetMetaXMLFeed)
+		r.Get("/{slug}/feeds/google.xml", catalogFeedHandler.GetGoogleFeed)
+
 		// Protected store endpoints (vendor only)
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.JWTAuth)
+			r.Use(jwtAuth)
+
+			// POST /stores - Open an additional store
+			r.Post("/", storeHandler.CreateStore)
 
-			// GET /stores/my - Get authenticated vendor's store with products
+			// GET /stores/my?store_id= - Get one of the vendor's stores with products
 			r.Get("/my", storeHandler.GetMyStore)
 
-			// PUT /stores/my - Update vendor's store info
+			// PUT /stores/my?store_id= - Update one of the vendor's stores
 			r.Put("/my", storeHandler.UpdateMyStore)
+
+			// DELETE /stores/my?store_id= - Delete one of the vendor's stores
+			r.Delete("/my", storeHandler.DeleteMyStore)
+
+			// Storefront logo and banner (multipart "image" field)
+			r.Put("/my/logo", storeHandler.UploadStoreLogo)
+			r.Delete("/my/logo", storeHandler.DeleteStoreLogo)
+			r.Put("/my/banner", storeHandler.UploadStoreBanner)
+			r.Delete("/my/banner", storeHandler.DeleteStoreBanner)
+
+			// GET /stores/my/analytics?store_id=&from=&to= - Views and WhatsApp clicks
+			r.Get("/my/analytics", analyticsHandler.GetMyStoreAnalytics)
+
+			// Coupon codes for the vendor's store
+			r.Get("/my/coupons", couponHandler.ListCoupons)
+			r.Post("/my/coupons", couponHandler.CreateCoupon)
+			r.Put("/my/coupons/{couponId}", couponHandler.UpdateCoupon)
+			r.Delete("/my/coupons/{couponId}", couponHandler.DeleteCoupon)
+
+			// Webhook endpoints and their delivery log
+			r.Get("/my/webhooks", webhookHandler.ListWebhooks)
+			r.Post("/my/webhooks", webhookHandler.CreateWebhook)
+			r.Put("/my/webhooks/{webhookId}", webhookHandler.UpdateWebhook)
+			r.Delete("/my/webhooks/{webhookId}", webhookHandler.DeleteWebhook)
+			r.Get("/my/webhooks/{webhookId}/deliveries", webhookHandler.ListWebhookDeliveries)
+			r.Post("/my/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver", webhookHandler.RedeliverWebhook)
+
+			// API keys for the vendor's own systems
+			r.Get("/my/api-keys", apiKeyHandler.ListAPIKeys)
+			r.Post("/my/api-keys", apiKeyHandler.CreateAPIKey)
+			r.Delete("/my/api-keys/{keyId}", apiKeyHandler.RevokeAPIKey)
+
+			// GET /stores/my/reviews?store_id= - All reviews of the store's products
+			r.Get("/my/reviews", reviewHandler.ListMyStoreReviews)
+
+			// Staff management for the vendor's store
+			r.Get("/my/staff", staffHandler.ListStaff)
+			r.Post("/my/staff", staffHandler.InviteStaff)
+			r.Put("/my/staff/{memberId}", staffHandler.UpdateStaffRole)
+			r.Delete("/my/staff/{memberId}", staffHandler.RemoveStaff)
+		})
+
+		// Orders and their payments (vendor, order staff or an API key)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.JWTOrAPIKeyAuth(cfg.Auth.JWTSecret, apiKeyService))
+			r.Use(vendorAPILimit)
+			r.Get("/my/orders", orderHandler.ListMyStoreOrders)
+			r.Get("/my/orders/{orderId}", orderHandler.GetMyStoreOrder)
 		})
 	})
 
+	// Cart pricing with sale prices and coupons (public)
+	r.With(checkoutLimit).Post("/quotes", couponHandler.CreateQuote)
+
+	// Orders and online payment (public); the payment routes are only
+	// served when a provider is configured
+	r.Route("/orders", func(r chi.Router) {
+		r.With(checkoutLimit).Post("/", orderHandler.PlaceOrder)
+		r.Get("/{reference}", orderHandler.GetOrder)
+		if paymentHandler != nil {
+			r.With(checkoutLimit).Post("/{reference}/pay", paymentHandler.InitializePayment)
+		}
+	})
+	if paymentHandler != nil {
+		r.Get("/payments/verify", paymentHandler.VerifyPayment)
+
+		// Payment provider notifications, authenticated by the provider's
+		// signature. Only the enabled provider's route exists, so the
+		// unsigned fake webhook is never served outside development.
+		r.Post("/webhooks/payments/{provider:"+paymentProvider.Name()+"}", paymentHandler.PaymentWebhook)
+	}
+
+	// Storefront analytics ingestion (public)
+	r.With(eventLimit).Post("/events", analyticsHandler.TrackEvent)
+
+	// Store replies to reviews (vendor or catalog staff)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtAuth)
+		r.Put("/reviews/{reviewId}/reply", reviewHandler.ReplyToReview)
+	})
+
 	// Vendor public routes
 	r.Route("/vendors", func(r chi.Router) {
 		r.Get("/{id}/products", productHandler.GetVendorProducts)
 		r.Get("/{id}/products/active", productHandler.GetActiveProducts)
 	})
 
-	// Build CORS allowed origins
-	allowedOrigins := []string{
-		"http://localhost:3000",
-		"http://localhost:3001",
-		"https://vendorhub-v2-frontend.vercel.app",
-	}
-
-	if prodOrigins := os.Getenv("ALLOWED_ORIGINS"); prodOrigins != "" {
-		origins := strings.Split(prodOrigins, ",")
-		for _, origin := range origins {
-			allowedOrigins = append(allowedOrigins, strings.TrimSpace(origin))
-		}
-	}
-
 	c := cors.New(cors.Options{
-		AllowedOrigins:   allowedOrigins,
+		AllowedOrigins:   cfg.Server.CORSOrigins(),
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-API-Key", "If-None-Match", middleware.RequestIDHeader, "traceparent", "tracestate"},
+		ExposedHeaders:   []string{middleware.RequestIDHeader, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "ETag"},
 		AllowCredentials: true,
 	})
 
 	server := &http.Server{
-		Addr:         ":8080",
+		Addr:         cfg.Server.Addr(),
 		Handler:      c.Handler(r),
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
 	go func() {
-		log.Printf("Server starting on %s", server.Addr)
+		logger.Info("server starting", "addr", server.Addr)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
+			logger.Error("server error", "error", err)
+			os.Exit(1)
 		}
 	}()
 
@@ -233,8 +538,24 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	// Fail readiness first so load balancers stop sending new requests
+	// while in-flight ones finish
+	healthService.StartDraining()
+	if delay := cfg.Server.ShutdownDrainDelay; delay > 0 {
+		logger.Info("draining before shutdown", "delay", delay.String())
+		time.Sleep(delay)
+	}
+
+	logger.Info("shutting down server")
+	stopJobs()
 	if err := server.Shutdown(context.Background()); err != nil {
-		log.Fatalf("Server shutdown error: %v", err)
+		logger.Error("server shutdown error", "error", err)
+		os.Exit(1)
+	}
+
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
 	}
 }
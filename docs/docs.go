@@ -24,31 +24,52 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/vendors/approved": {
+        "/admin/reviews": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Lists all vendors that have been approved",
+                "description": "Lists reviews across all stores, newest first, optionally filtered by status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "List approved vendors",
+                "summary": "List reviews for moderation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "published or hidden",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -67,32 +88,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/admin/vendors/pending": {
-            "get": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/reviews/{reviewId}/hide": {
+            "put": {
+                "description": "Hides a review from public listings and removes it from ratings",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Lists all vendors that are pending approval",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "List pending vendors",
+                "summary": "Hide a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ModerateReviewRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
-                            }
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
                     "401": {
@@ -107,42 +151,68 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/admin/vendors/{id}/approve": {
-            "post": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
+                ]
+            }
+        },
+        "/admin/reviews/{reviewId}/publish": {
+            "put": {
+                "description": "Restores a hidden review to public listings and ratings",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Approves a vendor with the given ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Approve a vendor",
+                "summary": "Publish a review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vendor ID",
-                        "name": "id",
+                        "description": "Review ID",
+                        "name": "reviewId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Moderation note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ModerateReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -168,48 +238,42 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Logs in a user and returns a JWT token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/vendors/approved": {
+            "get": {
+                "description": "Lists all vendors that have been approved",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
-                ],
-                "summary": "Logs in a user",
-                "parameters": [
-                    {
-                        "description": "Login Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.LoginRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "List approved vendors",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -220,42 +284,42 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             }
         },
-        "/auth/signup": {
-            "post": {
-                "description": "Creates a new user with the provided details",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/vendors/pending": {
+            "get": {
+                "description": "Lists all vendors that are pending approval",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Sign up a new user",
-                "parameters": [
-                    {
-                        "description": "Sign Up Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                "summary": "List pending vendors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.SignUpRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
+                            }
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -266,42 +330,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/images/{imageId}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Deletes an image from a product",
+                ]
+            }
+        },
+        "/admin/vendors/{id}/approve": {
+            "post": {
+                "description": "Approves a vendor with the given ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProductImages"
+                    "Admin"
                 ],
-                "summary": "Delete a product image",
+                "summary": "Approve a vendor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Image ID",
-                        "name": "imageId",
+                        "description": "Vendor ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -327,17 +385,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/images/{imageId}/position": {
-            "put": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Changes the position/order of a product image",
+                ]
+            }
+        },
+        "/auth/accept-invite": {
+            "post": {
+                "description": "Redeems an invite token, creating a staff account if the invited email has none, and returns a JWT",
                 "consumes": [
                     "application/json"
                 ],
@@ -345,24 +403,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductImages"
+                    "Staff"
                 ],
-                "summary": "Update product image position",
+                "summary": "Accept a staff invite",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Image ID",
-                        "name": "imageId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Position Request",
+                        "description": "Accept Invite Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageRequest"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AcceptInviteRequest"
                         }
                     }
                 ],
@@ -370,10 +421,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
                         }
                     },
                     "400": {
@@ -388,14 +436,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -409,26 +457,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/me": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+        "/auth/login": {
+            "post": {
+                "description": "Logs in a user and returns a JWT token",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get the profile of the currently logged-in user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Get user profile",
+                "summary": "Logs in a user",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.LoginRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthUser"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
                     "401": {
@@ -437,8 +500,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -452,30 +515,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
-            "get": {
-                "description": "Retrieves a single product by its ID with images",
+        "/auth/signup": {
+            "post": {
+                "description": "Creates a new user with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Auth"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Sign up a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Sign Up Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.SignUpRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthResponse"
                         }
                     },
                     "400": {
@@ -484,8 +552,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -497,14 +571,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/events": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Creates a new product for the authenticated vendor",
+                "description": "Records a store_view, product_view or whatsapp_click. Each visitor counts once per event, store, product and day; bots are ignored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -512,25 +583,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Analytics"
                 ],
-                "summary": "Create a new product",
+                "summary": "Track a storefront event",
                 "parameters": [
                     {
-                        "description": "Create Product Request",
+                        "description": "Event",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateProductRequest"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.TrackEventRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -539,14 +613,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -560,57 +634,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/active": {
+        "/healthz": {
             "get": {
-                "description": "Retrieves all active products",
+                "description": "Reports that the process is up and serving requests. It doesn't check dependencies, so an outage of the database doesn't get healthy instances restarted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Health"
                 ],
-                "summary": "Get active products",
+                "summary": "Liveness probe",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.HealthResponse"
                         }
                     }
                 }
             }
         },
-        "/products/my": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves all products for the currently authenticated vendor",
+        "/images/{imageId}": {
+            "delete": {
+                "description": "Deletes an image from a product",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "ProductImages"
+                ],
+                "summary": "Delete a product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get authenticated vendor's products",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -626,48 +695,67 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
             }
         },
-        "/products/price": {
-            "get": {
-                "description": "Retrieves products within a specified price range",
+        "/images/{imageId}/position": {
+            "put": {
+                "description": "Changes the position/order of a product image",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "ProductImages"
                 ],
-                "summary": "Get products by price range",
+                "summary": "Update product image position",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "Minimum Price",
-                        "name": "min",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "number",
-                        "description": "Maximum Price",
-                        "name": "max",
-                        "in": "query",
-                        "required": true
+                        "description": "Position Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -677,46 +765,66 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
             }
         },
-        "/products/search": {
+        "/me": {
             "get": {
-                "description": "Searches for products by a search term",
+                "description": "Get the profile of the currently logged-in user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Search for products",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search Term",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
-                            }
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthUser"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -727,56 +835,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/products/{id}": {
-            "put": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Updates an existing product for the authenticated vendor",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/me/stores": {
+            "get": {
+                "description": "Lists the stores the authenticated user owns (role \"owner\") or has joined as staff, with their role",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Update a product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Product Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateProductRequest"
-                        }
-                    }
+                    "Staff"
                 ],
+                "summary": "List my stores",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreMemberResponse"
+                            }
                         }
                     },
                     "401": {
@@ -785,57 +869,49 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
+                ]
+            }
+        },
+        "/orders": {
+            "post": {
+                "description": "Prices a cart like a quote and saves it as an unpaid order, redeeming any coupon in the same transaction. The response carries the order reference for online payment and the WhatsApp order summary.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Deletes a product for the authenticated vendor",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Delete a product",
+                "summary": "Place an order",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Place Order Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.PlaceOrderRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.OrderResponse"
                         }
                     },
                     "400": {
@@ -844,20 +920,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -871,65 +941,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/status": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Toggles a product's active status for the authenticated vendor",
-                "consumes": [
-                    "application/json"
-                ],
+        "/orders/{reference}": {
+            "get": {
+                "description": "Returns an order and its payment status by the reference given to the buyer",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Toggle a product's active status",
+                "summary": "Get an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
+                        "description": "Order reference",
+                        "name": "reference",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Toggle Status Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ToggleProductStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.OrderResponse"
                         }
                     },
                     "404": {
@@ -947,51 +982,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{productId}/images": {
+        "/orders/{reference}/pay": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Uploads a new image for a product",
+                "description": "Starts an online payment of the order's total and returns the provider checkout URL to send the buyer to. The email defaults to the order's customer email.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProductImages"
+                    "Payments"
                 ],
-                "summary": "Upload an image for a product",
+                "summary": "Pay for an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Order reference",
+                        "name": "reference",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Image position",
-                        "name": "position",
-                        "in": "formData"
+                        "description": "Initialize Payment Request",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.InitializePaymentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.InitializePaymentResponse"
                         }
                     },
                     "400": {
@@ -1000,20 +1025,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -1027,41 +1046,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/stores": {
+        "/payments/verify": {
             "get": {
-                "description": "Retrieves all active vendor stores with pagination support",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Checks a payment with the provider and records the result. Call it when the buyer returns from checkout.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stores"
+                    "Payments"
                 ],
-                "summary": "Get all vendor stores",
+                "summary": "Verify a payment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size (default: 20, max: 100)",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Payment reference",
+                        "name": "reference",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
-                            }
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.PaymentResponse"
                         }
                     },
                     "400": {
@@ -1070,6 +1078,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1079,39 +1093,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/stores/my": {
+        "/products": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves the authenticated vendor's store and products",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves a single product by its ID with images",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stores"
+                    "Products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get authenticated vendor's store",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -1130,13 +1139,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Updates the authenticated vendor's store information",
+            "post": {
+                "description": "Creates a new product for the authenticated vendor",
                 "consumes": [
                     "application/json"
                 ],
@@ -1144,25 +1148,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Stores"
+                    "Products"
                 ],
-                "summary": "Update authenticated vendor's store",
+                "summary": "Create a new product",
                 "parameters": [
                     {
-                        "description": "Update Store Request",
+                        "description": "Create Product Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateStoreRequest"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateProductRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
                         }
                     },
                     "400": {
@@ -1183,35 +1187,57 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Slug already used in the store",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
             }
         },
-        "/stores/search": {
+        "/products/active": {
             "get": {
-                "description": "Searches for vendor stores by name or username",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves all active products. Responses carry an ETag; send it back in If-None-Match to get 304 Not Modified when nothing changed. Without If-None-Match, an If-Modified-Since no earlier than Last-Modified gets 304 too.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stores"
+                    "Products"
                 ],
-                "summary": "Search stores",
+                "summary": "Get active products",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
+                        "description": "Sort order: newest, price_asc, price_desc or rating",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a response already held",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a response already held",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1220,10 +1246,23 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response body"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the list was loaded, if it's cached"
                             }
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -1239,33 +1278,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/stores/vendor": {
+        "/products/export": {
             "get": {
-                "description": "Retrieves vendor's store and products by vendor ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Downloads every product of the store, active or not, in the import CSV format",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Stores"
+                    "Products"
                 ],
-                "summary": "Get store by vendor ID",
+                "summary": "Export products as CSV",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vendor ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Store ID (defaults to the caller's primary store)",
+                        "name": "store_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1274,6 +1309,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1286,36 +1333,62 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
             }
         },
-        "/stores/{slug}": {
-            "get": {
-                "description": "Retrieves vendor's store and products by store slug (WhatsApp shareable link)",
+        "/products/import": {
+            "post": {
+                "description": "Creates products from a CSV file with a header row. Columns: name, description, price (required), active, category and image_urls (separated by |). Each row is validated like POST /products and invalid rows are skipped. With dry_run=true the file is only checked and a preview returned; otherwise the valid rows are created in the background and the job returned for polling. At most 1000 rows and 5MB.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stores"
+                    "Products"
                 ],
-                "summary": "Get store by slug",
+                "summary": "Import products from CSV",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Store slug (e.g., pizzahut-lagos)",
-                        "name": "slug",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Product CSV",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to the caller's primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate and preview the import",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Dry run",
                         "schema": {
-                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse"
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImportPreviewResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImportJobResponse"
                         }
                     },
                     "400": {
@@ -1324,6 +1397,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1336,24 +1421,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
             }
         },
-        "/vendors/{id}/products": {
+        "/products/import/{jobId}": {
             "get": {
-                "description": "Retrieves all products for a specific vendor",
+                "description": "Returns the progress of a CSV import and the rows that were skipped, with the reason for each",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Products"
                 ],
-                "summary": "Get a vendor's products",
+                "summary": "Get a product import",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vendor ID",
-                        "name": "id",
+                        "description": "Import job ID",
+                        "name": "jobId",
                         "in": "path",
                         "required": true
                     }
@@ -1362,14 +1455,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
-                            }
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImportJobResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -1386,26 +1482,33 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
             }
         },
-        "/vendors/{id}/products/active": {
+        "/products/my": {
             "get": {
-                "description": "Retrieves all active products for a specific vendor",
+                "description": "Retrieves all products for the currently authenticated vendor, or for store_id when the caller is staff of that store",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Products"
                 ],
-                "summary": "Get active products for a vendor",
+                "summary": "Get authenticated vendor's products",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vendor ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Store ID (staff only)",
+                        "name": "store_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1418,14 +1521,14 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
@@ -1436,47 +1539,4960 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_falasefemi2_vendorhub_internal_dto.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthUser"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            }
+        },
+        "/products/price": {
+            "get": {
+                "description": "Retrieves products priced in a currency within a price range. Amounts are decimals in the currency's major unit, e.g. 1500.50.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get products by price range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Minimum Price",
+                        "name": "min",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum Price",
+                        "name": "max",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code (default: NGN)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: newest, price_asc, price_desc or rating",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.AuthUser": {
-            "type": "object",
-            "properties": {
-                "bio": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+        "/products/search": {
+            "get": {
+                "description": "Searches for products by a search term",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Search for products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search Term",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: newest, price_asc, price_desc or rating",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "put": {
+                "description": "Updates an existing product for the authenticated vendor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Product Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug already used in the store",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deletes a product for the authenticated vendor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/status": {
+            "put": {
+                "description": "Toggles a product's active status for the authenticated vendor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Toggle a product's active status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Toggle Status Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ToggleProductStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            }
+        },
+        "/products/{productId}/images": {
+            "post": {
+                "description": "Uploads a new image for a product",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductImages"
+                ],
+                "summary": "Upload an image for a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image position",
+                        "name": "position",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            }
+        },
+        "/products/{productId}/reviews": {
+            "get": {
+                "description": "Lists the published reviews of a product, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "List product reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Leaves a 1-5 star rating and optional comment on an active product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Review a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Review Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes": {
+            "post": {
+                "description": "Prices a cart at current sale prices, applies an optional coupon and returns the WhatsApp order summary. Quotes don't count coupon uses; placing the order does.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Price a cart",
+                "parameters": [
+                    {
+                        "description": "Quote Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.QuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks the database, file storage and schema version, each within HEALTH_CHECK_TIMEOUT, and reports each check's status and latency. Returns 503 when a check fails or while the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{reviewId}/reply": {
+            "put": {
+                "description": "Sets the store's public reply to a review. An empty reply removes it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Reply to a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reply Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewReplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "Lists the storefront pages of every store of an active vendor and of their active products, regenerated at most hourly",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "SEO"
+                ],
+                "summary": "Sitemap",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores": {
+            "get": {
+                "description": "Retrieves all stores of approved vendors with pagination support",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get all stores",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an additional store owned by the authenticated vendor. The slug is derived from the name unless a custom one is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Open a new store",
+                "parameters": [
+                    {
+                        "description": "Create Store Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateStoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my": {
+            "get": {
+                "description": "Retrieves the store given by store_id, or the vendor's primary store, with all its products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get one of the authenticated vendor's stores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Updates the store given by store_id, or the vendor's primary store when omitted. Renaming the store or setting a custom slug keeps the old slug as a redirect. Also manages business hours, timezone and vacation mode.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Update one of the authenticated vendor's stores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Update Store Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateStoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deletes the store given by store_id together with its products and staff",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Delete one of the authenticated vendor's stores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/analytics": {
+            "get": {
+                "description": "Returns daily store views, product views and WhatsApp clicks plus the top products of one of the authenticated vendor's stores. Figures are refreshed by a background job every few minutes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get store analytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD (default: 29 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD (default: today in the store's timezone)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreAnalyticsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/api-keys": {
+            "get": {
+                "description": "Lists the API keys of one of the vendor's stores, newest first, with when each was last used. Revoked and expired keys are included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "List my store's API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to the vendor's primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.APIKeyResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Issues a key for the vendor's own systems to call the product and order endpoints of one store, with the chosen scopes (catalog:write, orders:write). Send it in X-API-Key or as a Bearer token. The key is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "Create API Key Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/api-keys/{keyId}": {
+            "delete": {
+                "description": "Stops an API key from working immediately. The key stays listed with its revocation time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.APIKeyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/banner": {
+            "put": {
+                "description": "Uploads a banner for one of the authenticated vendor's stores, replacing the current one",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Upload store banner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Banner image (jpg, jpeg, png, gif, webp)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Removes the banner of one of the authenticated vendor's stores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Remove store banner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/coupons": {
+            "get": {
+                "description": "Lists the coupons of one of the vendor's stores with their usage, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "List my store's coupons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to the vendor's primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CouponResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Adds a percentage or fixed-amount coupon code to one of the vendor's stores. Codes are case-insensitive and unique per store.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Create a coupon",
+                "parameters": [
+                    {
+                        "description": "Create Coupon Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CouponResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/coupons/{couponId}": {
+            "put": {
+                "description": "Changes the fields that are set. A max_uses of 0 removes the limit and empty times clear them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Update a coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon ID",
+                        "name": "couponId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Coupon Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CouponResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Deletes a coupon from one of the vendor's stores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Delete a coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon ID",
+                        "name": "couponId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/logo": {
+            "put": {
+                "description": "Uploads a logo for one of the authenticated vendor's stores, replacing the current one",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Upload store logo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Logo image (jpg, jpeg, png, gif, webp)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Removes the logo of one of the authenticated vendor's stores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Remove store logo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/orders": {
+            "get": {
+                "description": "Lists a store's orders with their payment status and references, newest first, for its owner or order staff",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List my store's orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to the user's primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "unpaid",
+                            "pending",
+                            "paid",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Only orders with this payment status",
+                        "name": "payment_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.OrderResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/orders/{orderId}": {
+            "get": {
+                "description": "Returns an order with every payment attempt, including provider references, for the store's owner or order staff",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get one of my store's orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.OrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "VendorAPIKey": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/reviews": {
+            "get": {
+                "description": "Lists all reviews of a store's products, including hidden ones, for its owner or catalog staff",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "List my store's reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to the vendor's primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/staff": {
+            "get": {
+                "description": "Lists staff members and pending invites of one of the authenticated vendor's stores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "List store staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreMemberResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Invites a staff member by email to one of the authenticated vendor's stores. The returned invite token is shown once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Invite a staff member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Invite Staff Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.InviteStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.InviteStaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/staff/{memberId}": {
+            "put": {
+                "description": "Updates the role of a staff member or pending invite",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Change a staff member's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Role Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateStaffRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Removes a staff member or revokes a pending invite",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Remove a staff member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/webhooks": {
+            "get": {
+                "description": "Lists the webhook endpoints of one of the vendor's stores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List my store's webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store ID (defaults to the vendor's primary store)",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.WebhookEndpointResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Registers a URL to receive signed POSTs for the chosen events (product.created, product.updated, product.deleted, order.created, order.paid). The signing secret is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Create Webhook Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/webhooks/{webhookId}": {
+            "put": {
+                "description": "Changes the fields that are set. With rotate_secret the signing secret is replaced and the new one returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Webhook Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Removes a webhook endpoint and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "description": "Returns a webhook's delivery log, newest first, with the payload, attempts and the outcome of the latest attempt. Finished deliveries are kept for 30 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Only deliveries with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/my/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "description": "Queues a delivery to be sent again right away with a fresh retry schedule, whatever its status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.WebhookDeliveryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/stores/search": {
+            "get": {
+                "description": "Searches stores by store name or owner name/username",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Search stores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/vendor": {
+            "get": {
+                "description": "Retrieves a vendor's primary store and its active products by vendor ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get store by vendor ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}": {
+            "get": {
+                "description": "Retrieves a store and its active products by store slug (WhatsApp shareable link). A slug the store used before being renamed answers with a 301 to the current slug. Responses carry an ETag; send it back in If-None-Match to get 304 Not Modified when nothing changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get store by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug (e.g., pizzahut-lagos)",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: newest, price_asc, price_desc or rating",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a response already held",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the response body"
+                            }
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently to the store's current slug"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/feeds/google.xml": {
+            "get": {
+                "description": "Lists a store's active products with images as an RSS 2.0 feed for Google Merchant Center. Products are out of stock while the store is on vacation. A slug the store used before being renamed answers with a 301 to the current slug.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Google Merchant feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently to the store's current slug"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/feeds/meta.csv": {
+            "get": {
+                "description": "Lists a store's active products with images as a Meta Commerce Manager data feed, for WhatsApp Business catalogs and Instagram shopping. Products are out of stock while the store is on vacation. A slug the store used before being renamed answers with a 301 to the current slug.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Meta Commerce CSV feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently to the store's current slug"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/feeds/meta.xml": {
+            "get": {
+                "description": "Lists a store's active products with images as an RSS feed for Meta Commerce Manager. Products are out of stock while the store is on vacation. A slug the store used before being renamed answers with a 301 to the current slug.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Meta Commerce XML feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently to the store's current slug"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/meta": {
+            "get": {
+                "description": "Returns Open Graph and Twitter card metadata for a store page: name, bio, cover image (banner, else logo) and canonical URL, with the tags ready to render",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SEO"
+                ],
+                "summary": "Store page metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.PageMetaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/products/{productSlug}": {
+            "get": {
+                "description": "Retrieves an active product of a store by the store and product slugs, for product pages. A slug the store used before being renamed answers with a 301 to the current slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Get a store's product by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug (e.g., pizzahut-lagos)",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product slug (e.g., pepperoni-pizza)",
+                        "name": "productSlug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreProductResponse"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently to the store's current slug"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/products/{productSlug}/meta": {
+            "get": {
+                "description": "Returns Open Graph and Twitter card metadata for an active product's page: title, description, first image, current price and canonical URL, with the tags ready to render",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SEO"
+                ],
+                "summary": "Product page metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product slug",
+                        "name": "productSlug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.PageMetaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/products/{productSlug}/qr": {
+            "get": {
+                "description": "Renders a QR code for an active product's storefront link as PNG or SVG, or as a printable A4 PDF flyer with the product's name and price, the store name and WhatsApp number. With logo=true the store logo is drawn in the centre and error correction is raised to at least high.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Product QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product slug",
+                        "name": "productSlug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (default), svg or pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Width and height in pixels, 128-2048 (default 512); ignored for pdf",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "low, medium (default), high or highest",
+                        "name": "error_correction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Draw the store logo in the centre",
+                        "name": "logo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{slug}/qr": {
+            "get": {
+                "description": "Renders a QR code for the store's storefront link as PNG or SVG, or as a printable A4 PDF flyer with the store name and WhatsApp number. With logo=true the store logo is drawn in the centre and error correction is raised to at least high.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Stores"
+                ],
+                "summary": "Store QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (default), svg or pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Width and height in pixels, 128-2048 (default 512); ignored for pdf",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "low, medium (default), high or highest",
+                        "name": "error_correction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Draw the store logo in the centre",
+                        "name": "logo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/products": {
+            "get": {
+                "description": "Retrieves all products for a specific vendor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a vendor's products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: newest, price_asc, price_desc or rating",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/products/active": {
+            "get": {
+                "description": "Retrieves all active products for a specific vendor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get active products for a vendor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: newest, price_asc, price_desc or rating",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payments/{provider}": {
+            "post": {
+                "description": "Endpoint for provider notifications. Paystack events must carry a valid x-paystack-signature; events already processed are acknowledged without changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Receive a payment webhook",
+                "parameters": [
+                    {
+                        "enum": [
+                            "paystack",
+                            "fake"
+                        ],
+                        "type": "string",
+                        "description": "Payment provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_falasefemi2_vendorhub_internal_apperr.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be a valid email address"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Key is only returned when the key is created; it can't be shown again",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the key, to tell keys apart",
+                    "type": "string",
+                    "example": "vhk_3f9a1c07"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "store_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.AcceptInviteRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.AnalyticsDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "product_views": {
+                    "type": "integer"
+                },
+                "store_views": {
+                    "type": "integer"
+                },
+                "whatsapp_clicks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.AnalyticsTotals": {
+            "type": "object",
+            "properties": {
+                "product_views": {
+                    "type": "integer"
+                },
+                "store_views": {
+                    "type": "integer"
+                },
+                "whatsapp_clicks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.AppliedCouponResponse": {
+            "type": "object",
+            "properties": {
+                "amount_off": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "percent_off": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AuthUser"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.AuthUser": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "type": "string"
+                },
+                "store_slug": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "whatsapp_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.BusinessHours": {
+            "type": "object",
+            "properties": {
+                "closes": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "monday"
+                },
+                "opens": {
+                    "type": "string",
+                    "example": "09:00"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CouponResponse": {
+            "type": "object",
+            "properties": {
+                "amount_off": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "percent_off": {
+                    "description": "PercentOff is set for percentage coupons and AmountOff for fixed ones",
+                    "type": "number"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "used_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt is when the key stops working; omit for a key that lasts\nuntil revoked",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Inventory sync"
+                },
+                "scopes": {
+                    "description": "Scopes the key is granted: catalog:write, orders:write",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "store_id": {
+                    "description": "StoreID defaults to the vendor's primary store",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CreateCouponRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "discount_type",
+                "value"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ]
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "description": "MaxUses limits total redemptions; omit for unlimited",
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "string",
+                    "example": "5000"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "StoreID defaults to the vendor's primary store",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the percentage off, or the amount off in the store's currency",
+                    "type": "string",
+                    "example": "10"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Shoes"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "price": {
+                    "description": "Price is in the store's currency, as a decimal number or string",
+                    "type": "string",
+                    "example": "1500.50"
+                },
+                "sale_ends_at": {
+                    "type": "string"
+                },
+                "sale_price": {
+                    "description": "SalePrice is an optional discounted price shown instead of Price,\nwith Price as the compare-at price, between the optional start and end.",
+                    "type": "string",
+                    "example": "1200"
+                },
+                "sale_starts_at": {
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "Slug is derived from the name unless given, and must be unique\nwithin the store",
+                    "type": "string",
+                    "maxLength": 80,
+                    "example": "ankara-dress"
+                },
+                "store_id": {
+                    "description": "StoreID selects the store to create the product in. Defaults to the\ncaller's primary store; staff must always set it.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating",
+                "reviewer_name"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "reviewer_name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CreateStoreRequest": {
+            "type": "object",
+            "required": [
+                "store_name"
+            ],
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Currency is an ISO 4217 code such as NGN, GHS, KES or USD and\ndefaults to NGN",
+                    "type": "string",
+                    "example": "NGN"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "whatsapp_number": {
+                    "type": "string",
+                    "example": "+2348012345678"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "events": {
+                    "description": "Events to subscribe to: product.created, product.updated,\nproduct.deleted, order.created, order.paid",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "store_id": {
+                    "description": "StoreID defaults to the vendor's primary store",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/vendorhub"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number",
+                    "example": 1.42
+                },
+                "error": {
+                    "type": "string",
+                    "example": "timed out"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "failing"
+                    ]
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.HealthCheckResult"
+                    }
+                },
+                "status": {
+                    "description": "Status is \"ok\", \"failing\" when a check failed, or \"draining\" while\nthe server shuts down",
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "failing",
+                        "draining"
+                    ]
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.InitializePaymentRequest": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "description": "CallbackURL is where the provider sends the buyer after checkout",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email defaults to the order's customer email",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.InitializePaymentResponse": {
+            "type": "object",
+            "properties": {
+                "access_code": {
+                    "type": "string"
+                },
+                "amount": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "authorization_url": {
+                    "type": "string"
+                },
+                "order_reference": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.InviteStaffRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.InviteStaffResponse": {
+            "type": "object",
+            "properties": {
+                "invite_token": {
+                    "type": "string"
+                },
+                "member": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreMemberResponse"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.MetaTag": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Ankara dress | Ada Styles"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "twitter:card"
+                },
+                "property": {
+                    "type": "string",
+                    "example": "og:title"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ModerateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 150050
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "NGN"
+                },
+                "formatted": {
+                    "type": "string",
+                    "example": "₦1,500.50"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "1500.50"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "line_total": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "customer_email": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "discount": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.OrderItemResponse"
+                    }
+                },
+                "order_summary": {
+                    "description": "OrderSummary and WhatsappURL are returned when the order is placed",
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment_reference": {
+                    "description": "PaymentReference is the latest payment attempt, or the one that paid",
+                    "type": "string"
+                },
+                "payment_status": {
+                    "type": "string",
+                    "enum": [
+                        "unpaid",
+                        "pending",
+                        "paid",
+                        "failed"
+                    ]
+                },
+                "payments": {
+                    "description": "Payments lists every payment attempt; only included for the store",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.PaymentResponse"
+                    }
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "total": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "whatsapp_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.PageMetaResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "site_name": {
+                    "type": "string",
+                    "example": "VendorHub"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.MetaTag"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Ankara dress | Ada Styles"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "website",
+                        "product"
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "order_reference": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "success",
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.PlaceOrderRequest": {
+            "type": "object",
+            "required": [
+                "customer_name",
+                "items",
+                "store_id"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "customer_email": {
+                    "description": "CustomerEmail is needed to pay online",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "customer_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "customer_phone": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.QuoteItemRequest"
+                    }
+                },
+                "store_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductAnalytics": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                },
+                "whatsapp_clicks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_rows": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImportRowError"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "running",
+                        "completed"
+                    ]
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "description": "TotalRows counts the data rows in the file and ValidRows those that\npassed validation; ProcessedRows of the valid rows have been handled",
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductImportPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImportRowError"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImportRowPreview"
+                    }
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductImportRowError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "product price must be greater than 0"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductImportRowPreview": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "image_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "compare_at_price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "effective_price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImageResponse"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_sale": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "rating_average": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "sale_ends_at": {
+                    "type": "string"
+                },
+                "sale_price": {
+                    "description": "EffectivePrice is what the buyer pays now; CompareAtPrice is only\nset while a sale is running",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                        }
+                    ]
+                },
+                "sale_starts_at": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.QuoteItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.QuoteLineResponse": {
+            "type": "object",
+            "properties": {
+                "line_total": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_sale": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "regular_unit_price": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "unit_price": {
+                    "description": "UnitPrice is what the buyer pays; RegularUnitPrice differs from it\nwhile the product is on sale",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.QuoteRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "store_id"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.QuoteItemRequest"
+                    }
+                },
+                "store_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "coupon": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AppliedCouponResponse"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.QuoteLineResponse"
+                    }
+                },
+                "order_summary": {
+                    "description": "OrderSummary is the text to prefill the WhatsApp message with",
+                    "type": "string"
+                },
+                "sale_savings": {
+                    "description": "SaleSavings is how much the running sales take off the regular prices",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                        }
+                    ]
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "total": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.Money"
+                },
+                "whatsapp_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ReviewReplyRequest": {
+            "type": "object",
+            "properties": {
+                "reply": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "reviewer_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "vendor_replied_at": {
+                    "type": "string"
+                },
+                "vendor_reply": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.SignUpRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "store_name",
+                "username",
+                "whatsapp_number"
+            ],
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "store_currency": {
+                    "description": "StoreCurrency is an ISO 4217 code and defaults to NGN",
+                    "type": "string",
+                    "example": "NGN"
+                },
+                "store_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "store_slug": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "whatsapp_number": {
+                    "type": "string",
+                    "example": "+2348012345678"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.StoreAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AnalyticsDay"
+                    }
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "top_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductAnalytics"
+                    }
+                },
+                "totals": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.AnalyticsTotals"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                    }
+                },
+                "store": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                },
+                "store_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.StoreMemberResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "type": "string"
+                },
+                "store_slug": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.StoreProductResponse": {
+            "type": "object",
+            "properties": {
+                "product": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                },
+                "product_url": {
+                    "type": "string"
+                },
+                "store": {
+                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.StoreResponse": {
+            "type": "object",
+            "properties": {
+                "accent_color": {
+                    "type": "string"
+                },
+                "accepting_orders": {
+                    "type": "boolean"
+                },
+                "banner_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "block_orders_when_closed": {
+                    "type": "boolean"
+                },
+                "business_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.BusinessHours"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "delivery_notes": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "facebook_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instagram_url": {
+                    "type": "string"
+                },
+                "is_open_now": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "logo_url": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "role": {
+                "on_vacation": {
+                    "type": "boolean"
+                },
+                "opening_hours": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "primary_color": {
+                    "type": "string"
+                },
+                "rating_average": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "tiktok_url": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 },
-                "store_name": {
+                "vacation_ends_at": {
                     "type": "string"
                 },
-                "store_slug": {
+                "vacation_message": {
                     "type": "string"
                 },
-                "username": {
+                "vacation_mode": {
+                    "type": "boolean"
+                },
+                "vacation_starts_at": {
                     "type": "string"
                 },
                 "whatsapp_number": {
@@ -1484,247 +6500,372 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.CreateProductRequest": {
+        "github_com_falasefemi2_vendorhub_internal_dto.ToggleProductStatusRequest": {
             "type": "object",
-            "required": [
-                "description",
-                "name",
-                "price"
-            ],
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 1
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "price": {
-                    "type": "number"
+                "is_active": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.LoginRequest": {
+        "github_com_falasefemi2_vendorhub_internal_dto.TrackEventRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "store_id",
+                "type"
             ],
             "properties": {
-                "email": {
+                "product_id": {
                     "type": "string"
                 },
-                "password": {
+                "store_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "store_view"
+                },
+                "visitor_id": {
+                    "description": "VisitorID is an optional anonymous ID kept by the storefront, e.g. in\nlocalStorage. Without it visitors are told apart by IP and user agent.",
                     "type": "string"
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.ProductImageResponse": {
+        "github_com_falasefemi2_vendorhub_internal_dto.UpdateCouponRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "code": {
+                    "type": "string",
+                    "maxLength": 40
                 },
-                "image_url": {
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ]
+                },
+                "expires_at": {
                     "type": "string"
                 },
-                "position": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
                     "type": "integer"
+                },
+                "min_spend": {
+                    "type": "string",
+                    "example": "5000"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "10"
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.ProductResponse": {
+        "github_com_falasefemi2_vendorhub_internal_dto.UpdateProductRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "category": {
+                    "description": "Category of \"\" removes the product from its category",
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductImageResponse"
-                    }
+                    "type": "string",
+                    "maxLength": 1000
                 },
                 "is_active": {
                     "type": "boolean"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "price": {
-                    "type": "number"
+                    "type": "string",
+                    "example": "1500.50"
                 },
-                "updated_at": {
+                "sale_ends_at": {
                     "type": "string"
                 },
-                "user_id": {
+                "sale_price": {
+                    "description": "SalePrice of 0 ends the sale. Empty sale times clear them.",
+                    "type": "string",
+                    "example": "1200"
+                },
+                "sale_starts_at": {
                     "type": "string"
+                },
+                "slug": {
+                    "description": "Slug keeps its value when the product is renamed; changing it breaks\nlinks already shared",
+                    "type": "string",
+                    "example": "ankara-dress"
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.SignUpRequest": {
+        "github_com_falasefemi2_vendorhub_internal_dto.UpdateStaffRoleRequest": {
             "type": "object",
             "required": [
-                "email",
-                "name",
-                "password",
-                "store_name",
-                "username",
-                "whatsapp_number"
+                "role"
             ],
             "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.UpdateStoreRequest": {
+            "type": "object",
+            "properties": {
+                "accent_color": {
+                    "type": "string",
+                    "example": "#FBBC04"
+                },
                 "bio": {
                     "type": "string"
                 },
-                "email": {
+                "block_orders_when_closed": {
+                    "type": "boolean"
+                },
+                "business_hours": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.BusinessHours"
+                    }
+                },
+                "currency": {
+                    "description": "Changing the currency keeps prices at the same decimal value",
+                    "type": "string",
+                    "example": "GHS"
+                },
+                "delivery_notes": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "facebook_url": {
                     "type": "string"
                 },
-                "name": {
+                "instagram_url": {
                     "type": "string"
                 },
-                "password": {
+                "location": {
                     "type": "string",
-                    "minLength": 8
+                    "maxLength": 255
                 },
-                "store_name": {
+                "opening_hours": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "primary_color": {
+                    "type": "string",
+                    "example": "#1A73E8"
+                },
+                "slug": {
                     "type": "string"
                 },
-                "username": {
+                "store_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "tiktok_url": {
                     "type": "string"
                 },
+                "timezone": {
+                    "type": "string",
+                    "example": "Africa/Lagos"
+                },
+                "vacation_ends_at": {
+                    "type": "string",
+                    "example": "2026-01-05T00:00:00Z"
+                },
+                "vacation_message": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "vacation_mode": {
+                    "type": "boolean"
+                },
+                "vacation_starts_at": {
+                    "type": "string",
+                    "example": "2025-12-20T00:00:00Z"
+                },
                 "whatsapp_number": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "+2348012345678"
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.StoreDetailsResponse": {
+        "github_com_falasefemi2_vendorhub_internal_dto.UpdateWebhookRequest": {
             "type": "object",
             "properties": {
-                "products": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.ProductResponse"
+                        "type": "string"
                     }
                 },
-                "store": {
-                    "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_dto.StoreResponse"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "store_url": {
+                "rotate_secret": {
+                    "type": "boolean"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.StoreResponse": {
+        "github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageRequest": {
             "type": "object",
             "properties": {
-                "bio": {
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
+                "image_url": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_falasefemi2_vendorhub_internal_dto.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "id": {
+                "endpoint_id": {
                     "type": "string"
                 },
-                "name": {
+                "event_id": {
                     "type": "string"
                 },
-                "slug": {
+                "event_type": {
                     "type": "string"
                 },
-                "username": {
+                "id": {
                     "type": "string"
                 },
-                "whatsapp_number": {
+                "last_attempt_at": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_falasefemi2_vendorhub_internal_dto.ToggleProductStatusRequest": {
-            "type": "object",
-            "properties": {
-                "is_active": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "github_com_falasefemi2_vendorhub_internal_dto.UpdateProductRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "last_error": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "last_status_code": {
+                    "type": "integer"
                 },
-                "name": {
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is set while the delivery is pending",
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "succeeded",
+                        "failed"
+                    ]
                 }
             }
         },
-        "github_com_falasefemi2_vendorhub_internal_dto.UpdateStoreRequest": {
+        "github_com_falasefemi2_vendorhub_internal_dto.WebhookEndpointResponse": {
             "type": "object",
             "properties": {
-                "bio": {
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "description": {
                     "type": "string"
                 },
-                "store_name": {
-                    "type": "string"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "username": {
+                "id": {
                     "type": "string"
                 },
-                "whatsapp_number": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "secret": {
+                    "description": "Secret is only returned when the endpoint is created or its secret\nrotated; keep it to verify signatures",
                     "type": "string"
-                }
-            }
-        },
-        "github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageRequest": {
-            "type": "object",
-            "properties": {
-                "position": {
-                    "type": "integer",
-                    "minimum": 0
-                }
-            }
-        },
-        "github_com_falasefemi2_vendorhub_internal_dto.UploadProductImageResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
+                },
+                "store_id": {
                     "type": "string"
                 },
-                "image_url": {
+                "updated_at": {
                     "type": "string"
                 },
-                "position": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
                 }
             }
         },
         "github_com_falasefemi2_vendorhub_internal_utils.ErrorResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "product_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "product not found"
+                },
                 "error": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "product not found"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_falasefemi2_vendorhub_internal_apperr.FieldError"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
                 }
             }
         }
@@ -1734,6 +6875,11 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "VendorAPIKey": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
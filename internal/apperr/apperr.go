@@ -0,0 +1,84 @@
+// Package apperr defines the errors that report a problem with a request
+// rather than a fault of the server, each with the HTTP status and the
+// stable code clients see.
+package apperr
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Kind is the class of problem an error reports
+type Kind int
+
+const (
+	KindValidation Kind = iota + 1
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindTooLarge
+)
+
+// Status returns the HTTP status a problem of kind k is reported with
+func (k Kind) Status() int {
+	switch k {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// FieldError reports what's wrong with one field of a request
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Message string `json:"message" example:"must be a valid email address"`
+}
+
+// Error is a problem with a request. Code identifies it to clients and
+// stays the same when Message is reworded, so two errors with the same
+// code match under errors.Is.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// New returns an error of kind reported as code. Services usually wrap a
+// package level one with fmt.Errorf("%w: ...") to add detail.
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Invalid returns a validation error listing every invalid field
+func Invalid(fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: "validation_failed", Message: "invalid request", Fields: fields}
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	details := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		details[i] = field.Field + " " + field.Message
+	}
+	return e.Message + ": " + strings.Join(details, "; ")
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
@@ -0,0 +1,18 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache keeps values for a while. LRU suits a single instance; replicas
+// share a Redis cache so a change made through one is seen by all.
+// Callers treat errors as misses: the cache only saves work.
+type Cache interface {
+	// Get returns the value of key, and false when there is none
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set keeps value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete drops keys, ignoring those that aren't set
+	Delete(ctx context.Context, keys ...string) error
+}
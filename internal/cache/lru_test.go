@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+
+	type op struct {
+		set    string
+		ttl    time.Duration
+		get    string
+		delete string
+		want   bool
+	}
+	tests := []struct {
+		name string
+		ops  []op
+	}{
+		{
+			name: "get what was set",
+			ops:  []op{{set: "a", ttl: time.Minute}, {get: "a", want: true}, {get: "b", want: false}},
+		},
+		{
+			name: "expired entries are misses",
+			ops:  []op{{set: "a", ttl: -time.Second}, {get: "a", want: false}},
+		},
+		{
+			name: "least recently used is dropped",
+			ops: []op{
+				{set: "a", ttl: time.Minute},
+				{set: "b", ttl: time.Minute},
+				{get: "a", want: true},
+				{set: "c", ttl: time.Minute},
+				{get: "b", want: false},
+				{get: "a", want: true},
+				{get: "c", want: true},
+			},
+		},
+		{
+			name: "setting again refreshes",
+			ops: []op{
+				{set: "a", ttl: time.Minute},
+				{set: "b", ttl: time.Minute},
+				{set: "a", ttl: time.Minute},
+				{set: "c", ttl: time.Minute},
+				{get: "a", want: true},
+				{get: "b", want: false},
+			},
+		},
+		{
+			name: "delete",
+			ops: []op{
+				{set: "a", ttl: time.Minute},
+				{delete: "a"},
+				{delete: "missing"},
+				{get: "a", want: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU(2)
+			for i, op := range tt.ops {
+				switch {
+				case op.set != "":
+					c.Set(ctx, op.set, []byte(op.set), op.ttl)
+				case op.delete != "":
+					c.Delete(ctx, op.delete)
+				default:
+					value, ok, err := c.Get(ctx, op.get)
+					if err != nil || ok != op.want {
+						t.Fatalf("op %d: Get(%q) = %t, %v, want %t", i, op.get, ok, err, op.want)
+					}
+					if ok && string(value) != op.get {
+						t.Errorf("op %d: Get(%q) = %q", i, op.get, value)
+					}
+				}
+			}
+			if c.order.Len() != len(c.entries) || len(c.entries) > 2 {
+				t.Errorf("LRU holds %d entries in order and %d by key", c.order.Len(), len(c.entries))
+			}
+		})
+	}
+}
@@ -1,50 +1,434 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"go.yaml.in/yaml/v3"
 )
 
-func Load() {
-	err := godotenv.Load()
-	if err != nil {
-		err = godotenv.Load("../../.env")
-		if err != nil {
-			fmt.Println("Warning: .env file not found, using system environment variables")
+// Config holds every setting of the server. Each field is read from the
+// environment variable named in its comment, and can also be set in the
+// YAML file named by CONFIG_FILE under its yaml key. Environment variables,
+// including those from .env, take precedence over the file.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Storage   StorageConfig   `yaml:"storage"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Payment   PaymentConfig   `yaml:"payment"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	Log       LogConfig       `yaml:"log"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Health    HealthConfig    `yaml:"health"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Cache     CacheConfig     `yaml:"cache"`
+
+	// EnvFile is the .env file that was read, empty when there was none
+	EnvFile string `yaml:"-"`
+}
+
+type ServerConfig struct {
+	// Environment is "production" or "development" (APP_ENV), production
+	// by default. Development allows settings that are unsafe in
+	// production, such as the fake payment provider.
+	Environment string `yaml:"environment"`
+	// Port to listen on (PORT), 8080 by default
+	Port int `yaml:"port"`
+	// BaseURL the API is reached at, used for the Swagger UI (BASE_URL).
+	// Empty means http://localhost:8080.
+	BaseURL string `yaml:"base_url"`
+	// FrontendURL is the public web storefront that store links point at
+	// (FRONTEND_URL). It is always an allowed CORS origin.
+	FrontendURL string `yaml:"frontend_url"`
+	// AllowedOrigins are CORS origins allowed besides the storefront and
+	// local development servers (ALLOWED_ORIGINS, comma separated)
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// ClientIPHeader names the header a trusted reverse proxy puts the
+	// client address in, e.g. X-Forwarded-For (CLIENT_IP_HEADER). Without
+	// one the connection's address is used. Don't set it when clients can
+	// reach the server directly, as they could send any address.
+	ClientIPHeader string `yaml:"client_ip_header"`
+	// ReadTimeout (SERVER_READ_TIMEOUT), WriteTimeout (SERVER_WRITE_TIMEOUT)
+	// and IdleTimeout (SERVER_IDLE_TIMEOUT) bound connections, 15s, 15s and
+	// 60s by default
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// ShutdownDrainDelay is how long the server keeps serving after a
+	// shutdown signal while /readyz fails, so load balancers stop sending
+	// it traffic first (SHUTDOWN_DRAIN_DELAY), 0 by default
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay"`
+}
+
+type DatabaseConfig struct {
+	// URL is the Postgres connection string (DATABASE_URL), required
+	URL string `yaml:"url"`
+}
+
+type StorageConfig struct {
+	// SupabaseURL (SUPABASE_URL) and SupabaseKey (SUPABASE_KEY) are
+	// required
+	SupabaseURL string `yaml:"supabase_url"`
+	SupabaseKey string `yaml:"supabase_key"`
+	// Bucket product images are stored in (SUPABASE_BUCKET), "products"
+	// by default
+	Bucket string `yaml:"bucket"`
+}
+
+type AuthConfig struct {
+	// JWTSecret signs and verifies auth tokens (JWT_SECRET), required and
+	// at least 32 characters long
+	JWTSecret string `yaml:"jwt_secret"`
+	// AnalyticsSalt is the secret mixed into hashed visitor identifiers
+	// (ANALYTICS_SALT), falling back to JWTSecret so deployments work
+	// without extra setup
+	AnalyticsSalt string `yaml:"analytics_salt"`
+}
+
+type PaymentConfig struct {
+	// Provider is "none", "paystack" or "fake" (PAYMENT_PROVIDER), none
+	// by default: orders are then settled by hand and the payment routes
+	// aren't served. The fake provider marks any payment paid, so it is
+	// only allowed with APP_ENV=development.
+	Provider string `yaml:"provider"`
+	// PaystackSecretKey (PAYSTACK_SECRET_KEY) is required for paystack
+	PaystackSecretKey string `yaml:"paystack_secret_key"`
+	// CallbackURL is where buyers land after checkout when the storefront
+	// doesn't pass its own callback URL (PAYMENT_CALLBACK_URL)
+	CallbackURL string `yaml:"callback_url"`
+}
+
+type WebhooksConfig struct {
+	// AllowPrivateNetworks lets webhooks be delivered to loopback, private
+	// and other non-public addresses, which is only wanted in development
+	// (WEBHOOK_ALLOW_PRIVATE_NETWORKS)
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
+type LogConfig struct {
+	// Level is the minimum level of log records, "debug", "info", "warn"
+	// or "error" (LOG_LEVEL), info by default
+	Level slog.Level `yaml:"level"`
+	// Format is "json" or "text" for reading in a terminal (LOG_FORMAT),
+	// json by default
+	Format string `yaml:"format"`
+}
+
+type MetricsConfig struct {
+	// Token is the bearer token Prometheus must send to scrape /metrics
+	// (METRICS_TOKEN). Without one the endpoint is open, which is only
+	// wanted when it isn't reachable from the internet.
+	Token string `yaml:"token"`
+	// PprofEnabled serves the pprof profiling endpoints to admins under
+	// /admin/debug/pprof (PPROF_ENABLED)
+	PprofEnabled bool `yaml:"pprof_enabled"`
+}
+
+type TracingConfig struct {
+	// Exporter is where trace spans are sent: "otlp" to an OpenTelemetry
+	// collector, "console" to print them to stdout for local debugging, or
+	// "none" to turn tracing off (OTEL_TRACES_EXPORTER), none by default
+	Exporter string `yaml:"exporter"`
+}
+
+type RateLimitConfig struct {
+	// Enabled turns rate limiting on (RATE_LIMIT_ENABLED), true by default
+	Enabled bool `yaml:"enabled"`
+	// RedisURL is a redis:// or rediss:// URL of the Redis server replicas
+	// share rate limits through (RATE_LIMIT_REDIS_URL). Without one each
+	// instance counts requests in memory.
+	RedisURL string `yaml:"redis_url"`
+}
+
+type CacheConfig struct {
+	// Enabled turns caching of public catalog responses on
+	// (CACHE_ENABLED), true by default
+	Enabled bool `yaml:"enabled"`
+	// TTL bounds how long a cached response is served (CACHE_TTL), 1m by
+	// default. Product and store changes drop the responses they affect
+	// sooner.
+	TTL time.Duration `yaml:"ttl"`
+	// MaxEntries is how many responses each instance keeps in memory
+	// (CACHE_MAX_ENTRIES), 1000 by default
+	MaxEntries int `yaml:"max_entries"`
+	// RedisURL is a redis:// or rediss:// URL of the Redis server replicas
+	// share the cache through (CACHE_REDIS_URL). Without one each instance
+	// caches in memory, and only sees its own changes before the TTL.
+	RedisURL string `yaml:"redis_url"`
+}
+
+type HealthConfig struct {
+	// CheckTimeout is how long each readiness check may take before it
+	// counts as failed (HEALTH_CHECK_TIMEOUT), 2s by default
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+}
+
+// minJWTSecretLength is the shortest JWT_SECRET accepted, 256 bits for
+// HMAC-SHA256
+const minJWTSecretLength = 32
+
+// Default returns the configuration used for settings that aren't set
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Environment:  "production",
+			Port:         8080,
+			FrontendURL:  "https://vendorhub-v2-frontend.vercel.app",
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
+		Storage:   StorageConfig{Bucket: "products"},
+		Payment:   PaymentConfig{Provider: "none"},
+		Log:       LogConfig{Level: slog.LevelInfo, Format: "json"},
+		Tracing:   TracingConfig{Exporter: "none"},
+		Health:    HealthConfig{CheckTimeout: 2 * time.Second},
+		RateLimit: RateLimitConfig{Enabled: true},
+		Cache:     CacheConfig{Enabled: true, TTL: time.Minute, MaxEntries: 1000},
+	}
+}
+
+// Load reads the configuration from the defaults, the YAML file named by
+// CONFIG_FILE, .env and the environment, in increasing precedence. The
+// error lists every invalid or missing setting.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if err := godotenv.Load(); err == nil {
+		cfg.EnvFile = ".env"
+	} else if err := godotenv.Load("../../.env"); err == nil {
+		cfg.EnvFile = "../../.env"
+	}
+
+	var env envReader
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			env.errs = append(env.errs, err)
 		}
 	}
+
+	env.string("APP_ENV", &cfg.Server.Environment)
+	env.int("PORT", &cfg.Server.Port)
+	env.string("BASE_URL", &cfg.Server.BaseURL)
+	env.string("FRONTEND_URL", &cfg.Server.FrontendURL)
+	env.list("ALLOWED_ORIGINS", &cfg.Server.AllowedOrigins)
+	env.string("CLIENT_IP_HEADER", &cfg.Server.ClientIPHeader)
+	env.duration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	env.duration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	env.duration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	env.duration("SHUTDOWN_DRAIN_DELAY", &cfg.Server.ShutdownDrainDelay)
+	env.string("DATABASE_URL", &cfg.Database.URL)
+	env.string("SUPABASE_URL", &cfg.Storage.SupabaseURL)
+	env.string("SUPABASE_KEY", &cfg.Storage.SupabaseKey)
+	env.string("SUPABASE_BUCKET", &cfg.Storage.Bucket)
+	env.string("JWT_SECRET", &cfg.Auth.JWTSecret)
+	env.string("ANALYTICS_SALT", &cfg.Auth.AnalyticsSalt)
+	env.string("PAYMENT_PROVIDER", &cfg.Payment.Provider)
+	env.string("PAYSTACK_SECRET_KEY", &cfg.Payment.PaystackSecretKey)
+	env.string("PAYMENT_CALLBACK_URL", &cfg.Payment.CallbackURL)
+	env.bool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", &cfg.Webhooks.AllowPrivateNetworks)
+	env.level("LOG_LEVEL", &cfg.Log.Level)
+	env.string("LOG_FORMAT", &cfg.Log.Format)
+	env.string("METRICS_TOKEN", &cfg.Metrics.Token)
+	env.bool("PPROF_ENABLED", &cfg.Metrics.PprofEnabled)
+	env.string("OTEL_TRACES_EXPORTER", &cfg.Tracing.Exporter)
+	env.duration("HEALTH_CHECK_TIMEOUT", &cfg.Health.CheckTimeout)
+	env.bool("RATE_LIMIT_ENABLED", &cfg.RateLimit.Enabled)
+	env.string("RATE_LIMIT_REDIS_URL", &cfg.RateLimit.RedisURL)
+	env.bool("CACHE_ENABLED", &cfg.Cache.Enabled)
+	env.duration("CACHE_TTL", &cfg.Cache.TTL)
+	env.int("CACHE_MAX_ENTRIES", &cfg.Cache.MaxEntries)
+	env.string("CACHE_REDIS_URL", &cfg.Cache.RedisURL)
+
+	cfg.applyFallbacks()
+	return cfg, errors.Join(append(env.errs, cfg.Validate())...)
+}
+
+// loadFile overlays the settings set in a YAML file. Unknown keys are
+// rejected so typos don't go unnoticed.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
 }
 
-func GetDBURL() string {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		panic("DATABASE_URL environment variable is not set")
+// applyFallbacks fills in settings whose defaults depend on others
+func (c *Config) applyFallbacks() {
+	if c.Auth.AnalyticsSalt == "" {
+		c.Auth.AnalyticsSalt = c.Auth.JWTSecret
 	}
-	return dbURL
+	c.Server.FrontendURL = strings.TrimSuffix(c.Server.FrontendURL, "/")
 }
 
-func GetSupabaseURL() string {
-	url := os.Getenv("SUPABASE_URL")
-	if url == "" {
-		panic("SUPABASE_URL environment variable is not set")
+// Validate reports every missing or invalid setting
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Environment == "production" || c.Server.Environment == "development", "APP_ENV must be production or development, got %q", c.Server.Environment)
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "PORT must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.BaseURL == "" || isHTTPURL(c.Server.BaseURL), "BASE_URL must be an http or https URL, got %q", c.Server.BaseURL)
+	check(isHTTPURL(c.Server.FrontendURL), "FRONTEND_URL must be an http or https URL, got %q", c.Server.FrontendURL)
+	for _, origin := range c.Server.AllowedOrigins {
+		check(isHTTPURL(origin), "ALLOWED_ORIGINS must be http or https URLs, got %q", origin)
+	}
+	check(c.Server.ReadTimeout > 0, "SERVER_READ_TIMEOUT must be positive")
+	check(c.Server.WriteTimeout > 0, "SERVER_WRITE_TIMEOUT must be positive")
+	check(c.Server.IdleTimeout > 0, "SERVER_IDLE_TIMEOUT must be positive")
+	check(c.Server.ShutdownDrainDelay >= 0, "SHUTDOWN_DRAIN_DELAY must not be negative")
+
+	check(c.Database.URL != "", "DATABASE_URL is not set")
+	check(c.Storage.SupabaseURL != "", "SUPABASE_URL is not set")
+	check(c.Storage.SupabaseKey != "", "SUPABASE_KEY is not set")
+	check(c.Storage.Bucket != "", "SUPABASE_BUCKET must not be empty")
+	if c.Auth.JWTSecret == "" {
+		check(false, "JWT_SECRET is not set")
+	} else {
+		check(len(c.Auth.JWTSecret) >= minJWTSecretLength, "JWT_SECRET must be at least %d characters", minJWTSecretLength)
+	}
+
+	switch c.Payment.Provider {
+	case "none":
+	case "paystack":
+		check(c.Payment.PaystackSecretKey != "", "PAYSTACK_SECRET_KEY is not set")
+	case "fake":
+		check(c.Server.IsDevelopment(), "PAYMENT_PROVIDER=fake is only allowed with APP_ENV=development")
+	default:
+		check(false, "PAYMENT_PROVIDER must be none, paystack or fake, got %q", c.Payment.Provider)
+	}
+
+	check(c.Log.Format == "json" || c.Log.Format == "text", "LOG_FORMAT must be json or text, got %q", c.Log.Format)
+	switch c.Tracing.Exporter {
+	case "none", "otlp", "console":
+	default:
+		check(false, "OTEL_TRACES_EXPORTER must be none, otlp or console, got %q", c.Tracing.Exporter)
 	}
-	return url
+	check(c.Health.CheckTimeout > 0, "HEALTH_CHECK_TIMEOUT must be positive")
+	check(c.RateLimit.RedisURL == "" || isRedisURL(c.RateLimit.RedisURL), "RATE_LIMIT_REDIS_URL must be a redis:// or rediss:// URL")
+	check(c.Cache.TTL > 0, "CACHE_TTL must be positive")
+	check(c.Cache.MaxEntries > 0, "CACHE_MAX_ENTRIES must be positive")
+	check(c.Cache.RedisURL == "" || isRedisURL(c.Cache.RedisURL), "CACHE_REDIS_URL must be a redis:// or rediss:// URL")
+
+	return errors.Join(errs...)
+}
+
+// IsDevelopment reports whether the server runs for local development
+func (s ServerConfig) IsDevelopment() bool {
+	return s.Environment == "development"
+}
+
+// Addr returns the address to listen on
+func (s ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(s.Port)
 }
 
-func GetSupabaseKey() string {
-	key := os.Getenv("SUPABASE_KEY")
-	if key == "" {
-		panic("SUPABASE_KEY environment variable is not set")
+// CORSOrigins returns the allowed CORS origins: local development
+// servers, the storefront and AllowedOrigins
+func (s ServerConfig) CORSOrigins() []string {
+	origins := []string{"http://localhost:3000", "http://localhost:3001", s.FrontendURL}
+	return append(origins, s.AllowedOrigins...)
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func isRedisURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "redis" || u.Scheme == "rediss")
+}
+
+// envReader overlays settings with the environment variables that are
+// set, collecting the ones that can't be parsed
+type envReader struct {
+	errs []error
+}
+
+func (r *envReader) string(name string, dst *string) {
+	if value := os.Getenv(name); value != "" {
+		*dst = value
+	}
+}
+
+func (r *envReader) list(name string, dst *[]string) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	*dst = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*dst = append(*dst, item)
+		}
 	}
-	return key
 }
 
-func GetSupabaseBucket() string {
-	bucket := os.Getenv("SUPABASE_BUCKET")
-	if bucket == "" {
-		return "products" // Default bucket name
+func (r *envReader) int(name string, dst *int) {
+	r.parse(name, func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err == nil {
+			*dst = n
+		}
+		return err
+	})
+}
+
+func (r *envReader) bool(name string, dst *bool) {
+	r.parse(name, func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err == nil {
+			*dst = b
+		}
+		return err
+	})
+}
+
+func (r *envReader) duration(name string, dst *time.Duration) {
+	r.parse(name, func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err == nil {
+			*dst = d
+		}
+		return err
+	})
+}
+
+func (r *envReader) level(name string, dst *slog.Level) {
+	r.parse(name, func(value string) error {
+		return dst.UnmarshalText([]byte(value))
+	})
+}
+
+func (r *envReader) parse(name string, parse func(value string) error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	if err := parse(value); err != nil {
+		r.errs = append(r.errs, fmt.Errorf("invalid %s %q", name, value))
 	}
-	return bucket
 }
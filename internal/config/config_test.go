@@ -0,0 +1,137 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// validConfig returns the defaults with every required setting filled in
+func validConfig() *Config {
+	cfg := Default()
+	cfg.Database.URL = "postgres://localhost/vendorhub"
+	cfg.Storage.SupabaseURL = "https://project.supabase.co"
+	cfg.Storage.SupabaseKey = "key"
+	cfg.Auth.JWTSecret = strings.Repeat("s", minJWTSecretLength)
+	return cfg
+}
+
+func TestValidatePaymentProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "disabled by default",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "paystack with a secret key",
+			modify: func(c *Config) {
+				c.Payment.Provider = "paystack"
+				c.Payment.PaystackSecretKey = "sk_test_123"
+			},
+		},
+		{
+			name:    "paystack without a secret key",
+			modify:  func(c *Config) { c.Payment.Provider = "paystack" },
+			wantErr: "PAYSTACK_SECRET_KEY is not set",
+		},
+		{
+			name:    "fake in production",
+			modify:  func(c *Config) { c.Payment.Provider = "fake" },
+			wantErr: "PAYMENT_PROVIDER=fake is only allowed with APP_ENV=development",
+		},
+		{
+			name: "fake in development",
+			modify: func(c *Config) {
+				c.Server.Environment = "development"
+				c.Payment.Provider = "fake"
+			},
+		},
+		{
+			name:    "unknown provider",
+			modify:  func(c *Config) { c.Payment.Provider = "stripe" },
+			wantErr: `PAYMENT_PROVIDER must be none, paystack or fake, got "stripe"`,
+		},
+		{
+			name:    "unknown environment",
+			modify:  func(c *Config) { c.Server.Environment = "staging" },
+			wantErr: `APP_ENV must be production or development, got "staging"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+			cfg.applyFallbacks()
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadLeavesPaymentsDisabled(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/vendorhub")
+	t.Setenv("SUPABASE_URL", "https://project.supabase.co")
+	t.Setenv("SUPABASE_KEY", "key")
+	t.Setenv("JWT_SECRET", strings.Repeat("s", minJWTSecretLength))
+	t.Setenv("PAYSTACK_SECRET_KEY", "")
+	t.Setenv("PAYMENT_PROVIDER", "")
+	t.Setenv("CONFIG_FILE", "")
+
+	// Deployments without a payment provider keep starting after upgrades,
+	// and never fall back to the fake provider
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Payment.Provider != "none" {
+		t.Errorf("Payment.Provider = %q, want none", cfg.Payment.Provider)
+	}
+}
+
+func TestValidateJWTSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr string
+	}{
+		{name: "long enough", secret: strings.Repeat("s", minJWTSecretLength)},
+		{name: "missing", secret: "", wantErr: "JWT_SECRET is not set"},
+		{name: "too short", secret: "supersecretkey", wantErr: "JWT_SECRET must be at least 32 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.Auth.JWTSecret = tt.secret
+			cfg.Auth.AnalyticsSalt = ""
+			cfg.applyFallbacks()
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				if cfg.Auth.AnalyticsSalt != tt.secret {
+					t.Errorf("AnalyticsSalt = %q, want it to fall back to JWT_SECRET", cfg.Auth.AnalyticsSalt)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
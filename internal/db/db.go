@@ -2,15 +2,28 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func ConnectAndMigrate(ctx context.Context, connString string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(ctx, connString)
+// ConnectAndMigrate opens the connection pool and applies schema.sql.
+// Queries are logged to logger at debug level and traced, along with
+// waits for a free connection.
+func ConnectAndMigrate(ctx context.Context, connString string, logger *slog.Logger) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("connect error: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = multitracer.New(queryTracer{logger: logger}, newSpanTracer())
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("connect error: %w", err)
 	}
@@ -20,6 +33,34 @@ func ConnectAndMigrate(ctx context.Context, connString string) (*pgxpool.Pool, e
 		return nil, fmt.Errorf("ping error: %w", err)
 	}
 
+	schema, err := readSchema()
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	logger.Info("running migration")
+
+	_, err = pool.Exec(ctx, schema)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrate error: %w", err)
+	}
+
+	version := schemaVersion(schema)
+	_, err = pool.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT (version) DO NOTHING`, version)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("record schema version: %w", err)
+	}
+
+	logger.Info("migration completed", "schema_version", version)
+	return pool, nil
+}
+
+// readSchema loads schema.sql, relative to the repository root or to
+// cmd/server
+func readSchema() (string, error) {
 	schemaPath := filepath.Join("internal", "db", "schema.sql")
 
 	if _, err := os.Stat(schemaPath); os.IsNotExist(err) {
@@ -28,24 +69,47 @@ func ConnectAndMigrate(ctx context.Context, connString string) (*pgxpool.Pool, e
 
 	sqlBytes, err := os.ReadFile(schemaPath)
 	if err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("read schema: %w", err)
+		return "", fmt.Errorf("read schema: %w", err)
 	}
 
 	sqlString := string(sqlBytes)
 	if sqlString == "" {
-		pool.Close()
-		return nil, fmt.Errorf("schema.sql is empty")
+		return "", fmt.Errorf("schema.sql is empty")
 	}
+	return sqlString, nil
+}
 
-	fmt.Printf("Running migration...\n")
+// schemaVersion identifies a schema by the start of its SHA-256
+func schemaVersion(schema string) string {
+	sum := sha256.Sum256([]byte(schema))
+	return hex.EncodeToString(sum[:])[:12]
+}
 
-	_, err = pool.Exec(ctx, sqlString)
+// SchemaChecker reports whether the schema this instance was built with
+// has been applied to the database
+type SchemaChecker struct {
+	pool    *pgxpool.Pool
+	version string
+}
+
+func NewSchemaChecker(pool *pgxpool.Pool) (*SchemaChecker, error) {
+	schema, err := readSchema()
 	if err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("migrate error: %w", err)
+		return nil, err
 	}
+	return &SchemaChecker{pool: pool, version: schemaVersion(schema)}, nil
+}
 
-	fmt.Println("Migration completed successfully")
-	return pool, nil
+// CheckHealth fails when the database has no record of this instance's
+// schema version, e.g. after being restored from an older backup
+func (c *SchemaChecker) CheckHealth(ctx context.Context) error {
+	var applied bool
+	err := c.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, c.version).Scan(&applied)
+	if err != nil {
+		return fmt.Errorf("failed to check schema version: %w", err)
+	}
+	if !applied {
+		return fmt.Errorf("schema version %s has not been applied", c.version)
+	}
+	return nil
 }
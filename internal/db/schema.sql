@@ -9,26 +9,123 @@ CREATE TABLE IF NOT EXISTS users (
     bio TEXT,
     role VARCHAR(10) NOT NULL DEFAULT 'vendor',
     is_active BOOLEAN DEFAULT FALSE,
-    store_name VARCHAR(255),
-    store_slug VARCHAR(255),
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 
+CREATE TABLE IF NOT EXISTS stores (
+    id CHAR(36) PRIMARY KEY,
+    owner_id CHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    bio TEXT NOT NULL DEFAULT '',
+    whatsapp_number VARCHAR(20) NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL DEFAULT 'NGN',
+    logo_url VARCHAR(255) NOT NULL DEFAULT '',
+    banner_url VARCHAR(255) NOT NULL DEFAULT '',
+    primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    accent_color VARCHAR(7) NOT NULL DEFAULT '',
+    instagram_url VARCHAR(255) NOT NULL DEFAULT '',
+    tiktok_url VARCHAR(255) NOT NULL DEFAULT '',
+    facebook_url VARCHAR(255) NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    opening_hours TEXT NOT NULL DEFAULT '',
+    delivery_notes TEXT NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Africa/Lagos',
+    business_hours JSONB NOT NULL DEFAULT '[]',
+    vacation_mode BOOLEAN NOT NULL DEFAULT FALSE,
+    vacation_starts_at TIMESTAMPTZ,
+    vacation_ends_at TIMESTAMPTZ,
+    vacation_message TEXT NOT NULL DEFAULT '',
+    block_orders_when_closed BOOLEAN NOT NULL DEFAULT FALSE,
+    rating_average NUMERIC(3,2) NOT NULL DEFAULT 0,
+    rating_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_stores_owner
+      FOREIGN KEY(owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE stores
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'NGN',
+    ADD COLUMN IF NOT EXISTS logo_url VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS banner_url VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS accent_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS instagram_url VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tiktok_url VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS facebook_url VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS location VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS opening_hours TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS delivery_notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Africa/Lagos',
+    ADD COLUMN IF NOT EXISTS business_hours JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS vacation_mode BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS vacation_starts_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS vacation_ends_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS vacation_message TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS block_orders_when_closed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS rating_average NUMERIC(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_stores_owner ON stores(owner_id);
+
+
+CREATE TABLE IF NOT EXISTS store_slug_history (
+    slug VARCHAR(255) PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_slug_history_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_store_slug_history_store ON store_slug_history(store_id);
+
+
 CREATE TABLE IF NOT EXISTS products (
     id CHAR(36) PRIMARY KEY,
     user_id CHAR(36) NOT NULL,
+    store_id CHAR(36) NOT NULL,
     name VARCHAR(255) NOT NULL,
     description TEXT,
-    price NUMERIC(10,2) NOT NULL,
+    -- Prices are in minor units (kobo, cents) of the store's currency
+    price BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'NGN',
     is_active BOOLEAN DEFAULT TRUE,
+    rating_average NUMERIC(3,2) NOT NULL DEFAULT 0,
+    rating_count INT NOT NULL DEFAULT 0,
+    sale_price BIGINT,
+    sale_starts_at TIMESTAMPTZ,
+    sale_ends_at TIMESTAMPTZ,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    slug VARCHAR(80) NOT NULL DEFAULT '',
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
 
     CONSTRAINT fk_products_user
-      FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+      FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_products_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
 );
 
+ALTER TABLE products ADD COLUMN IF NOT EXISTS store_id CHAR(36)
+    CONSTRAINT fk_products_store REFERENCES stores(id) ON DELETE CASCADE;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS rating_average NUMERIC(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'NGN',
+    ADD COLUMN IF NOT EXISTS sale_price BIGINT,
+    ADD COLUMN IF NOT EXISTS sale_starts_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS sale_ends_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS slug VARCHAR(80) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_products_store ON products(store_id);
+CREATE INDEX IF NOT EXISTS idx_products_currency_price ON products(currency, price);
+
 
 CREATE TABLE IF NOT EXISTS product_images (
     id CHAR(36) PRIMARY KEY,
@@ -40,3 +137,389 @@ CREATE TABLE IF NOT EXISTS product_images (
     CONSTRAINT fk_images_product
       FOREIGN KEY(product_id) REFERENCES products(id) ON DELETE CASCADE
 );
+
+
+CREATE TABLE IF NOT EXISTS store_members (
+    id CHAR(36) PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    user_id CHAR(36),
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(30) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'invited',
+    invite_token_hash CHAR(64) UNIQUE,
+    invited_by CHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_members_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    CONSTRAINT fk_members_user
+      FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_members_store_email UNIQUE (store_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_store_members_user ON store_members(user_id);
+
+
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id CHAR(36) PRIMARY KEY,
+    product_id CHAR(36) NOT NULL,
+    store_id CHAR(36) NOT NULL,
+    reviewer_name VARCHAR(100) NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'published',
+    moderation_note TEXT NOT NULL DEFAULT '',
+    vendor_reply TEXT NOT NULL DEFAULT '',
+    vendor_replied_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_reviews_product
+      FOREIGN KEY(product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviews_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_reviews_product ON product_reviews(product_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_store ON product_reviews(store_id);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_status ON product_reviews(status);
+
+
+CREATE TABLE IF NOT EXISTS coupons (
+    id CHAR(36) PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    code VARCHAR(40) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL,
+    percent_off NUMERIC(5,2) NOT NULL DEFAULT 0,
+    -- Amounts are in minor units of the store's currency
+    amount_off BIGINT NOT NULL DEFAULT 0,
+    min_spend BIGINT NOT NULL DEFAULT 0,
+    max_uses INT,
+    used_count INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_coupons_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    CONSTRAINT uq_coupons_store_code UNIQUE (store_id, code)
+);
+
+
+-- Raw analytics events, one row per visitor, event and day. product_id is
+-- '' for store-level events so it can take part in the dedup key.
+CREATE TABLE IF NOT EXISTS analytics_events (
+    id BIGSERIAL PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    product_id VARCHAR(36) NOT NULL DEFAULT '',
+    event_type VARCHAR(30) NOT NULL,
+    visitor_hash CHAR(64) NOT NULL,
+    day DATE NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_events_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    CONSTRAINT uq_events_visitor UNIQUE (store_id, product_id, event_type, visitor_hash, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_events_day ON analytics_events(day);
+
+
+CREATE TABLE IF NOT EXISTS analytics_daily (
+    store_id CHAR(36) NOT NULL,
+    product_id VARCHAR(36) NOT NULL DEFAULT '',
+    event_type VARCHAR(30) NOT NULL,
+    day DATE NOT NULL,
+    count INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (store_id, day, event_type, product_id),
+    CONSTRAINT fk_daily_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+
+-- Orders placed from storefront carts. Items are a snapshot of the quote so
+-- later price changes don't rewrite history. Amounts are in minor units.
+CREATE TABLE IF NOT EXISTS orders (
+    id CHAR(36) PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    reference VARCHAR(32) NOT NULL UNIQUE,
+    customer_name VARCHAR(100) NOT NULL DEFAULT '',
+    customer_phone VARCHAR(20) NOT NULL DEFAULT '',
+    customer_email VARCHAR(255) NOT NULL DEFAULT '',
+    items JSONB NOT NULL DEFAULT '[]',
+    currency CHAR(3) NOT NULL,
+    subtotal BIGINT NOT NULL,
+    discount BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL,
+    coupon_code VARCHAR(40) NOT NULL DEFAULT '',
+    payment_status VARCHAR(20) NOT NULL DEFAULT 'unpaid',
+    payment_reference VARCHAR(64) NOT NULL DEFAULT '',
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_orders_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_store ON orders(store_id, created_at DESC);
+
+
+-- Payment attempts against an order, one per provider transaction
+CREATE TABLE IF NOT EXISTS payments (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    reference VARCHAR(64) NOT NULL UNIQUE,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    authorization_url TEXT NOT NULL DEFAULT '',
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_payments_order
+      FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_order ON payments(order_id);
+
+
+-- Provider webhook events already applied, so redelivered events are
+-- acknowledged without being processed twice
+CREATE TABLE IF NOT EXISTS payment_webhook_events (
+    provider VARCHAR(20) NOT NULL,
+    event_id VARCHAR(128) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (provider, event_id)
+);
+
+
+-- Endpoints a store's integrations receive events at
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id CHAR(36) PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    events TEXT[] NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_webhook_endpoints_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_store ON webhook_endpoints(store_id);
+
+
+-- Delivery queue and log, one row per event and endpoint. Pending rows are
+-- sent once next_attempt_at has passed.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id CHAR(36) PRIMARY KEY,
+    endpoint_id CHAR(36) NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INT,
+    last_error TEXT NOT NULL DEFAULT '',
+    last_attempt_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_webhook_deliveries_endpoint
+      FOREIGN KEY(endpoint_id) REFERENCES webhook_endpoints(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries(endpoint_id, created_at DESC);
+
+
+-- API keys vendors' own systems use instead of a JWT. Only the SHA-256 of
+-- each key is kept; prefix is its first characters, for display.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    store_id CHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    key_hash CHAR(64) UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_api_keys_user
+      FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_api_keys_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_store ON api_keys(store_id);
+
+
+-- CSV product imports. Valid rows wait in pending_rows until a worker
+-- creates them; a running job whose lease has passed is picked up again
+-- where it stopped.
+CREATE TABLE IF NOT EXISTS product_import_jobs (
+    id CHAR(36) PRIMARY KEY,
+    store_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_rows INT NOT NULL DEFAULT 0,
+    valid_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    created_rows INT NOT NULL DEFAULT 0,
+    pending_rows JSONB NOT NULL DEFAULT '[]',
+    errors JSONB NOT NULL DEFAULT '[]',
+    lease_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_product_import_jobs_store
+      FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_import_jobs_user
+      FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_import_jobs_open ON product_import_jobs(created_at) WHERE status <> 'completed';
+
+-- Versions of this file applied to the database, a hash of its contents,
+-- so instances can tell whether their schema is in place
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version CHAR(12) PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+
+-- One-off migration of store settings from users into stores. Each existing
+-- vendor gets a store whose ID equals their user ID, so products and staff
+-- memberships keyed by the vendor keep pointing at the right store. Owners of
+-- products who are no longer vendors get a placeholder store the same way,
+-- so none of their products is left without one.
+DO $$
+DECLARE
+    placeholders INT;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'users' AND column_name = 'store_slug'
+    ) THEN
+        INSERT INTO stores (id, owner_id, name, slug, bio, whatsapp_number, created_at)
+        SELECT id, id, COALESCE(NULLIF(store_name, ''), name), COALESCE(store_slug, ''),
+               COALESCE(bio, ''), whatsapp_number, created_at
+        FROM users
+        WHERE role = 'vendor'
+        ON CONFLICT (id) DO NOTHING;
+
+        -- Blank slugs are filled in when slugs are made unique below
+        INSERT INTO stores (id, owner_id, name, slug, bio, whatsapp_number, created_at)
+        SELECT u.id, u.id, COALESCE(NULLIF(u.store_name, ''), u.name), '',
+               COALESCE(u.bio, ''), COALESCE(u.whatsapp_number, ''), u.created_at
+        FROM users u
+        WHERE EXISTS (SELECT 1 FROM products p WHERE p.user_id = u.id AND p.store_id IS NULL)
+        ON CONFLICT (id) DO NOTHING;
+        GET DIAGNOSTICS placeholders = ROW_COUNT;
+        IF placeholders > 0 THEN
+            RAISE NOTICE 'created % placeholder stores for product owners who are not vendors', placeholders;
+        END IF;
+
+        UPDATE products SET store_id = user_id WHERE store_id IS NULL;
+        ALTER TABLE products ALTER COLUMN store_id SET NOT NULL;
+
+        ALTER TABLE store_members DROP CONSTRAINT IF EXISTS fk_members_store;
+        ALTER TABLE store_members ADD CONSTRAINT fk_members_store
+            FOREIGN KEY(store_id) REFERENCES stores(id) ON DELETE CASCADE;
+
+        ALTER TABLE users DROP COLUMN store_name, DROP COLUMN store_slug;
+    END IF;
+END $$;
+
+
+-- Slugs used to be regenerated without a uniqueness check. Before enforcing
+-- uniqueness, give blank slugs and every duplicate but the oldest a suffix
+-- derived from the store ID.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_indexes WHERE indexname = 'uq_stores_slug') THEN
+        UPDATE stores SET slug = 'store-' || LEFT(id, 8) WHERE slug = '';
+
+        UPDATE stores s SET slug = s.slug || '-' || LEFT(s.id, 8)
+        WHERE EXISTS (
+            SELECT 1 FROM stores o
+            WHERE o.slug = s.slug AND (o.created_at, o.id) < (s.created_at, s.id)
+        );
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_stores_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_stores_slug ON stores(slug);
+
+
+-- Prices and coupon amounts used to be NUMERIC(10,2) in naira. Convert them
+-- to integer kobo, which is what every store's default currency (NGN) uses.
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'products' AND column_name = 'price') = 'numeric' THEN
+        ALTER TABLE products ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'products' AND column_name = 'sale_price') = 'numeric' THEN
+        ALTER TABLE products ALTER COLUMN sale_price TYPE BIGINT USING ROUND(sale_price * 100)::BIGINT;
+    END IF;
+
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'coupons' AND column_name = 'value'
+    ) THEN
+        ALTER TABLE coupons
+            ADD COLUMN IF NOT EXISTS percent_off NUMERIC(5,2) NOT NULL DEFAULT 0,
+            ADD COLUMN IF NOT EXISTS amount_off BIGINT NOT NULL DEFAULT 0;
+
+        UPDATE coupons SET percent_off = value WHERE discount_type = 'percentage';
+        UPDATE coupons SET amount_off = ROUND(value * 100)::BIGINT WHERE discount_type = 'fixed';
+
+        ALTER TABLE coupons ALTER COLUMN min_spend TYPE BIGINT USING ROUND(min_spend * 100)::BIGINT;
+        ALTER TABLE coupons DROP COLUMN value;
+    END IF;
+END $$;
+
+
+-- Products created before slugs existed get one derived from their name.
+-- Duplicates within a store, and names without letters or digits, get a
+-- suffix from the product ID.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_indexes WHERE indexname = 'uq_products_store_slug') THEN
+        UPDATE products
+        SET slug = LEFT(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(name), '[^a-z0-9]+', '-', 'g')), 60)
+        WHERE slug = '';
+
+        UPDATE products SET slug = TRIM(BOTH '-' FROM slug);
+        UPDATE products SET slug = 'product-' || LEFT(id, 8) WHERE slug = '';
+
+        UPDATE products p SET slug = p.slug || '-' || LEFT(p.id, 8)
+        WHERE EXISTS (
+            SELECT 1 FROM products o
+            WHERE o.store_id = p.store_id AND o.slug = p.slug
+              AND (o.created_at, o.id) < (p.created_at, p.id)
+        );
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_products_store_slug ON products(store_id, slug);
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// queryLogMaxSQL bounds the SQL logged or traced per query, so the schema
+// migration doesn't flood the log
+const queryLogMaxSQL = 1000
+
+// compactSQL puts sql on one line, cut to queryLogMaxSQL bytes
+func compactSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > queryLogMaxSQL {
+		sql = sql[:queryLogMaxSQL] + "..."
+	}
+	return sql
+}
+
+// queryTracer logs every query at debug level with its duration, under the
+// request ID of the context it ran with. Arguments are left out as they
+// include password hashes and tokens.
+type queryTracer struct {
+	logger *slog.Logger
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql string
+	at  time.Time
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !t.logger.Enabled(ctx, slog.LevelDebug) {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: data.SQL, at: time.Now()})
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	attrs := []any{
+		slog.String("sql", compactSQL(start.sql)),
+		slog.Float64("duration_ms", float64(time.Since(start.at).Microseconds())/1000),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, slog.Any("error", data.Err))
+	} else {
+		attrs = append(attrs, slog.Int64("rows", data.CommandTag.RowsAffected()))
+	}
+	t.logger.DebugContext(ctx, "query", attrs...)
+}
@@ -0,0 +1,37 @@
+package dto
+
+type TrackEventRequest struct {
+	Type      string `json:"type" validate:"required" example:"store_view"`
+	StoreID   string `json:"store_id" validate:"required"`
+	ProductID string `json:"product_id"`
+	// VisitorID is an optional anonymous ID kept by the storefront, e.g. in
+	// localStorage. Without it visitors are told apart by IP and user agent.
+	VisitorID string `json:"visitor_id"`
+}
+
+type AnalyticsTotals struct {
+	StoreViews     int `json:"store_views"`
+	ProductViews   int `json:"product_views"`
+	WhatsappClicks int `json:"whatsapp_clicks"`
+}
+
+type AnalyticsDay struct {
+	Date string `json:"date" example:"2025-01-31"`
+	AnalyticsTotals
+}
+
+type ProductAnalytics struct {
+	ProductID      string `json:"product_id"`
+	Name           string `json:"name"`
+	Views          int    `json:"views"`
+	WhatsappClicks int    `json:"whatsapp_clicks"`
+}
+
+type StoreAnalyticsResponse struct {
+	StoreID     string              `json:"store_id"`
+	From        string              `json:"from"`
+	To          string              `json:"to"`
+	Totals      AnalyticsTotals     `json:"totals"`
+	Series      []AnalyticsDay      `json:"series"`
+	TopProducts []*ProductAnalytics `json:"top_products"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"errors"
+)
+
+type CreateAPIKeyRequest struct {
+	// StoreID defaults to the vendor's primary store
+	StoreID string `json:"store_id,omitempty"`
+	Name    string `json:"name" validate:"required,max=100" example:"Inventory sync"`
+	// Scopes the key is granted: catalog:write, orders:write
+	Scopes []string `json:"scopes" validate:"required"`
+	// ExpiresAt is when the key stops working; omit for a key that lasts
+	// until revoked
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+func (r *CreateAPIKeyRequest) Validate() error {
+	if !isOptionalTimestamp(&r.ExpiresAt) {
+		return errors.New("expires_at must be an RFC 3339 timestamp")
+	}
+	return nil
+}
+
+type APIKeyResponse struct {
+	ID      string `json:"id"`
+	StoreID string `json:"store_id"`
+	Name    string `json:"name"`
+	// Prefix is the start of the key, to tell keys apart
+	Prefix string   `json:"prefix" example:"vhk_3f9a1c07"`
+	Scopes []string `json:"scopes"`
+	// Key is only returned when the key is created; it can't be shown again
+	Key        string `json:"key,omitempty"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
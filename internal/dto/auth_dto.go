@@ -1,18 +1,21 @@
 package dto
 
 type SignUpRequest struct {
-	Name           string `json:"name" binding:"required"`
-	Email          string `json:"email" binding:"required,email"`
-	Password       string `json:"password" binding:"required,min=8"`
-	WhatsappNumber string `json:"whatsapp_number" binding:"required"`
-	Username       string `json:"username" binding:"required"`
-	StoreName      string `json:"store_name" binding:"required"`
-	Bio            string `json:"bio"`
+	Name           string `json:"name" validate:"required,max=100"`
+	Email          string `json:"email" validate:"required,email,max=100"`
+	Password       string `json:"password" validate:"required,min=8,max=72"`
+	WhatsappNumber string `json:"whatsapp_number" validate:"required,e164" example:"+2348012345678"`
+	Username       string `json:"username" validate:"required,username,min=3,max=50"`
+	StoreName      string `json:"store_name" validate:"required,max=255"`
+	StoreSlug      string `json:"store_slug"`
+	// StoreCurrency is an ISO 4217 code and defaults to NGN
+	StoreCurrency string `json:"store_currency" example:"NGN"`
+	Bio           string `json:"bio"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
 }
 
 type AuthUser struct {
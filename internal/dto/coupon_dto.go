@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var couponCode = regexp.MustCompile(`^[A-Za-z0-9_-]{3,40}$`)
+
+type CreateCouponRequest struct {
+	// StoreID defaults to the vendor's primary store
+	StoreID      string `json:"store_id,omitempty"`
+	Code         string `json:"code" validate:"required,max=40"`
+	DiscountType string `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	// Value is the percentage off, or the amount off in the store's currency
+	Value    Amount `json:"value" validate:"required" swaggertype:"string" example:"10"`
+	MinSpend Amount `json:"min_spend" swaggertype:"string" example:"5000"`
+	// MaxUses limits total redemptions; omit for unlimited
+	MaxUses   *int   `json:"max_uses,omitempty"`
+	StartsAt  string `json:"starts_at,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	IsActive  *bool  `json:"is_active,omitempty"`
+}
+
+func (r *CreateCouponRequest) Validate() error {
+	if !couponCode.MatchString(strings.TrimSpace(r.Code)) {
+		return errors.New("code must be 3-40 letters, digits, dashes or underscores")
+	}
+	if err := validateDiscount(r.DiscountType, r.Value); err != nil {
+		return err
+	}
+	if r.MaxUses != nil && *r.MaxUses < 1 {
+		return errors.New("max_uses must be at least 1")
+	}
+	if !isOptionalTimestamp(&r.StartsAt) {
+		return errors.New("starts_at must be an RFC 3339 timestamp")
+	}
+	if !isOptionalTimestamp(&r.ExpiresAt) {
+		return errors.New("expires_at must be an RFC 3339 timestamp")
+	}
+	return nil
+}
+
+// UpdateCouponRequest changes only the fields that are set. A max_uses of 0
+// removes the limit and empty times clear them.
+type UpdateCouponRequest struct {
+	Code         *string `json:"code" validate:"max=40"`
+	DiscountType *string `json:"discount_type" validate:"oneof=percentage fixed"`
+	Value        *Amount `json:"value" swaggertype:"string" example:"10"`
+	MinSpend     *Amount `json:"min_spend" swaggertype:"string" example:"5000"`
+	MaxUses      *int    `json:"max_uses"`
+	StartsAt     *string `json:"starts_at"`
+	ExpiresAt    *string `json:"expires_at"`
+	IsActive     *bool   `json:"is_active"`
+}
+
+func (r *UpdateCouponRequest) Validate() error {
+	if r.Code != nil && !couponCode.MatchString(strings.TrimSpace(*r.Code)) {
+		return errors.New("code must be 3-40 letters, digits, dashes or underscores")
+	}
+	if r.Value != nil && !r.Value.isPositive() {
+		return errors.New("value must be greater than 0")
+	}
+	if r.MaxUses != nil && *r.MaxUses < 0 {
+		return errors.New("max_uses must not be negative")
+	}
+	if !isOptionalTimestamp(r.StartsAt) {
+		return errors.New("starts_at must be an RFC 3339 timestamp")
+	}
+	if !isOptionalTimestamp(r.ExpiresAt) {
+		return errors.New("expires_at must be an RFC 3339 timestamp")
+	}
+	return nil
+}
+
+func validateDiscount(discountType string, value Amount) error {
+	switch discountType {
+	case "percentage":
+		if !isPercentage(value) {
+			return errors.New("a percentage value must be greater than 0 and at most 100")
+		}
+	case "fixed":
+		if !value.isPositive() {
+			return errors.New("value must be greater than 0")
+		}
+	default:
+		return errors.New("discount_type must be percentage or fixed")
+	}
+	return nil
+}
+
+// isPercentage reports whether value is a valid percentage off, above 0
+// and at most 100
+func isPercentage(value Amount) bool {
+	percent, err := strconv.ParseFloat(string(value), 64)
+	return value.isPositive() && err == nil && percent <= 100
+}
+
+type CouponResponse struct {
+	ID           string `json:"id"`
+	StoreID      string `json:"store_id"`
+	Code         string `json:"code"`
+	DiscountType string `json:"discount_type"`
+	// PercentOff is set for percentage coupons and AmountOff for fixed ones
+	PercentOff float64 `json:"percent_off,omitempty"`
+	AmountOff  *Money  `json:"amount_off,omitempty"`
+	MinSpend   Money   `json:"min_spend"`
+	MaxUses    *int    `json:"max_uses"`
+	UsedCount  int     `json:"used_count"`
+	StartsAt   string  `json:"starts_at,omitempty"`
+	ExpiresAt  string  `json:"expires_at,omitempty"`
+	IsActive   bool    `json:"is_active"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+type QuoteItemRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+// QuoteRequest prices a cart. It never counts a coupon use; placing the
+// order does.
+type QuoteRequest struct {
+	StoreID    string             `json:"store_id" validate:"required"`
+	Items      []QuoteItemRequest `json:"items" validate:"required,max=100"`
+	CouponCode string             `json:"coupon_code,omitempty"`
+}
+
+type QuoteLineResponse struct {
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	// UnitPrice is what the buyer pays; RegularUnitPrice differs from it
+	// while the product is on sale
+	UnitPrice        Money `json:"unit_price"`
+	RegularUnitPrice Money `json:"regular_unit_price"`
+	OnSale           bool  `json:"on_sale"`
+	LineTotal        Money `json:"line_total"`
+}
+
+type AppliedCouponResponse struct {
+	Code         string  `json:"code"`
+	DiscountType string  `json:"discount_type"`
+	PercentOff   float64 `json:"percent_off,omitempty"`
+	AmountOff    *Money  `json:"amount_off,omitempty"`
+}
+
+type QuoteResponse struct {
+	StoreID  string               `json:"store_id"`
+	Currency string               `json:"currency"`
+	Items    []*QuoteLineResponse `json:"items"`
+	// SaleSavings is how much the running sales take off the regular prices
+	SaleSavings Money                  `json:"sale_savings"`
+	Subtotal    Money                  `json:"subtotal"`
+	Coupon      *AppliedCouponResponse `json:"coupon,omitempty"`
+	Discount    Money                  `json:"discount"`
+	Total       Money                  `json:"total"`
+	// OrderSummary is the text to prefill the WhatsApp message with
+	OrderSummary string `json:"order_summary"`
+	WhatsappURL  string `json:"whatsapp_url,omitempty"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// HealthResponse reports whether the service is up, and for readiness
+// the result of each dependency check
+type HealthResponse struct {
+	// Status is "ok", "failing" when a check failed, or "draining" while
+	// the server shuts down
+	Status string                       `json:"status" enums:"ok,failing,draining"`
+	Checks map[string]HealthCheckResult `json:"checks,omitempty"`
+}
+
+type HealthCheckResult struct {
+	Status     string  `json:"status" enums:"ok,failing"`
+	DurationMS float64 `json:"duration_ms" example:"1.42"`
+	Error      string  `json:"error,omitempty" example:"timed out"`
+}
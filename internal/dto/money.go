@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+)
+
+var decimalAmount = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)$`)
+
+// Amount is a decimal amount in a currency's major unit, e.g. 1500.50 naira.
+// It accepts a JSON number or string and keeps the exact text, so no
+// precision is lost to floating point before it's converted to minor units.
+type Amount string
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var text string
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+		text = number.String()
+	}
+
+	if !decimalAmount.MatchString(text) {
+		return errors.New("amount must be a non-negative decimal like 1500.50")
+	}
+	*a = Amount(text)
+	return nil
+}
+
+// isPositive reports whether the amount is greater than zero
+func (a Amount) isPositive() bool {
+	if !decimalAmount.MatchString(string(a)) {
+		return false
+	}
+	for _, r := range a {
+		if r >= '1' && r <= '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// Money is an amount in responses. Amount is in minor units (kobo, cents)
+// for calculations; Value and Formatted are for display.
+type Money struct {
+	Amount    int64  `json:"amount" example:"150050"`
+	Currency  string `json:"currency" example:"NGN"`
+	Value     string `json:"value" example:"1500.50"`
+	Formatted string `json:"formatted" example:"₦1,500.50"`
+}
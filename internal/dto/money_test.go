@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Amount
+		wantErr bool
+	}{
+		{name: "number", json: `1500.50`, want: "1500.50"},
+		{name: "string", json: `"1500.50"`, want: "1500.50"},
+		{name: "number keeps its digits", json: `0.1000000000000000055511151231257827`, want: "0.1000000000000000055511151231257827"},
+		{name: "whole number", json: `20`, want: "20"},
+		{name: "null", json: `null`, want: ""},
+		{name: "negative", json: `-5`, wantErr: true},
+		{name: "exponent", json: `1e3`, wantErr: true},
+		{name: "text", json: `"ten"`, wantErr: true},
+		{name: "boolean", json: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Price Amount `json:"price"`
+			}
+			err := json.Unmarshal([]byte(`{"price":`+tt.json+`}`), &body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %t", tt.json, err, tt.wantErr)
+			}
+			if err == nil && body.Price != tt.want {
+				t.Errorf("Unmarshal(%s) = %q, want %q", tt.json, body.Price, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountIsPositive(t *testing.T) {
+	tests := map[Amount]bool{
+		"1500":  true,
+		"0.01":  true,
+		".5":    true,
+		"0":     false,
+		"0.00":  false,
+		"":      false,
+		"abc":   false,
+		"-1":    false,
+		"00.10": true,
+	}
+	for amount, want := range tests {
+		if got := amount.isPositive(); got != want {
+			t.Errorf("Amount(%q).isPositive() = %t, want %t", amount, got, want)
+		}
+	}
+}
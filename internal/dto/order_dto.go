@@ -0,0 +1,56 @@
+package dto
+
+// PlaceOrderRequest turns a cart into an order. Any coupon is redeemed
+// when the order is placed.
+type PlaceOrderRequest struct {
+	StoreID       string             `json:"store_id" validate:"required"`
+	Items         []QuoteItemRequest `json:"items" validate:"required,max=100"`
+	CouponCode    string             `json:"coupon_code,omitempty"`
+	CustomerName  string             `json:"customer_name" validate:"required,max=100"`
+	CustomerPhone string             `json:"customer_phone,omitempty" validate:"max=20"`
+	// CustomerEmail is needed to pay online
+	CustomerEmail string `json:"customer_email,omitempty" validate:"email,max=255"`
+}
+
+// QuoteRequest returns the quote that prices the order. It doesn't redeem
+// the coupon, which is counted when the order is saved.
+func (r *PlaceOrderRequest) QuoteRequest() QuoteRequest {
+	return QuoteRequest{
+		StoreID:    r.StoreID,
+		Items:      r.Items,
+		CouponCode: r.CouponCode,
+	}
+}
+
+type OrderItemResponse struct {
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice Money  `json:"unit_price"`
+	LineTotal Money  `json:"line_total"`
+}
+
+type OrderResponse struct {
+	ID            string               `json:"id"`
+	StoreID       string               `json:"store_id"`
+	Reference     string               `json:"reference"`
+	CustomerName  string               `json:"customer_name"`
+	CustomerPhone string               `json:"customer_phone,omitempty"`
+	CustomerEmail string               `json:"customer_email,omitempty"`
+	Items         []*OrderItemResponse `json:"items"`
+	Currency      string               `json:"currency"`
+	Subtotal      Money                `json:"subtotal"`
+	Discount      Money                `json:"discount"`
+	Total         Money                `json:"total"`
+	CouponCode    string               `json:"coupon_code,omitempty"`
+	PaymentStatus string               `json:"payment_status" enums:"unpaid,pending,paid,failed"`
+	// PaymentReference is the latest payment attempt, or the one that paid
+	PaymentReference string `json:"payment_reference,omitempty"`
+	PaidAt           string `json:"paid_at,omitempty"`
+	// Payments lists every payment attempt; only included for the store
+	Payments []*PaymentResponse `json:"payments,omitempty"`
+	// OrderSummary and WhatsappURL are returned when the order is placed
+	OrderSummary string `json:"order_summary,omitempty"`
+	WhatsappURL  string `json:"whatsapp_url,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
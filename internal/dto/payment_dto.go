@@ -0,0 +1,28 @@
+package dto
+
+// InitializePaymentRequest starts an online payment for an order
+type InitializePaymentRequest struct {
+	// Email defaults to the order's customer email
+	Email string `json:"email,omitempty" validate:"email,max=255"`
+	// CallbackURL is where the provider sends the buyer after checkout
+	CallbackURL string `json:"callback_url,omitempty" validate:"url"`
+}
+
+type InitializePaymentResponse struct {
+	OrderReference   string `json:"order_reference"`
+	Reference        string `json:"reference"`
+	Provider         string `json:"provider"`
+	AuthorizationURL string `json:"authorization_url"`
+	AccessCode       string `json:"access_code,omitempty"`
+	Amount           Money  `json:"amount"`
+}
+
+type PaymentResponse struct {
+	Reference      string `json:"reference"`
+	OrderReference string `json:"order_reference,omitempty"`
+	Provider       string `json:"provider"`
+	Amount         Money  `json:"amount"`
+	Status         string `json:"status" enums:"pending,success,failed"`
+	PaidAt         string `json:"paid_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
@@ -5,46 +5,65 @@ import (
 )
 
 type CreateProductRequest struct {
-	Name        string  `json:"name" binding:"required,min=1,max=255"`
-	Description string  `json:"description" binding:"required,min=1,max=1000"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"required,max=1000"`
+	// Price is in the store's currency, as a decimal number or string
+	Price Amount `json:"price" validate:"required" swaggertype:"string" example:"1500.50"`
+	// StoreID selects the store to create the product in. Defaults to the
+	// caller's primary store; staff must always set it.
+	StoreID string `json:"store_id,omitempty"`
+	// SalePrice is an optional discounted price shown instead of Price,
+	// with Price as the compare-at price, between the optional start and end.
+	SalePrice    *Amount `json:"sale_price,omitempty" swaggertype:"string" example:"1200"`
+	SaleStartsAt string  `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   string  `json:"sale_ends_at,omitempty"`
+	Category     string  `json:"category,omitempty" validate:"max=100" example:"Shoes"`
+	// Slug is derived from the name unless given, and must be unique
+	// within the store
+	Slug string `json:"slug,omitempty" validate:"max=80" example:"ankara-dress"`
 }
 
 func (r *CreateProductRequest) Validate() error {
-	if r.Name == "" {
-		return errors.New("product name is required")
-	}
-	if len(r.Name) > 255 {
-		return errors.New("product name must be less than 255 characters")
+	if !r.Price.isPositive() {
+		return errors.New("product price must be greater than 0")
 	}
-	if r.Description == "" {
-		return errors.New("product description is required")
+	if r.SalePrice != nil && !r.SalePrice.isPositive() {
+		return errors.New("sale_price must be greater than 0")
 	}
-	if len(r.Description) > 1000 {
-		return errors.New("product description must be less than 1000 characters")
+	if !isOptionalTimestamp(&r.SaleStartsAt) {
+		return errors.New("sale_starts_at must be an RFC 3339 timestamp")
 	}
-	if r.Price <= 0 {
-		return errors.New("product price must be greater than 0")
+	if !isOptionalTimestamp(&r.SaleEndsAt) {
+		return errors.New("sale_ends_at must be an RFC 3339 timestamp")
 	}
 	return nil
 }
 
 type UpdateProductRequest struct {
-	Name        *string  `json:"name"`
-	Description *string  `json:"description"`
-	Price       *float64 `json:"price"`
-	IsActive    *bool    `json:"is_active"`
+	Name        *string `json:"name" validate:"max=255"`
+	Description *string `json:"description" validate:"max=1000"`
+	Price       *Amount `json:"price" swaggertype:"string" example:"1500.50"`
+	IsActive    *bool   `json:"is_active"`
+	// SalePrice of 0 ends the sale. Empty sale times clear them.
+	SalePrice    *Amount `json:"sale_price" swaggertype:"string" example:"1200"`
+	SaleStartsAt *string `json:"sale_starts_at"`
+	SaleEndsAt   *string `json:"sale_ends_at"`
+	// Category of "" removes the product from its category
+	Category *string `json:"category" validate:"max=100"`
+	// Slug keeps its value when the product is renamed; changing it breaks
+	// links already shared
+	Slug *string `json:"slug" example:"ankara-dress"`
 }
 
 func (r *UpdateProductRequest) Validate() error {
-	if r.Name != nil && len(*r.Name) > 255 {
-		return errors.New("product name must be less than 255 characters")
+	if r.Price != nil && !r.Price.isPositive() {
+		return errors.New("product price must be greater than 0")
 	}
-	if r.Description != nil && len(*r.Description) > 1000 {
-		return errors.New("product description must be less than 1000 characters")
+	if !isOptionalTimestamp(r.SaleStartsAt) {
+		return errors.New("sale_starts_at must be an RFC 3339 timestamp")
 	}
-	if r.Price != nil && *r.Price <= 0 {
-		return errors.New("product price must be greater than 0")
+	if !isOptionalTimestamp(r.SaleEndsAt) {
+		return errors.New("sale_ends_at must be an RFC 3339 timestamp")
 	}
 	return nil
 }
@@ -54,15 +73,28 @@ type ToggleProductStatusRequest struct {
 }
 
 type ProductResponse struct {
-	ID          string                  `json:"id"`
-	UserID      string                  `json:"user_id"`
-	Name        string                  `json:"name"`
-	Description string                  `json:"description"`
-	Price       float64                 `json:"price"`
-	IsActive    bool                    `json:"is_active"`
-	Images      []*ProductImageResponse `json:"images"`
-	CreatedAt   string                  `json:"created_at"`
-	UpdatedAt   string                  `json:"updated_at"`
+	ID            string  `json:"id"`
+	UserID        string  `json:"user_id"`
+	StoreID       string  `json:"store_id"`
+	Name          string  `json:"name"`
+	Slug          string  `json:"slug"`
+	Description   string  `json:"description"`
+	Price         Money   `json:"price"`
+	IsActive      bool    `json:"is_active"`
+	Category      string  `json:"category"`
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int     `json:"rating_count"`
+	// EffectivePrice is what the buyer pays now; CompareAtPrice is only
+	// set while a sale is running
+	SalePrice      *Money                  `json:"sale_price,omitempty"`
+	SaleStartsAt   string                  `json:"sale_starts_at,omitempty"`
+	SaleEndsAt     string                  `json:"sale_ends_at,omitempty"`
+	OnSale         bool                    `json:"on_sale"`
+	EffectivePrice Money                   `json:"effective_price"`
+	CompareAtPrice *Money                  `json:"compare_at_price,omitempty"`
+	Images         []*ProductImageResponse `json:"images"`
+	CreatedAt      string                  `json:"created_at"`
+	UpdatedAt      string                  `json:"updated_at"`
 }
 
 type ProductImageResponse struct {
@@ -72,7 +104,7 @@ type ProductImageResponse struct {
 }
 
 type UploadProductImageRequest struct {
-	Position int `json:"position" binding:"min=0"`
+	Position int `json:"position" validate:"min=0"`
 }
 
 type UploadProductImageResponse struct {
@@ -0,0 +1,43 @@
+package dto
+
+// ProductImportPreviewResponse is the result of a dry-run import: what
+// would be created and which rows would be skipped, without changing
+// anything
+type ProductImportPreviewResponse struct {
+	StoreID   string                     `json:"store_id"`
+	TotalRows int                        `json:"total_rows"`
+	ValidRows int                        `json:"valid_rows"`
+	Products  []*ProductImportRowPreview `json:"products"`
+	Errors    []*ProductImportRowError   `json:"errors"`
+}
+
+type ProductImportRowPreview struct {
+	Row       int      `json:"row"`
+	Name      string   `json:"name"`
+	Price     Money    `json:"price"`
+	IsActive  bool     `json:"is_active"`
+	Category  string   `json:"category"`
+	ImageURLs []string `json:"image_urls"`
+}
+
+// ProductImportRowError explains why a row was skipped. Rows are numbered
+// as in a spreadsheet, with the header as row 1.
+type ProductImportRowError struct {
+	Row     int    `json:"row" example:"3"`
+	Message string `json:"message" example:"product price must be greater than 0"`
+}
+
+type ProductImportJobResponse struct {
+	ID      string `json:"id"`
+	StoreID string `json:"store_id"`
+	Status  string `json:"status" enums:"pending,running,completed"`
+	// TotalRows counts the data rows in the file and ValidRows those that
+	// passed validation; ProcessedRows of the valid rows have been handled
+	TotalRows     int                      `json:"total_rows"`
+	ValidRows     int                      `json:"valid_rows"`
+	ProcessedRows int                      `json:"processed_rows"`
+	CreatedRows   int                      `json:"created_rows"`
+	Errors        []*ProductImportRowError `json:"errors"`
+	CreatedAt     string                   `json:"created_at"`
+	CompletedAt   string                   `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"slices"
+)
+
+const (
+	QRCodeMinSize = 128
+	QRCodeMaxSize = 2048
+)
+
+// QRCodeRequest holds the query options of the QR code endpoints. Empty
+// values take the defaults: a 512px PNG with medium error correction.
+type QRCodeRequest struct {
+	Format          string
+	Size            int
+	ErrorCorrection string
+	Logo            bool
+}
+
+func (r *QRCodeRequest) Validate() error {
+	if r.Format != "" && !slices.Contains([]string{"png", "svg", "pdf"}, r.Format) {
+		return errors.New("format must be png, svg or pdf")
+	}
+	if r.Size != 0 && (r.Size < QRCodeMinSize || r.Size > QRCodeMaxSize) {
+		return errors.New("size must be between 128 and 2048 pixels")
+	}
+	if r.ErrorCorrection != "" && !slices.Contains([]string{"low", "medium", "high", "highest"}, r.ErrorCorrection) {
+		return errors.New("error_correction must be low, medium, high or highest")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package dto
+
+type CreateReviewRequest struct {
+	ReviewerName string `json:"reviewer_name" validate:"required,max=100"`
+	Rating       int    `json:"rating" validate:"required,min=1,max=5"`
+	Comment      string `json:"comment" validate:"max=2000"`
+}
+
+type ReviewReplyRequest struct {
+	Reply string `json:"reply" validate:"max=2000"`
+}
+
+type ModerateReviewRequest struct {
+	Note string `json:"note"`
+}
+
+type ReviewResponse struct {
+	ID              string `json:"id"`
+	ProductID       string `json:"product_id"`
+	StoreID         string `json:"store_id"`
+	ReviewerName    string `json:"reviewer_name"`
+	Rating          int    `json:"rating"`
+	Comment         string `json:"comment"`
+	Status          string `json:"status,omitempty"`
+	ModerationNote  string `json:"moderation_note,omitempty"`
+	VendorReply     string `json:"vendor_reply,omitempty"`
+	VendorRepliedAt string `json:"vendor_replied_at,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// PageMetaResponse describes a storefront page for link previews on
+// WhatsApp, Facebook, X and search engines. Tags are ready to render as
+// <meta> elements in the page head.
+type PageMetaResponse struct {
+	Type        string     `json:"type" enums:"website,product"`
+	Title       string     `json:"title" example:"Ankara dress | Ada Styles"`
+	Description string     `json:"description"`
+	Image       string     `json:"image,omitempty"`
+	URL         string     `json:"url"`
+	SiteName    string     `json:"site_name" example:"VendorHub"`
+	Price       *Money     `json:"price,omitempty"`
+	Tags        []*MetaTag `json:"tags"`
+}
+
+// MetaTag is one <meta> element. Open Graph tags use property and Twitter
+// card tags use name.
+type MetaTag struct {
+	Property string `json:"property,omitempty" example:"og:title"`
+	Name     string `json:"name,omitempty" example:"twitter:card"`
+	Content  string `json:"content" example:"Ankara dress | Ada Styles"`
+}
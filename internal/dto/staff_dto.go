@@ -0,0 +1,35 @@
+package dto
+
+type InviteStaffRequest struct {
+	Email string `json:"email" validate:"required,email,max=100"`
+	Role  string `json:"role" validate:"required"`
+}
+
+type UpdateStaffRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type AcceptInviteRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Name     string `json:"name" validate:"max=100"`
+	Username string `json:"username" validate:"username,min=3,max=50"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type StoreMemberResponse struct {
+	ID         string `json:"id,omitempty"`
+	StoreID    string `json:"store_id"`
+	StoreName  string `json:"store_name,omitempty"`
+	StoreSlug  string `json:"store_slug,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	Status     string `json:"status"`
+	CreatedAt  string `json:"created_at"`
+	AcceptedAt string `json:"accepted_at,omitempty"`
+}
+
+type InviteStaffResponse struct {
+	Member      *StoreMemberResponse `json:"member"`
+	InviteToken string               `json:"invite_token"`
+}
@@ -1,14 +1,58 @@
 package dto
 
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
 type StoreResponse struct {
 	ID             string `json:"id"`
+	OwnerID        string `json:"owner_id"`
 	Name           string `json:"name"`
 	Slug           string `json:"slug"`
 	Username       string `json:"username"`
 	Bio            string `json:"bio"`
 	WhatsappNumber string `json:"whatsapp_number"`
+	Currency       string `json:"currency"`
 	Email          string `json:"email"`
-	CreatedAt      string `json:"created_at"`
+	LogoURL        string `json:"logo_url"`
+	BannerURL      string `json:"banner_url"`
+	PrimaryColor   string `json:"primary_color"`
+	AccentColor    string `json:"accent_color"`
+	InstagramURL   string `json:"instagram_url"`
+	TiktokURL      string `json:"tiktok_url"`
+	FacebookURL    string `json:"facebook_url"`
+	Location       string `json:"location"`
+	OpeningHours   string `json:"opening_hours"`
+	DeliveryNotes  string `json:"delivery_notes"`
+
+	Timezone              string          `json:"timezone"`
+	BusinessHours         []BusinessHours `json:"business_hours"`
+	VacationMode          bool            `json:"vacation_mode"`
+	VacationStartsAt      string          `json:"vacation_starts_at,omitempty"`
+	VacationEndsAt        string          `json:"vacation_ends_at,omitempty"`
+	VacationMessage       string          `json:"vacation_message"`
+	BlockOrdersWhenClosed bool            `json:"block_orders_when_closed"`
+	OnVacation            bool            `json:"on_vacation"`
+	IsOpenNow             bool            `json:"is_open_now"`
+	AcceptingOrders       bool            `json:"accepting_orders"`
+	RatingAverage         float64         `json:"rating_average"`
+	RatingCount           int             `json:"rating_count"`
+
+	CreatedAt string `json:"created_at"`
+}
+
+// BusinessHours is one opening interval, e.g. {"day": "monday", "opens":
+// "09:00", "closes": "17:00"}. A closing time earlier than the opening time
+// runs past midnight.
+type BusinessHours struct {
+	Day    string `json:"day" example:"monday"`
+	Opens  string `json:"opens" example:"09:00"`
+	Closes string `json:"closes" example:"17:00"`
 }
 
 type StoreDetailsResponse struct {
@@ -17,10 +61,112 @@ type StoreDetailsResponse struct {
 	StoreURL string             `json:"store_url"`
 }
 
+// StoreProductResponse is a product page: the product and the store it
+// belongs to
+type StoreProductResponse struct {
+	Store      *StoreResponse   `json:"store"`
+	Product    *ProductResponse `json:"product"`
+	ProductURL string           `json:"product_url"`
+}
+
+type CreateStoreRequest struct {
+	StoreName      string `json:"store_name" validate:"required,max=255"`
+	Slug           string `json:"slug"`
+	Bio            string `json:"bio"`
+	WhatsappNumber string `json:"whatsapp_number" validate:"e164" example:"+2348012345678"`
+	// Currency is an ISO 4217 code such as NGN, GHS, KES or USD and
+	// defaults to NGN
+	Currency string `json:"currency" example:"NGN"`
+}
+
 type UpdateStoreRequest struct {
-	StoreName      *string `json:"store_name"`
-	Username       *string `json:"username"`
+	StoreName      *string `json:"store_name" validate:"max=255"`
+	Slug           *string `json:"slug"`
 	Bio            *string `json:"bio"`
-	WhatsappNumber *string `json:"whatsapp_number"`
-	Email          *string `json:"email"`
+	WhatsappNumber *string `json:"whatsapp_number" validate:"e164" example:"+2348012345678"`
+	// Changing the currency keeps prices at the same decimal value
+	Currency      *string `json:"currency" example:"GHS"`
+	PrimaryColor  *string `json:"primary_color" example:"#1A73E8"`
+	AccentColor   *string `json:"accent_color" example:"#FBBC04"`
+	InstagramURL  *string `json:"instagram_url"`
+	TiktokURL     *string `json:"tiktok_url"`
+	FacebookURL   *string `json:"facebook_url"`
+	Location      *string `json:"location" validate:"max=255"`
+	OpeningHours  *string `json:"opening_hours" validate:"max=1000"`
+	DeliveryNotes *string `json:"delivery_notes" validate:"max=2000"`
+
+	Timezone              *string          `json:"timezone" example:"Africa/Lagos"`
+	BusinessHours         *[]BusinessHours `json:"business_hours" validate:"max=50"`
+	VacationMode          *bool            `json:"vacation_mode"`
+	VacationStartsAt      *string          `json:"vacation_starts_at" example:"2025-12-20T00:00:00Z"`
+	VacationEndsAt        *string          `json:"vacation_ends_at" example:"2026-01-05T00:00:00Z"`
+	VacationMessage       *string          `json:"vacation_message" validate:"max=500"`
+	BlockOrdersWhenClosed *bool            `json:"block_orders_when_closed"`
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Validate checks the profile fields that are set. Empty strings clear a
+// field and are always accepted.
+func (r *UpdateStoreRequest) Validate() error {
+	if r.PrimaryColor != nil && *r.PrimaryColor != "" && !hexColor.MatchString(*r.PrimaryColor) {
+		return errors.New("primary_color must be a hex colour like #1A73E8")
+	}
+	if r.AccentColor != nil && *r.AccentColor != "" && !hexColor.MatchString(*r.AccentColor) {
+		return errors.New("accent_color must be a hex colour like #FBBC04")
+	}
+
+	links := []struct {
+		name   string
+		value  *string
+		domain string
+	}{
+		{"instagram_url", r.InstagramURL, "instagram.com"},
+		{"tiktok_url", r.TiktokURL, "tiktok.com"},
+		{"facebook_url", r.FacebookURL, "facebook.com"},
+	}
+	for _, link := range links {
+		if link.value == nil || *link.value == "" {
+			continue
+		}
+		if !isProfileURL(*link.value, link.domain) {
+			return fmt.Errorf("%s must be an https link on %s", link.name, link.domain)
+		}
+	}
+
+	if r.Timezone != nil {
+		if _, err := time.LoadLocation(*r.Timezone); err != nil || *r.Timezone == "" {
+			return errors.New("timezone must be an IANA name like Africa/Lagos")
+		}
+	}
+	if !isOptionalTimestamp(r.VacationStartsAt) {
+		return errors.New("vacation_starts_at must be an RFC 3339 timestamp")
+	}
+	if !isOptionalTimestamp(r.VacationEndsAt) {
+		return errors.New("vacation_ends_at must be an RFC 3339 timestamp")
+	}
+	return nil
+}
+
+// isOptionalTimestamp reports whether value is unset, empty or RFC 3339
+func isOptionalTimestamp(value *string) bool {
+	if value == nil || *value == "" {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, *value)
+	return err == nil
+}
+
+// isProfileURL reports whether raw is an https URL on domain or one of its
+// subdomains, e.g. https://www.instagram.com/shop.
+func isProfileURL(raw, domain string) bool {
+	if len(raw) > 255 {
+		return false
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == domain || strings.HasSuffix(host, "."+domain)
 }
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+type CreateWebhookRequest struct {
+	// StoreID defaults to the vendor's primary store
+	StoreID string `json:"store_id,omitempty"`
+	URL     string `json:"url" validate:"required,url" example:"https://example.com/hooks/vendorhub"`
+	// Events to subscribe to: product.created, product.updated,
+	// product.deleted, order.created, order.paid
+	Events      []string `json:"events" validate:"required"`
+	Description string   `json:"description,omitempty" validate:"max=200"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+}
+
+func (r *CreateWebhookRequest) Validate() error {
+	if err := validateWebhookURL(r.URL); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateWebhookRequest changes only the fields that are set. RotateSecret
+// replaces the signing secret and returns the new one.
+type UpdateWebhookRequest struct {
+	URL          *string  `json:"url" validate:"url"`
+	Events       []string `json:"events"`
+	Description  *string  `json:"description" validate:"max=200"`
+	IsActive     *bool    `json:"is_active"`
+	RotateSecret bool     `json:"rotate_secret"`
+}
+
+func (r *UpdateWebhookRequest) Validate() error {
+	if r.URL != nil {
+		if err := validateWebhookURL(*r.URL); err != nil {
+			return err
+		}
+	}
+	if r.Events != nil && len(r.Events) == 0 {
+		return errors.New("events must contain at least one event type")
+	}
+	return nil
+}
+
+func validateWebhookURL(raw string) error {
+	endpoint, err := url.Parse(raw)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return errors.New("url must be an http or https URL")
+	}
+	if len(raw) > 2000 {
+		return errors.New("url must be at most 2000 characters")
+	}
+	return nil
+}
+
+type WebhookEndpointResponse struct {
+	ID          string   `json:"id"`
+	StoreID     string   `json:"store_id"`
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Description string   `json:"description"`
+	IsActive    bool     `json:"is_active"`
+	// Secret is only returned when the endpoint is created or its secret
+	// rotated; keep it to verify signatures
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID         string          `json:"id"`
+	EndpointID string          `json:"endpoint_id"`
+	EventID    string          `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Payload    json.RawMessage `json:"payload" swaggertype:"object"`
+	Status     string          `json:"status" enums:"pending,succeeded,failed"`
+	Attempts   int             `json:"attempts"`
+	// NextAttemptAt is set while the delivery is pending
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int   `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	LastAttemptAt  string `json:"last_attempt_at,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// WebhookEvent is the body POSTed to webhook endpoints
+type WebhookEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	StoreID   string `json:"store_id"`
+	CreatedAt string `json:"created_at"`
+	Data      any    `json:"data"`
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -33,10 +32,6 @@ func NewAdminHandler(admin *service.AdminService) *AdminHandler {
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /admin/vendors/{id}/approve [post]
 func (h *AdminHandler) ApproveVendor(w http.ResponseWriter, r *http.Request) {
-	rctx := chi.RouteContext(r.Context())
-	if rctx != nil {
-		log.Println("Chi RoutePattern:", rctx.RoutePattern())
-	}
 	adminID, err := utils.GetUserIDFromContext(r.Context())
 	if err != nil {
 		utils.HandleServiceError(w, err)
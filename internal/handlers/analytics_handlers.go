@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type AnalyticsHandler struct {
+	analyticsService *service.AnalyticsService
+	clientIPHeader   string
+}
+
+// NewAnalyticsHandler returns the analytics handler. clientIPHeader is the
+// header a trusted reverse proxy puts the client address in, as for
+// utils.ClientIP, so visitors can't rotate theirs to be counted again.
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService, clientIPHeader string) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService, clientIPHeader: clientIPHeader}
+}
+
+// TrackEvent godoc
+// @Summary      Track a storefront event
+// @Description  Records a store_view, product_view or whatsapp_click. Each visitor counts once per event, store, product and day; bots are ignored.
+// @Tags         Analytics
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.TrackEventRequest true "Event"
+// @Success      202  {object}  map[string]string
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /events [post]
+func (ah *AnalyticsHandler) TrackEvent(w http.ResponseWriter, r *http.Request) {
+	var req dto.TrackEventRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := ah.analyticsService.TrackEvent(r.Context(), req, utils.ClientIP(r, ah.clientIPHeader), r.UserAgent()); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+}
+
+// GetMyStoreAnalytics godoc
+// @Summary      Get store analytics
+// @Description  Returns daily store views, product views and WhatsApp clicks plus the top products of one of the authenticated vendor's stores. Figures are refreshed by a background job every few minutes.
+// @Tags         Analytics
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Param        from query string false "First day, YYYY-MM-DD (default: 29 days before to)"
+// @Param        to query string false "Last day, YYYY-MM-DD (default: today in the store's timezone)"
+// @Success      200  {object}  dto.StoreAnalyticsResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/analytics [get]
+func (ah *AnalyticsHandler) GetMyStoreAnalytics(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view store analytics")
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	response, err := ah.analyticsService.GetStoreAnalytics(r.Context(), vendorID, query.Get("store_id"), query.Get("from"), query.Get("to"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
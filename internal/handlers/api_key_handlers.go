@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// CreateAPIKey godoc
+// @Summary      Create an API key
+// @Description  Issues a key for the vendor's own systems to call the product and order endpoints of one store, with the chosen scopes (catalog:write, orders:write). Send it in X-API-Key or as a Bearer token. The key is only returned here.
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        body body dto.CreateAPIKeyRequest true "Create API Key Request"
+// @Success      201  {object}  dto.APIKeyResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/api-keys [post]
+func (ah *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can create api keys")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := ah.apiKeyService.CreateAPIKey(r.Context(), vendorID, req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListAPIKeys godoc
+// @Summary      List my store's API keys
+// @Description  Lists the API keys of one of the vendor's stores, newest first, with when each was last used. Revoked and expired keys are included.
+// @Tags         API Keys
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to the vendor's primary store)"
+// @Success      200  {array}   dto.APIKeyResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/api-keys [get]
+func (ah *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view api keys")
+	if !ok {
+		return
+	}
+
+	response, err := ah.apiKeyService.ListAPIKeys(r.Context(), vendorID, r.URL.Query().Get("store_id"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Stops an API key from working immediately. The key stays listed with its revocation time.
+// @Tags         API Keys
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        keyId path string true "API key ID"
+// @Success      200  {object}  dto.APIKeyResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/api-keys/{keyId} [delete]
+func (ah *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can revoke api keys")
+	if !ok {
+		return
+	}
+
+	response, err := ah.apiKeyService.RevokeAPIKey(r.Context(), vendorID, chi.URLParam(r, "keyId"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
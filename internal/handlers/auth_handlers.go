@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/falasefemi2/vendorhub/internal/dto"
@@ -26,17 +25,15 @@ func NewAuthHandler(auth *service.AuthService) *AuthHandler {
 // @Param        body body dto.SignUpRequest true "Sign Up Request"
 // @Success      201  {object}  dto.AuthResponse
 // @Failure      400  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /auth/signup [post]
 func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	var req dto.SignUpRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
-		return
-	}
-	if req.StoreName == "" {
-		utils.WriteError(w, http.StatusBadRequest, "store_name is required")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
 		return
 	}
 	user, err := h.authService.SignUp(req)
@@ -58,13 +55,14 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 // @Success      200  {object}  dto.AuthResponse
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      401  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req dto.LoginRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
 		return
 	}
 
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type CatalogFeedHandler struct {
+	feedService *service.CatalogFeedService
+	logger      *slog.Logger
+}
+
+func NewCatalogFeedHandler(feedService *service.CatalogFeedService, logger *slog.Logger) *CatalogFeedHandler {
+	return &CatalogFeedHandler{feedService: feedService, logger: logger}
+}
+
+// GetMetaCSVFeed godoc
+// @Summary      Meta Commerce CSV feed
+// @Description  Lists a store's active products with images as a Meta Commerce Manager data feed, for WhatsApp Business catalogs and Instagram shopping. Products are out of stock while the store is on vacation. A slug the store used before being renamed answers with a 301 to the current slug.
+// @Tags         Feeds
+// @Produce      text/csv
+// @Param        slug path string true "Store slug"
+// @Success      200  {file}    file
+// @Success      301  "Moved Permanently to the store's current slug"
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/feeds/meta.csv [get]
+func (fh *CatalogFeedHandler) GetMetaCSVFeed(w http.ResponseWriter, r *http.Request) {
+	fh.serveFeed(w, r, service.CatalogFeedMetaCSV)
+}
+
+// GetMetaXMLFeed godoc
+// @Summary      Meta Commerce XML feed
+// @Description  Lists a store's active products with images as an RSS feed for Meta Commerce Manager. Products are out of stock while the store is on vacation. A slug the store used before being renamed answers with a 301 to the current slug.
+// @Tags         Feeds
+// @Produce      xml
+// @Param        slug path string true "Store slug"
+// @Success      200  {file}    file
+// @Success      301  "Moved Permanently to the store's current slug"
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/feeds/meta.xml [get]
+func (fh *CatalogFeedHandler) GetMetaXMLFeed(w http.ResponseWriter, r *http.Request) {
+	fh.serveFeed(w, r, service.CatalogFeedMetaXML)
+}
+
+// GetGoogleFeed godoc
+// @Summary      Google Merchant feed
+// @Description  Lists a store's active products with images as an RSS 2.0 feed for Google Merchant Center. Products are out of stock while the store is on vacation. A slug the store used before being renamed answers with a 301 to the current slug.
+// @Tags         Feeds
+// @Produce      xml
+// @Param        slug path string true "Store slug"
+// @Success      200  {file}    file
+// @Success      301  "Moved Permanently to the store's current slug"
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/feeds/google.xml [get]
+func (fh *CatalogFeedHandler) GetGoogleFeed(w http.ResponseWriter, r *http.Request) {
+	fh.serveFeed(w, r, service.CatalogFeedGoogle)
+}
+
+func (fh *CatalogFeedHandler) serveFeed(w http.ResponseWriter, r *http.Request, format string) {
+	slugName := chi.URLParam(r, "slug")
+	feed, err := fh.feedService.GetFeed(r.Context(), slugName, format)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	if feed.StoreSlug != slugName {
+		target := *r.URL
+		target.Path = "/stores/" + feed.StoreSlug + "/feeds/" + format
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		return
+	}
+
+	w.Header().Set("Content-Type", feed.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=900")
+	w.Header().Set("Last-Modified", feed.GeneratedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.Itoa(len(feed.Body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(feed.Body); err != nil {
+		fh.logger.WarnContext(r.Context(), "failed to write catalog feed", "format", format, "error", err)
+	}
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type CouponHandler struct {
+	couponService *service.CouponService
+}
+
+func NewCouponHandler(couponService *service.CouponService) *CouponHandler {
+	return &CouponHandler{couponService: couponService}
+}
+
+// CreateCoupon godoc
+// @Summary      Create a coupon
+// @Description  Adds a percentage or fixed-amount coupon code to one of the vendor's stores. Codes are case-insensitive and unique per store.
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        body body dto.CreateCouponRequest true "Create Coupon Request"
+// @Success      201  {object}  dto.CouponResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/coupons [post]
+func (ch *CouponHandler) CreateCoupon(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can create coupons")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCouponRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := ch.couponService.CreateCoupon(r.Context(), vendorID, req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListCoupons godoc
+// @Summary      List my store's coupons
+// @Description  Lists the coupons of one of the vendor's stores with their usage, newest first
+// @Tags         Coupons
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to the vendor's primary store)"
+// @Success      200  {array}   dto.CouponResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/coupons [get]
+func (ch *CouponHandler) ListCoupons(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view coupons")
+	if !ok {
+		return
+	}
+
+	response, err := ch.couponService.ListCoupons(r.Context(), vendorID, r.URL.Query().Get("store_id"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// UpdateCoupon godoc
+// @Summary      Update a coupon
+// @Description  Changes the fields that are set. A max_uses of 0 removes the limit and empty times clear them.
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        couponId path string true "Coupon ID"
+// @Param        body body dto.UpdateCouponRequest true "Update Coupon Request"
+// @Success      200  {object}  dto.CouponResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/coupons/{couponId} [put]
+func (ch *CouponHandler) UpdateCoupon(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can update coupons")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCouponRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := ch.couponService.UpdateCoupon(r.Context(), vendorID, chi.URLParam(r, "couponId"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// DeleteCoupon godoc
+// @Summary      Delete a coupon
+// @Description  Deletes a coupon from one of the vendor's stores
+// @Tags         Coupons
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        couponId path string true "Coupon ID"
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/coupons/{couponId} [delete]
+func (ch *CouponHandler) DeleteCoupon(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can delete coupons")
+	if !ok {
+		return
+	}
+
+	if err := ch.couponService.DeleteCoupon(r.Context(), vendorID, chi.URLParam(r, "couponId")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "coupon deleted successfully"})
+}
+
+// CreateQuote godoc
+// @Summary      Price a cart
+// @Description  Prices a cart at current sale prices, applies an optional coupon and returns the WhatsApp order summary. Quotes don't count coupon uses; placing the order does.
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.QuoteRequest true "Quote Request"
+// @Success      200  {object}  dto.QuoteResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /quotes [post]
+func (ch *CouponHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	var req dto.QuoteRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := ch.couponService.Quote(r.Context(), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
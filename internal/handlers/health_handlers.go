@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	_ "github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type HealthHandler struct {
+	healthService *service.HealthService
+}
+
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is up and serving requests. It doesn't check dependencies, so an outage of the database doesn't get healthy instances restarted.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  dto.HealthResponse
+// @Router       /healthz [get]
+func (hh *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	utils.WriteJSON(w, http.StatusOK, hh.healthService.Liveness())
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Checks the database, file storage and schema version, each within HEALTH_CHECK_TIMEOUT, and reports each check's status and latency. Returns 503 when a check fails or while the server is shutting down.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  dto.HealthResponse
+// @Failure      503  {object}  dto.HealthResponse
+// @Router       /readyz [get]
+func (hh *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	resp, ready := hh.healthService.Readiness(r.Context())
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	utils.WriteJSON(w, status, resp)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type OrderHandler struct {
+	orderService *service.OrderService
+}
+
+func NewOrderHandler(orderService *service.OrderService) *OrderHandler {
+	return &OrderHandler{orderService: orderService}
+}
+
+// canManageOrders reports whether the role may reach store order endpoints.
+// Staff are further checked against their store membership by the service.
+func canManageOrders(role string) bool {
+	return role == "vendor" || role == "staff"
+}
+
+// PlaceOrder godoc
+// @Summary      Place an order
+// @Description  Prices a cart like a quote and saves it as an unpaid order, redeeming any coupon in the same transaction. The response carries the order reference for online payment and the WhatsApp order summary.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.PlaceOrderRequest true "Place Order Request"
+// @Success      201  {object}  dto.OrderResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /orders [post]
+func (oh *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var req dto.PlaceOrderRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := oh.orderService.PlaceOrder(r.Context(), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// GetOrder godoc
+// @Summary      Get an order
+// @Description  Returns an order and its payment status by the reference given to the buyer
+// @Tags         Orders
+// @Produce      json
+// @Param        reference path string true "Order reference"
+// @Success      200  {object}  dto.OrderResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /orders/{reference} [get]
+func (oh *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	response, err := oh.orderService.GetOrder(r.Context(), chi.URLParam(r, "reference"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ListMyStoreOrders godoc
+// @Summary      List my store's orders
+// @Description  Lists a store's orders with their payment status and references, newest first, for its owner or order staff
+// @Tags         Orders
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     VendorAPIKey
+// @Param        store_id query string false "Store ID (defaults to the user's primary store)"
+// @Param        payment_status query string false "Only orders with this payment status" Enums(unpaid, pending, paid, failed)
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 20, max: 100)"
+// @Success      200  {array}   dto.OrderResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/orders [get]
+func (oh *OrderHandler) ListMyStoreOrders(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	role, err := utils.GetRoleFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	if !canManageOrders(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can view store orders")
+		return
+	}
+
+	query := r.URL.Query()
+	page, pageSize := pageParams(r)
+	response, err := oh.orderService.ListStoreOrders(r.Context(), userID, query.Get("store_id"), query.Get("payment_status"), page, pageSize)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// GetMyStoreOrder godoc
+// @Summary      Get one of my store's orders
+// @Description  Returns an order with every payment attempt, including provider references, for the store's owner or order staff
+// @Tags         Orders
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     VendorAPIKey
+// @Param        orderId path string true "Order ID"
+// @Success      200  {object}  dto.OrderResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/orders/{orderId} [get]
+func (oh *OrderHandler) GetMyStoreOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	role, err := utils.GetRoleFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	if !canManageOrders(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can view store orders")
+		return
+	}
+
+	response, err := oh.orderService.GetStoreOrder(r.Context(), userID, chi.URLParam(r, "orderId"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
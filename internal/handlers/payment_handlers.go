@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// maxWebhookBody bounds webhook payloads, which are a few KB in practice
+const maxWebhookBody = 1 << 20
+
+type PaymentHandler struct {
+	paymentService *service.PaymentService
+}
+
+func NewPaymentHandler(paymentService *service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+// InitializePayment godoc
+// @Summary      Pay for an order
+// @Description  Starts an online payment of the order's total and returns the provider checkout URL to send the buyer to. The email defaults to the order's customer email.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        reference path string true "Order reference"
+// @Param        body body dto.InitializePaymentRequest false "Initialize Payment Request"
+// @Success      201  {object}  dto.InitializePaymentResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /orders/{reference}/pay [post]
+func (ph *PaymentHandler) InitializePayment(w http.ResponseWriter, r *http.Request) {
+	var req dto.InitializePaymentRequest
+	// The body is optional
+	if err := utils.DecodeJSON(w, r, &req); err != nil && !errors.Is(err, utils.ErrEmptyBody) {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := ph.paymentService.InitializePayment(r.Context(), chi.URLParam(r, "reference"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// VerifyPayment godoc
+// @Summary      Verify a payment
+// @Description  Checks a payment with the provider and records the result. Call it when the buyer returns from checkout.
+// @Tags         Payments
+// @Produce      json
+// @Param        reference query string true "Payment reference"
+// @Success      200  {object}  dto.PaymentResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /payments/verify [get]
+func (ph *PaymentHandler) VerifyPayment(w http.ResponseWriter, r *http.Request) {
+	reference := r.URL.Query().Get("reference")
+	if reference == "" {
+		utils.WriteError(w, http.StatusBadRequest, "reference is required")
+		return
+	}
+
+	response, err := ph.paymentService.VerifyPayment(r.Context(), reference)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// PaymentWebhook godoc
+// @Summary      Receive a payment webhook
+// @Description  Endpoint for provider notifications. Paystack events must carry a valid x-paystack-signature; events already processed are acknowledged without changes.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        provider path string true "Payment provider" Enums(paystack, fake)
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /webhooks/payments/{provider} [post]
+func (ph *PaymentHandler) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := ph.paymentService.HandleWebhook(r.Context(), chi.URLParam(r, "provider"), r.Header, body); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "event received"})
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -23,6 +22,12 @@ func NewProductHandler(service *service.ProductService, storage storage.Storage)
 	return &ProductHandler{service: service, storage: storage}
 }
 
+// canManageProducts reports whether the role may reach catalog endpoints.
+// Staff are further checked against their store membership by the service.
+func canManageProducts(role string) bool {
+	return role == "vendor" || role == "staff"
+}
+
 // CreateProduct godoc
 // @Summary      Create a new product
 // @Description  Creates a new product for the authenticated vendor
@@ -30,11 +35,13 @@ func NewProductHandler(service *service.ProductService, storage storage.Storage)
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        body body dto.CreateProductRequest true "Create Product Request"
 // @Success      201  {object}  dto.ProductResponse
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      401  {object}  utils.ErrorResponse
 // @Failure      403  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse "Slug already used in the store"
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /products [post]
 func (ph *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
@@ -50,14 +57,14 @@ func (ph *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can create products")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can create products")
 		return
 	}
 
 	var req dto.CreateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
 		return
 	}
 	defer r.Body.Close()
@@ -100,10 +107,12 @@ func (ph *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 
 // GetUserProducts godoc
 // @Summary      Get authenticated vendor's products
-// @Description  Retrieves all products for the currently authenticated vendor
+// @Description  Retrieves all products for the currently authenticated vendor, or for store_id when the caller is staff of that store
 // @Tags         Products
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
+// @Param        store_id query string false "Store ID (staff only)"
 // @Success      200  {array}   dto.ProductResponse
 // @Failure      401  {object}  utils.ErrorResponse
 // @Failure      403  {object}  utils.ErrorResponse
@@ -122,12 +131,12 @@ func (ph *ProductHandler) GetUserProducts(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if role != "vendor" {
+	if !canManageProducts(role) {
 		utils.WriteError(w, http.StatusForbidden, "only vendors can view their products")
 		return
 	}
 
-	response, err := ph.service.GetUserProducts(r.Context(), vendorID)
+	response, err := ph.service.GetManagedProducts(r.Context(), r.URL.Query().Get("store_id"), vendorID)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
@@ -142,6 +151,7 @@ func (ph *ProductHandler) GetUserProducts(w http.ResponseWriter, r *http.Request
 // @Tags         Products
 // @Produce      json
 // @Param        id   path      string  true  "Vendor ID"
+// @Param        sort query     string  false "Sort order: newest, price_asc, price_desc or rating"
 // @Success      200  {array}   dto.ProductResponse
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      404  {object}  utils.ErrorResponse
@@ -160,6 +170,11 @@ func (ph *ProductHandler) GetVendorProducts(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if err := service.SortProducts(response, r.URL.Query().Get("sort")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
 	utils.WriteJSON(w, http.StatusOK, response)
 }
 
@@ -170,6 +185,7 @@ func (ph *ProductHandler) GetVendorProducts(w http.ResponseWriter, r *http.Reque
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        id   path      string  true  "Product ID"
 // @Param        body body      dto.UpdateProductRequest true "Update Product Request"
 // @Success      200  {object}  dto.ProductResponse
@@ -177,6 +193,7 @@ func (ph *ProductHandler) GetVendorProducts(w http.ResponseWriter, r *http.Reque
 // @Failure      401  {object}  utils.ErrorResponse
 // @Failure      403  {object}  utils.ErrorResponse
 // @Failure      404  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse "Slug already used in the store"
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /products/{id} [put]
 func (ph *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
@@ -198,24 +215,20 @@ func (ph *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can update products")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can update products")
 		return
 	}
 
 	var req dto.UpdateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
 	response, err := ph.service.UpdateProduct(r.Context(), productID, vendorID, req)
 	if err != nil {
-		if err.Error() == "unauthorized: product does not belong to this vendor" {
-			utils.WriteError(w, http.StatusForbidden, err.Error())
-			return
-		}
 		utils.HandleServiceError(w, err)
 		return
 	}
@@ -229,6 +242,7 @@ func (ph *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request)
 // @Tags         Products
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        id   path      string  true  "Product ID"
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  utils.ErrorResponse
@@ -256,17 +270,13 @@ func (ph *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can delete products")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can delete products")
 		return
 	}
 
 	err = ph.service.DeleteProduct(r.Context(), productID, vendorID)
 	if err != nil {
-		if err.Error() == "unauthorized: product does not belong to this vendor" {
-			utils.WriteError(w, http.StatusForbidden, err.Error())
-			return
-		}
 		utils.HandleServiceError(w, err)
 		return
 	}
@@ -276,20 +286,34 @@ func (ph *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request)
 
 // GetActiveProducts godoc
 // @Summary      Get active products
-// @Description  Retrieves all active products
+// @Description  Retrieves all active products. Responses carry an ETag; send it back in If-None-Match to get 304 Not Modified when nothing changed. Without If-None-Match, an If-Modified-Since no earlier than Last-Modified gets 304 too.
 // @Tags         Products
 // @Produce      json
+// @Param        sort query     string  false "Sort order: newest, price_asc, price_desc or rating"
+// @Param        If-None-Match header string false "ETag of a response already held"
+// @Param        If-Modified-Since header string false "Last-Modified of a response already held"
 // @Success      200  {array}   dto.ProductResponse
+// @Header       200  {string}  ETag "Tag of the response body"
+// @Header       200  {string}  Last-Modified "When the list was loaded, if it's cached"
+// @Success      304  "Not Modified"
+// @Failure      400  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /products/active [get]
 func (ph *ProductHandler) GetActiveProducts(w http.ResponseWriter, r *http.Request) {
-	responses, err := ph.service.GetActiveProducts(r.Context())
+	responses, loadedAt, err := ph.service.GetActiveProducts(r.Context())
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, responses)
+	if err := service.SortProducts(responses, r.URL.Query().Get("sort")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	// The list only changes when the cache loads it again, so its load time
+	// is a sound Last-Modified even for products that were removed
+	utils.WriteCacheableJSON(w, r, responses, loadedAt)
 }
 
 // GetActiveUserProducts godoc
@@ -298,6 +322,7 @@ func (ph *ProductHandler) GetActiveProducts(w http.ResponseWriter, r *http.Reque
 // @Tags         Products
 // @Produce      json
 // @Param        id   path      string  true  "Vendor ID"
+// @Param        sort query     string  false "Sort order: newest, price_asc, price_desc or rating"
 // @Success      200  {array}   dto.ProductResponse
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      404  {object}  utils.ErrorResponse
@@ -316,6 +341,11 @@ func (ph *ProductHandler) GetActiveUserProducts(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if err := service.SortProducts(responses, r.URL.Query().Get("sort")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
 	utils.WriteJSON(w, http.StatusOK, responses)
 }
 
@@ -326,6 +356,7 @@ func (ph *ProductHandler) GetActiveUserProducts(w http.ResponseWriter, r *http.R
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        id   path      string  true  "Product ID"
 // @Param        body body      dto.ToggleProductStatusRequest  true  "Toggle Status Request"
 // @Success      200  {object}  dto.ProductResponse
@@ -354,23 +385,19 @@ func (ph *ProductHandler) ToggleProductStatus(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can toggle product status")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can toggle product status")
 		return
 	}
 	var req dto.ToggleProductStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
 	response, err := ph.service.ToggleProductStatus(r.Context(), productID, vendorID, req.IsActive)
 	if err != nil {
-		if err.Error() == "unauthorized: product does not belong to this vendor" {
-			utils.WriteError(w, http.StatusForbidden, err.Error())
-			return
-		}
 		utils.HandleServiceError(w, err)
 		return
 	}
@@ -384,8 +411,10 @@ func (ph *ProductHandler) ToggleProductStatus(w http.ResponseWriter, r *http.Req
 // @Tags         Products
 // @Produce      json
 // @Param        q    query     string  true  "Search Term"
+// @Param        sort query     string  false "Sort order: newest, price_asc, price_desc or rating"
 // @Success      200  {array}   dto.ProductResponse
 // @Failure      400  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /products/search [get]
 func (ph *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
@@ -401,47 +430,44 @@ func (ph *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := service.SortProducts(responses, r.URL.Query().Get("sort")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
 	utils.WriteJSON(w, http.StatusOK, responses)
 }
 
 // GetProductsByPriceRange godoc
 // @Summary      Get products by price range
-// @Description  Retrieves products within a specified price range
+// @Description  Retrieves products priced in a currency within a price range. Amounts are decimals in the currency's major unit, e.g. 1500.50.
 // @Tags         Products
 // @Produce      json
-// @Param        min  query     number  true  "Minimum Price"
-// @Param        max  query     number  true  "Maximum Price"
+// @Param        min  query     string  true  "Minimum Price"
+// @Param        max  query     string  true  "Maximum Price"
+// @Param        currency query string  false "ISO 4217 currency code (default: NGN)"
+// @Param        sort query     string  false "Sort order: newest, price_asc, price_desc or rating"
 // @Success      200  {array}   dto.ProductResponse
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /products/price [get]
 func (ph *ProductHandler) GetProductsByPriceRange(w http.ResponseWriter, r *http.Request) {
-	minPriceStr := r.URL.Query().Get("min")
-	maxPriceStr := r.URL.Query().Get("max")
+	query := r.URL.Query()
+	minPrice := query.Get("min")
+	maxPrice := query.Get("max")
 
-	if minPriceStr == "" || maxPriceStr == "" {
+	if minPrice == "" || maxPrice == "" {
 		utils.WriteError(w, http.StatusBadRequest, "min and max price parameters are required")
 		return
 	}
 
-	var minPrice, maxPrice float64
-	_, err := utils.ParseFloat64(minPriceStr)
+	responses, err := ph.service.GetProductsByPriceRange(r.Context(), query.Get("currency"), minPrice, maxPrice)
 	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid min price")
-		return
-	}
-
-	maxPriceVal, err := utils.ParseFloat64(maxPriceStr)
-	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid max price")
+		utils.HandleServiceError(w, err)
 		return
 	}
 
-	minPrice, _ = utils.ParseFloat64(minPriceStr)
-	maxPrice = maxPriceVal
-
-	responses, err := ph.service.GetProductsByPriceRange(r.Context(), minPrice, maxPrice)
-	if err != nil {
+	if err := service.SortProducts(responses, r.URL.Query().Get("sort")); err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
@@ -456,6 +482,7 @@ func (ph *ProductHandler) GetProductsByPriceRange(w http.ResponseWriter, r *http
 // @Accept       multipart/form-data
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        productId path string true "Product ID"
 // @Param        image formData file true "Image file"
 // @Param        position formData integer false "Image position"
@@ -485,8 +512,8 @@ func (ph *ProductHandler) UploadProductImage(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can upload product images")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can upload product images")
 		return
 	}
 
@@ -515,7 +542,7 @@ func (ph *ProductHandler) UploadProductImage(w http.ResponseWriter, r *http.Requ
 	// Save file to Supabase and get public URL
 	imageURL, err := ph.storage.SaveFile(r.Context(), handler)
 	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		utils.HandleServiceError(w, err)
 		return
 	}
 
@@ -542,6 +569,7 @@ func (ph *ProductHandler) UploadProductImage(w http.ResponseWriter, r *http.Requ
 // @Tags         ProductImages
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        imageId path string true "Image ID"
 // @Success      200  {object}  map[string]string
 // @Failure      401  {object}  utils.ErrorResponse
@@ -568,17 +596,13 @@ func (ph *ProductHandler) DeleteProductImage(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can delete product images")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can delete product images")
 		return
 	}
 
 	err = ph.service.DeleteProductImage(r.Context(), imageID, vendorID)
 	if err != nil {
-		if err.Error() == "unauthorized: image does not belong to this vendor" {
-			utils.WriteError(w, http.StatusForbidden, err.Error())
-			return
-		}
 		utils.HandleServiceError(w, err)
 		return
 	}
@@ -593,6 +617,7 @@ func (ph *ProductHandler) DeleteProductImage(w http.ResponseWriter, r *http.Requ
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Security     VendorAPIKey
 // @Param        imageId path string true "Image ID"
 // @Param        body body dto.UploadProductImageRequest true "Position Request"
 // @Success      200  {object}  map[string]string
@@ -621,24 +646,20 @@ func (ph *ProductHandler) UpdateProductImagePosition(w http.ResponseWriter, r *h
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can update product images")
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can update product images")
 		return
 	}
 
 	var req dto.UploadProductImageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
 		return
 	}
 	defer r.Body.Close()
 
 	err = ph.service.UpdateProductImagePosition(r.Context(), imageID, vendorID, req.Position)
 	if err != nil {
-		if err.Error() == "unauthorized: image does not belong to this vendor" {
-			utils.WriteError(w, http.StatusForbidden, err.Error())
-			return
-		}
 		utils.HandleServiceError(w, err)
 		return
 	}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// productImportMaxBytes limits the size of an uploaded product CSV
+const productImportMaxBytes = 5 << 20
+
+type ProductImportHandler struct {
+	importService *service.ProductImportService
+	logger        *slog.Logger
+}
+
+func NewProductImportHandler(importService *service.ProductImportService, logger *slog.Logger) *ProductImportHandler {
+	return &ProductImportHandler{importService: importService, logger: logger}
+}
+
+// ImportProducts godoc
+// @Summary      Import products from CSV
+// @Description  Creates products from a CSV file with a header row. Columns: name, description, price (required), active, category and image_urls (separated by |). Each row is validated like POST /products and invalid rows are skipped. With dry_run=true the file is only checked and a preview returned; otherwise the valid rows are created in the background and the job returned for polling. At most 1000 rows and 5MB.
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     VendorAPIKey
+// @Param        file formData file true "Product CSV"
+// @Param        store_id query string false "Store ID (defaults to the caller's primary store)"
+// @Param        dry_run query bool false "Only validate and preview the import"
+// @Success      200  {object}  dto.ProductImportPreviewResponse "Dry run"
+// @Success      202  {object}  dto.ProductImportJobResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /products/import [post]
+func (ih *ProductImportHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireCatalogManager(w, r, "only vendors or store staff can import products")
+	if !ok {
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, productImportMaxBytes)
+	if err := r.ParseMultipartForm(productImportMaxBytes); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "failed to parse form data; the file must be at most 5MB")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	storeID := r.URL.Query().Get("store_id")
+	if dryRun {
+		var preview *dto.ProductImportPreviewResponse
+		if preview, err = ih.importService.PreviewImport(r.Context(), userID, storeID, file); err != nil {
+			utils.HandleServiceError(w, err)
+			return
+		}
+		utils.WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
+	var job *dto.ProductImportJobResponse
+	if job, err = ih.importService.StartImport(r.Context(), userID, storeID, file); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusAccepted, job)
+}
+
+// GetImportJob godoc
+// @Summary      Get a product import
+// @Description  Returns the progress of a CSV import and the rows that were skipped, with the reason for each
+// @Tags         Products
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     VendorAPIKey
+// @Param        jobId path string true "Import job ID"
+// @Success      200  {object}  dto.ProductImportJobResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /products/import/{jobId} [get]
+func (ih *ProductImportHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireCatalogManager(w, r, "only vendors or store staff can view product imports")
+	if !ok {
+		return
+	}
+
+	response, err := ih.importService.GetImportJob(r.Context(), userID, chi.URLParam(r, "jobId"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ExportProducts godoc
+// @Summary      Export products as CSV
+// @Description  Downloads every product of the store, active or not, in the import CSV format
+// @Tags         Products
+// @Produce      text/csv
+// @Security     ApiKeyAuth
+// @Security     VendorAPIKey
+// @Param        store_id query string false "Store ID (defaults to the caller's primary store)"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /products/export [get]
+func (ih *ProductImportHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireCatalogManager(w, r, "only vendors or store staff can export products")
+	if !ok {
+		return
+	}
+
+	filename, data, err := ih.importService.ExportProducts(r.Context(), userID, r.URL.Query().Get("store_id"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		ih.logger.WarnContext(r.Context(), "failed to write products export", "error", err)
+	}
+}
+
+// requireCatalogManager returns the caller's user ID when their role may
+// manage products, or writes the error response and returns false
+func requireCatalogManager(w http.ResponseWriter, r *http.Request, forbiddenMessage string) (string, bool) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return "", false
+	}
+
+	role, err := utils.GetRoleFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return "", false
+	}
+
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, forbiddenMessage)
+		return "", false
+	}
+
+	return userID, true
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type QRCodeHandler struct {
+	qrService *service.QRCodeService
+	logger    *slog.Logger
+}
+
+func NewQRCodeHandler(qrService *service.QRCodeService, logger *slog.Logger) *QRCodeHandler {
+	return &QRCodeHandler{qrService: qrService, logger: logger}
+}
+
+// GetStoreQRCode godoc
+// @Summary      Store QR code
+// @Description  Renders a QR code for the store's storefront link as PNG or SVG, or as a printable A4 PDF flyer with the store name and WhatsApp number. With logo=true the store logo is drawn in the centre and error correction is raised to at least high.
+// @Tags         Stores
+// @Produce      png
+// @Produce      image/svg+xml
+// @Produce      application/pdf
+// @Param        slug path string true "Store slug"
+// @Param        format query string false "png (default), svg or pdf"
+// @Param        size query int false "Width and height in pixels, 128-2048 (default 512); ignored for pdf"
+// @Param        error_correction query string false "low, medium (default), high or highest"
+// @Param        logo query bool false "Draw the store logo in the centre"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/qr [get]
+func (qh *QRCodeHandler) GetStoreQRCode(w http.ResponseWriter, r *http.Request) {
+	req, ok := qrCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	code, err := qh.qrService.StoreQRCode(r.Context(), chi.URLParam(r, "slug"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	qh.writeQRCode(w, r, code)
+}
+
+// GetProductQRCode godoc
+// @Summary      Product QR code
+// @Description  Renders a QR code for an active product's storefront link as PNG or SVG, or as a printable A4 PDF flyer with the product's name and price, the store name and WhatsApp number. With logo=true the store logo is drawn in the centre and error correction is raised to at least high.
+// @Tags         Stores
+// @Produce      png
+// @Produce      image/svg+xml
+// @Produce      application/pdf
+// @Param        slug path string true "Store slug"
+// @Param        productSlug path string true "Product slug"
+// @Param        format query string false "png (default), svg or pdf"
+// @Param        size query int false "Width and height in pixels, 128-2048 (default 512); ignored for pdf"
+// @Param        error_correction query string false "low, medium (default), high or highest"
+// @Param        logo query bool false "Draw the store logo in the centre"
+// @Success      200  {file}    file
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/products/{productSlug}/qr [get]
+func (qh *QRCodeHandler) GetProductQRCode(w http.ResponseWriter, r *http.Request) {
+	req, ok := qrCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	code, err := qh.qrService.ProductQRCode(r.Context(), chi.URLParam(r, "slug"), chi.URLParam(r, "productSlug"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	qh.writeQRCode(w, r, code)
+}
+
+// qrCodeRequest reads the QR code options from the query string, or writes
+// the error response and returns false
+func qrCodeRequest(w http.ResponseWriter, r *http.Request) (dto.QRCodeRequest, bool) {
+	query := r.URL.Query()
+	req := dto.QRCodeRequest{
+		Format:          query.Get("format"),
+		ErrorCorrection: query.Get("error_correction"),
+	}
+
+	if value := query.Get("size"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "size must be a whole number of pixels")
+			return req, false
+		}
+		req.Size = size
+	}
+	if value := query.Get("logo"); value != "" {
+		logo, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "logo must be true or false")
+			return req, false
+		}
+		req.Logo = logo
+	}
+
+	return req, true
+}
+
+func (qh *QRCodeHandler) writeQRCode(w http.ResponseWriter, r *http.Request, code *service.QRCode) {
+	w.Header().Set("Content-Type", code.ContentType)
+	w.Header().Set("Content-Disposition", `inline; filename="`+code.Filename+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("Content-Length", strconv.Itoa(len(code.Body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(code.Body); err != nil {
+		qh.logger.WarnContext(r.Context(), "failed to write QR code", "error", err)
+	}
+}
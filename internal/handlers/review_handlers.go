@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type ReviewHandler struct {
+	reviewService *service.ReviewService
+}
+
+func NewReviewHandler(reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewService: reviewService}
+}
+
+// pageParams reads the page and page_size query parameters, leaving
+// defaults to the service
+func pageParams(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	return page, pageSize
+}
+
+// CreateReview godoc
+// @Summary      Review a product
+// @Description  Leaves a 1-5 star rating and optional comment on an active product
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Param        productId path string true "Product ID"
+// @Param        body body dto.CreateReviewRequest true "Create Review Request"
+// @Success      201  {object}  dto.ReviewResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /products/{productId}/reviews [post]
+func (rh *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	if productID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "product id is required")
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := rh.reviewService.CreateReview(r.Context(), productID, req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListProductReviews godoc
+// @Summary      List product reviews
+// @Description  Lists the published reviews of a product, newest first
+// @Tags         Reviews
+// @Produce      json
+// @Param        productId path string true "Product ID"
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 20, max: 100)"
+// @Success      200  {array}   dto.ReviewResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /products/{productId}/reviews [get]
+func (rh *ReviewHandler) ListProductReviews(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productId")
+	page, pageSize := pageParams(r)
+
+	response, err := rh.reviewService.ListProductReviews(r.Context(), productID, page, pageSize)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ListMyStoreReviews godoc
+// @Summary      List my store's reviews
+// @Description  Lists all reviews of a store's products, including hidden ones, for its owner or catalog staff
+// @Tags         Reviews
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to the vendor's primary store)"
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 20, max: 100)"
+// @Success      200  {array}   dto.ReviewResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/reviews [get]
+func (rh *ReviewHandler) ListMyStoreReviews(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	role, err := utils.GetRoleFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can view store reviews")
+		return
+	}
+
+	page, pageSize := pageParams(r)
+	response, err := rh.reviewService.ListStoreReviews(r.Context(), userID, r.URL.Query().Get("store_id"), page, pageSize)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ReplyToReview godoc
+// @Summary      Reply to a review
+// @Description  Sets the store's public reply to a review. An empty reply removes it.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        reviewId path string true "Review ID"
+// @Param        body body dto.ReviewReplyRequest true "Reply Request"
+// @Success      200  {object}  dto.ReviewResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /reviews/{reviewId}/reply [put]
+func (rh *ReviewHandler) ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	role, err := utils.GetRoleFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	if !canManageProducts(role) {
+		utils.WriteError(w, http.StatusForbidden, "only vendors or store staff can reply to reviews")
+		return
+	}
+
+	var req dto.ReviewReplyRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := rh.reviewService.ReplyToReview(r.Context(), userID, chi.URLParam(r, "reviewId"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ListReviews godoc
+// @Summary      List reviews for moderation
+// @Description  Lists reviews across all stores, newest first, optionally filtered by status
+// @Tags         Admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        status query string false "published or hidden"
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 20, max: 100)"
+// @Success      200  {array}   dto.ReviewResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /admin/reviews [get]
+func (rh *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := pageParams(r)
+
+	response, err := rh.reviewService.ListReviewsForModeration(r.Context(), r.URL.Query().Get("status"), page, pageSize)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// HideReview godoc
+// @Summary      Hide a review
+// @Description  Hides a review from public listings and removes it from ratings
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        reviewId path string true "Review ID"
+// @Param        body body dto.ModerateReviewRequest false "Moderation note"
+// @Success      200  {object}  dto.ReviewResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /admin/reviews/{reviewId}/hide [put]
+func (rh *ReviewHandler) HideReview(w http.ResponseWriter, r *http.Request) {
+	rh.moderateReview(w, r, rh.reviewService.HideReview)
+}
+
+// PublishReview godoc
+// @Summary      Publish a review
+// @Description  Restores a hidden review to public listings and ratings
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        reviewId path string true "Review ID"
+// @Param        body body dto.ModerateReviewRequest false "Moderation note"
+// @Success      200  {object}  dto.ReviewResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /admin/reviews/{reviewId}/publish [put]
+func (rh *ReviewHandler) PublishReview(w http.ResponseWriter, r *http.Request) {
+	rh.moderateReview(w, r, rh.reviewService.PublishReview)
+}
+
+func (rh *ReviewHandler) moderateReview(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, reviewID string, req dto.ModerateReviewRequest) (*dto.ReviewResponse, error),
+) {
+	// The note is optional, so an empty body is allowed
+	var req dto.ModerateReviewRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil && !errors.Is(err, utils.ErrEmptyBody) {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := apply(r.Context(), chi.URLParam(r, "reviewId"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
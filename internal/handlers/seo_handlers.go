@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type SEOHandler struct {
+	seoService *service.SEOService
+	logger     *slog.Logger
+}
+
+func NewSEOHandler(seoService *service.SEOService, logger *slog.Logger) *SEOHandler {
+	return &SEOHandler{seoService: seoService, logger: logger}
+}
+
+// GetStoreMeta godoc
+// @Summary      Store page metadata
+// @Description  Returns Open Graph and Twitter card metadata for a store page: name, bio, cover image (banner, else logo) and canonical URL, with the tags ready to render
+// @Tags         SEO
+// @Produce      json
+// @Param        slug path string true "Store slug"
+// @Success      200  {object}  dto.PageMetaResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/meta [get]
+func (sh *SEOHandler) GetStoreMeta(w http.ResponseWriter, r *http.Request) {
+	var meta *dto.PageMetaResponse
+	var err error
+	if meta, err = sh.seoService.GetStoreMeta(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, meta)
+}
+
+// GetProductMeta godoc
+// @Summary      Product page metadata
+// @Description  Returns Open Graph and Twitter card metadata for an active product's page: title, description, first image, current price and canonical URL, with the tags ready to render
+// @Tags         SEO
+// @Produce      json
+// @Param        slug path string true "Store slug"
+// @Param        productSlug path string true "Product slug"
+// @Success      200  {object}  dto.PageMetaResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/products/{productSlug}/meta [get]
+func (sh *SEOHandler) GetProductMeta(w http.ResponseWriter, r *http.Request) {
+	meta, err := sh.seoService.GetProductMeta(r.Context(), chi.URLParam(r, "slug"), chi.URLParam(r, "productSlug"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, meta)
+}
+
+// Sitemap godoc
+// @Summary      Sitemap
+// @Description  Lists the storefront pages of every store of an active vendor and of their active products, regenerated at most hourly
+// @Tags         SEO
+// @Produce      xml
+// @Success      200  {file}    file
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /sitemap.xml [get]
+func (sh *SEOHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	sitemap, generatedAt, err := sh.seoService.Sitemap(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("Last-Modified", generatedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.Itoa(len(sitemap)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(sitemap); err != nil {
+		sh.logger.WarnContext(r.Context(), "failed to write sitemap", "error", err)
+	}
+}
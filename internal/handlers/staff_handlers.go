@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type StaffHandler struct {
+	staffService *service.StaffService
+}
+
+func NewStaffHandler(staffService *service.StaffService) *StaffHandler {
+	return &StaffHandler{staffService: staffService}
+}
+
+// InviteStaff godoc
+// @Summary      Invite a staff member
+// @Description  Invites a staff member by email to one of the authenticated vendor's stores. The returned invite token is shown once.
+// @Tags         Staff
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Param        body body dto.InviteStaffRequest true "Invite Staff Request"
+// @Success      201  {object}  dto.InviteStaffResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/staff [post]
+func (sh *StaffHandler) InviteStaff(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can invite staff")
+	if !ok {
+		return
+	}
+
+	var req dto.InviteStaffRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	storeID := r.URL.Query().Get("store_id")
+	response, err := sh.staffService.InviteStaff(r.Context(), vendorID, storeID, req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListStaff godoc
+// @Summary      List store staff
+// @Description  Lists staff members and pending invites of one of the authenticated vendor's stores
+// @Tags         Staff
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Success      200  {array}   dto.StoreMemberResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/staff [get]
+func (sh *StaffHandler) ListStaff(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view their staff")
+	if !ok {
+		return
+	}
+
+	storeID := r.URL.Query().Get("store_id")
+	response, err := sh.staffService.ListStaff(r.Context(), vendorID, storeID)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// UpdateStaffRole godoc
+// @Summary      Change a staff member's role
+// @Description  Updates the role of a staff member or pending invite
+// @Tags         Staff
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        memberId path string true "Member ID"
+// @Param        body body dto.UpdateStaffRoleRequest true "Update Role Request"
+// @Success      200  {object}  dto.StoreMemberResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/staff/{memberId} [put]
+func (sh *StaffHandler) UpdateStaffRole(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can manage staff")
+	if !ok {
+		return
+	}
+
+	memberID := chi.URLParam(r, "memberId")
+	if memberID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "member id is required")
+		return
+	}
+
+	var req dto.UpdateStaffRoleRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := sh.staffService.UpdateStaffRole(r.Context(), vendorID, memberID, req.Role)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// RemoveStaff godoc
+// @Summary      Remove a staff member
+// @Description  Removes a staff member or revokes a pending invite
+// @Tags         Staff
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        memberId path string true "Member ID"
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/staff/{memberId} [delete]
+func (sh *StaffHandler) RemoveStaff(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can manage staff")
+	if !ok {
+		return
+	}
+
+	memberID := chi.URLParam(r, "memberId")
+	if memberID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "member id is required")
+		return
+	}
+
+	if err := sh.staffService.RemoveStaff(r.Context(), vendorID, memberID); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "staff member removed successfully"})
+}
+
+// AcceptInvite godoc
+// @Summary      Accept a staff invite
+// @Description  Redeems an invite token, creating a staff account if the invited email has none, and returns a JWT
+// @Tags         Staff
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.AcceptInviteRequest true "Accept Invite Request"
+// @Success      200  {object}  dto.AuthResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /auth/accept-invite [post]
+func (sh *StaffHandler) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	var req dto.AcceptInviteRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := sh.staffService.AcceptInvite(r.Context(), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// GetMyMemberships godoc
+// @Summary      List my stores
+// @Description  Lists the stores the authenticated user owns (role "owner") or has joined as staff, with their role
+// @Tags         Staff
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {array}   dto.StoreMemberResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /me/stores [get]
+func (sh *StaffHandler) GetMyMemberships(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	response, err := sh.staffService.ListMyStores(r.Context(), userID)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// requireVendor returns the caller's user ID if they are a vendor, writing an
+// error response and returning false otherwise.
+func requireVendor(w http.ResponseWriter, r *http.Request, forbiddenMessage string) (string, bool) {
+	vendorID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return "", false
+	}
+
+	role, err := utils.GetRoleFromContext(r.Context())
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return "", false
+	}
+
+	if role != "vendor" {
+		utils.WriteError(w, http.StatusForbidden, forbiddenMessage)
+		return "", false
+	}
+
+	return vendorID, true
+}
@@ -1,39 +1,55 @@
 package handlers
 
 import (
-	"encoding/json"
-	"log"
 	"net/http"
+	"path"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
 	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/storage"
 	"github.com/falasefemi2/vendorhub/internal/utils"
 )
 
 type StoreHandler struct {
-	userService    *service.AuthService
+	storeService   *service.StoreService
 	productService *service.ProductService
+	storage        storage.Storage
 }
 
-func NewStoreHandler(userService *service.AuthService, productService *service.ProductService) *StoreHandler {
+func NewStoreHandler(storeService *service.StoreService, productService *service.ProductService, storage storage.Storage) *StoreHandler {
 	return &StoreHandler{
-		userService:    userService,
+		storeService:   storeService,
 		productService: productService,
+		storage:        storage,
+	}
+}
+
+func (sh *StoreHandler) storeDetails(store *models.Store, products []*dto.ProductResponse) *dto.StoreDetailsResponse {
+	return &dto.StoreDetailsResponse{
+		Store:    service.MapStoreToResponse(store),
+		Products: products,
+		StoreURL: sh.storeService.StoreURL(store),
 	}
 }
 
 // GetStoreBySlug godoc
 // @Summary      Get store by slug
-// @Description  Retrieves vendor's store and products by store slug (WhatsApp shareable link)
+// @Description  Retrieves a store and its active products by store slug (WhatsApp shareable link). A slug the store used before being renamed answers with a 301 to the current slug. Responses carry an ETag; send it back in If-None-Match to get 304 Not Modified when nothing changed.
 // @Tags         Stores
 // @Accept       json
 // @Produce      json
 // @Param        slug path string true "Store slug (e.g., pizzahut-lagos)"
+// @Param        sort query     string  false "Sort order: newest, price_asc, price_desc or rating"
+// @Param        If-None-Match header string false "ETag of a response already held"
 // @Success      200  {object}  dto.StoreDetailsResponse
+// @Header       200  {string}  ETag "Tag of the response body"
+// @Success      301  "Moved Permanently to the store's current slug"
+// @Success      304  "Not Modified"
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      404  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
@@ -44,38 +60,77 @@ func (sh *StoreHandler) GetStoreBySlug(w http.ResponseWriter, r *http.Request) {
 		utils.WriteError(w, http.StatusBadRequest, "store slug is required")
 		return
 	}
-	vendor, err := sh.userService.GetVendorBySlug(slugName)
+	store, err := sh.storeService.GetStoreBySlug(r.Context(), slugName)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
-	products, err := sh.productService.GetActiveProductsByUserID(r.Context(), vendor.ID)
+	if store.Slug != slugName {
+		target := *r.URL
+		target.Path = path.Join(path.Dir(r.URL.Path), store.Slug)
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		return
+	}
+	products, err := sh.productService.GetActiveProductsByStoreID(r.Context(), store.ID)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	response := &dto.StoreDetailsResponse{
-		Store: &dto.StoreResponse{
-			ID:             vendor.ID,
-			Name:           vendor.StoreName,
-			Slug:           vendor.StoreSlug,
-			Username:       vendor.Username,
-			Bio:            vendor.Bio,
-			WhatsappNumber: vendor.WhatsappNumber,
-			Email:          vendor.Email,
-			CreatedAt:      vendor.CreatedAt.Format(time.RFC3339),
-		},
-		Products: products,
-		StoreURL: "https://vendorhub-v2-frontend.vercel.app/stores/" + vendor.StoreSlug,
+	if err := service.SortProducts(products, r.URL.Query().Get("sort")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, response)
+	// No Last-Modified: whether the store is open changes with the clock
+	// rather than with any update, so only the ETag can tell
+	utils.WriteCacheableJSON(w, r, sh.storeDetails(store, products), time.Time{})
+}
+
+// GetStoreProduct godoc
+// @Summary      Get a store's product by slug
+// @Description  Retrieves an active product of a store by the store and product slugs, for product pages. A slug the store used before being renamed answers with a 301 to the current slug.
+// @Tags         Stores
+// @Produce      json
+// @Param        slug path string true "Store slug (e.g., pizzahut-lagos)"
+// @Param        productSlug path string true "Product slug (e.g., pepperoni-pizza)"
+// @Success      200  {object}  dto.StoreProductResponse
+// @Success      301  "Moved Permanently to the store's current slug"
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/{slug}/products/{productSlug} [get]
+func (sh *StoreHandler) GetStoreProduct(w http.ResponseWriter, r *http.Request) {
+	slugName := chi.URLParam(r, "slug")
+	productSlug := chi.URLParam(r, "productSlug")
+
+	store, err := sh.storeService.GetStoreBySlug(r.Context(), slugName)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	if store.Slug != slugName {
+		target := *r.URL
+		target.Path = "/stores/" + store.Slug + "/products/" + productSlug
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		return
+	}
+
+	product, err := sh.productService.GetStoreProductBySlug(r.Context(), store.ID, productSlug)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, &dto.StoreProductResponse{
+		Store:      service.MapStoreToResponse(store),
+		Product:    product,
+		ProductURL: sh.storeService.ProductURL(store, product.Slug),
+	})
 }
 
 // GetStoreByVendorID godoc
 // @Summary      Get store by vendor ID
-// @Description  Retrieves vendor's store and products by vendor ID
+// @Description  Retrieves a vendor's primary store and its active products by vendor ID
 // @Tags         Stores
 // @Accept       json
 // @Produce      json
@@ -92,153 +147,297 @@ func (sh *StoreHandler) GetStoreByVendorID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Get vendor
-	vendor, err := sh.userService.GetUserByID(vendorID)
+	store, err := sh.storeService.GetPrimaryStore(r.Context(), vendorID)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	// Get vendor's active products
-	products, err := sh.productService.GetActiveProductsByUserID(r.Context(), vendor.ID)
+	products, err := sh.productService.GetActiveProductsByStoreID(r.Context(), store.ID)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	// Build store response
-	response := &dto.StoreDetailsResponse{
-		Store: &dto.StoreResponse{
-			ID:             vendor.ID,
-			Name:           vendor.StoreName,
-			Slug:           vendor.StoreSlug,
-			Username:       vendor.Username,
-			Bio:            vendor.Bio,
-			WhatsappNumber: vendor.WhatsappNumber,
-			Email:          vendor.Email,
-			CreatedAt:      vendor.CreatedAt.Format(time.RFC3339),
-		},
-		Products: products,
-		StoreURL: "https://vendorhub-v2-frontend.vercel.app/stores/" + vendor.StoreSlug,
+	utils.WriteJSON(w, http.StatusOK, sh.storeDetails(store, products))
+}
+
+// CreateStore godoc
+// @Summary      Open a new store
+// @Description  Creates an additional store owned by the authenticated vendor. The slug is derived from the name unless a custom one is given.
+// @Tags         Stores
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        body body dto.CreateStoreRequest true "Create Store Request"
+// @Success      201  {object}  dto.StoreResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores [post]
+func (sh *StoreHandler) CreateStore(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can create stores")
+	if !ok {
+		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, response)
+	var req dto.CreateStoreRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := sh.storeService.CreateStore(r.Context(), vendorID, req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
 }
 
 // UpdateMyStore godoc
-// @Summary      Update authenticated vendor's store
-// @Description  Updates the authenticated vendor's store information
+// @Summary      Update one of the authenticated vendor's stores
+// @Description  Updates the store given by store_id, or the vendor's primary store when omitted. Renaming the store or setting a custom slug keeps the old slug as a redirect. Also manages business hours, timezone and vacation mode.
 // @Tags         Stores
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
 // @Param        body body dto.UpdateStoreRequest true "Update Store Request"
 // @Success      200  {object}  dto.StoreResponse
 // @Failure      400  {object}  utils.ErrorResponse
 // @Failure      401  {object}  utils.ErrorResponse
 // @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      409  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /stores/my [put]
 func (sh *StoreHandler) UpdateMyStore(w http.ResponseWriter, r *http.Request) {
-	vendorID, err := utils.GetUserIDFromContext(r.Context())
-	if err != nil {
+	vendorID, ok := requireVendor(w, r, "only vendors can update their store")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateStoreRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
+	defer r.Body.Close()
 
-	role, err := utils.GetRoleFromContext(r.Context())
+	storeID := r.URL.Query().Get("store_id")
+	response, err := sh.storeService.UpdateStore(r.Context(), vendorID, storeID, req)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	log.Printf("Role from context: %s", role)
+	utils.WriteJSON(w, http.StatusOK, response)
+}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can update their store")
+// DeleteMyStore godoc
+// @Summary      Delete one of the authenticated vendor's stores
+// @Description  Deletes the store given by store_id together with its products and staff
+// @Tags         Stores
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string true "Store ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my [delete]
+func (sh *StoreHandler) DeleteMyStore(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can delete their store")
+	if !ok {
 		return
 	}
 
-	var req dto.UpdateStoreRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+	storeID := r.URL.Query().Get("store_id")
+	if storeID == "" {
+		utils.WriteError(w, http.StatusBadRequest, "store_id is required")
 		return
 	}
-	defer r.Body.Close()
 
-	response, err := sh.userService.UpdateVendorStore(r.Context(), vendorID, req)
-	if err != nil {
+	if err := sh.storeService.DeleteStore(r.Context(), vendorID, storeID); err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, response)
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "store deleted successfully"})
 }
 
-// GetMyStore godoc
-// @Summary      Get authenticated vendor's store
-// @Description  Retrieves the authenticated vendor's store and products
+// UploadStoreLogo godoc
+// @Summary      Upload store logo
+// @Description  Uploads a logo for one of the authenticated vendor's stores, replacing the current one
 // @Tags         Stores
-// @Accept       json
+// @Accept       mpfd
 // @Produce      json
 // @Security     ApiKeyAuth
-// @Success      200  {object}  dto.StoreDetailsResponse
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Param        image formData file true "Logo image (jpg, jpeg, png, gif, webp)"
+// @Success      200  {object}  dto.StoreResponse
+// @Failure      400  {object}  utils.ErrorResponse
 // @Failure      401  {object}  utils.ErrorResponse
 // @Failure      403  {object}  utils.ErrorResponse
 // @Failure      404  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
-// @Router       /stores/my [get]
-func (sh *StoreHandler) GetMyStore(w http.ResponseWriter, r *http.Request) {
-	vendorID, err := utils.GetUserIDFromContext(r.Context())
+// @Router       /stores/my/logo [put]
+func (sh *StoreHandler) UploadStoreLogo(w http.ResponseWriter, r *http.Request) {
+	sh.uploadStoreImage(w, r, models.StoreImageLogo)
+}
+
+// DeleteStoreLogo godoc
+// @Summary      Remove store logo
+// @Description  Removes the logo of one of the authenticated vendor's stores
+// @Tags         Stores
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Success      200  {object}  dto.StoreResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/logo [delete]
+func (sh *StoreHandler) DeleteStoreLogo(w http.ResponseWriter, r *http.Request) {
+	sh.deleteStoreImage(w, r, models.StoreImageLogo)
+}
+
+// UploadStoreBanner godoc
+// @Summary      Upload store banner
+// @Description  Uploads a banner for one of the authenticated vendor's stores, replacing the current one
+// @Tags         Stores
+// @Accept       mpfd
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Param        image formData file true "Banner image (jpg, jpeg, png, gif, webp)"
+// @Success      200  {object}  dto.StoreResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/banner [put]
+func (sh *StoreHandler) UploadStoreBanner(w http.ResponseWriter, r *http.Request) {
+	sh.uploadStoreImage(w, r, models.StoreImageBanner)
+}
+
+// DeleteStoreBanner godoc
+// @Summary      Remove store banner
+// @Description  Removes the banner of one of the authenticated vendor's stores
+// @Tags         Stores
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Success      200  {object}  dto.StoreResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/banner [delete]
+func (sh *StoreHandler) DeleteStoreBanner(w http.ResponseWriter, r *http.Request) {
+	sh.deleteStoreImage(w, r, models.StoreImageBanner)
+}
+
+func (sh *StoreHandler) uploadStoreImage(w http.ResponseWriter, r *http.Request, kind string) {
+	vendorID, ok := requireVendor(w, r, "only vendors can update their store")
+	if !ok {
+		return
+	}
+
+	// Parse multipart form with max 10MB size
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "failed to parse form data")
+		return
+	}
+
+	file, handler, err := r.FormFile("image")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "image file is required")
+		return
+	}
+	defer file.Close()
+
+	imageURL, err := sh.storage.SaveFile(r.Context(), handler)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	role, err := utils.GetRoleFromContext(r.Context())
+	storeID := r.URL.Query().Get("store_id")
+	response, err := sh.storeService.SetStoreImage(r.Context(), vendorID, storeID, kind, imageURL)
 	if err != nil {
+		// Clean up file if database operation fails
+		sh.storage.DeleteFile(r.Context(), imageURL)
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	if role != "vendor" {
-		utils.WriteError(w, http.StatusForbidden, "only vendors can view their store")
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+func (sh *StoreHandler) deleteStoreImage(w http.ResponseWriter, r *http.Request, kind string) {
+	vendorID, ok := requireVendor(w, r, "only vendors can update their store")
+	if !ok {
 		return
 	}
 
-	vendor, err := sh.userService.GetUserByID(vendorID)
+	storeID := r.URL.Query().Get("store_id")
+	response, err := sh.storeService.SetStoreImage(r.Context(), vendorID, storeID, kind, "")
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	products, err := sh.productService.GetProductsByUserID(r.Context(), vendorID)
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// GetMyStore godoc
+// @Summary      Get one of the authenticated vendor's stores
+// @Description  Retrieves the store given by store_id, or the vendor's primary store, with all its products
+// @Tags         Stores
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to primary store)"
+// @Success      200  {object}  dto.StoreDetailsResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my [get]
+func (sh *StoreHandler) GetMyStore(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view their store")
+	if !ok {
+		return
+	}
+
+	store, err := sh.storeService.GetOwnedStore(r.Context(), vendorID, r.URL.Query().Get("store_id"))
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	response := &dto.StoreDetailsResponse{
-		Store: &dto.StoreResponse{
-			ID:             vendor.ID,
-			Name:           vendor.StoreName,
-			Slug:           vendor.StoreSlug,
-			Username:       vendor.Username,
-			Bio:            vendor.Bio,
-			WhatsappNumber: vendor.WhatsappNumber,
-			Email:          vendor.Email,
-			CreatedAt:      vendor.CreatedAt.Format(time.RFC3339),
-		},
-		Products: products,
-		StoreURL: "https://vendorhub-v2-frontend.vercel.app/stores/" + vendor.StoreSlug,
+	products, err := sh.productService.GetProductsByStoreID(r.Context(), store.ID)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, response)
+	utils.WriteJSON(w, http.StatusOK, sh.storeDetails(store, products))
 }
 
 // GetAllStores godoc
-// @Summary      Get all vendor stores
-// @Description  Retrieves all active vendor stores with pagination support
+// @Summary      Get all stores
+// @Description  Retrieves all stores of approved vendors with pagination support
 // @Tags         Stores
 // @Accept       json
 // @Produce      json
@@ -267,38 +466,25 @@ func (sh *StoreHandler) GetAllStores(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	vendors, err := sh.userService.GetAllActiveVendors(page, pageSize)
+	stores, err := sh.storeService.ListStores(r.Context(), page, pageSize)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	var storeResponses []*dto.StoreResponse
-	for _, vendor := range vendors {
-		storeResponses = append(storeResponses, &dto.StoreResponse{
-			ID:             vendor.ID,
-			Name:           vendor.StoreName,
-			Slug:           vendor.StoreSlug,
-			Username:       vendor.Username,
-			Bio:            vendor.Bio,
-			WhatsappNumber: vendor.WhatsappNumber,
-			Email:          vendor.Email,
-			CreatedAt:      vendor.CreatedAt.Format(time.RFC3339),
-		})
-	}
-
-	utils.WriteJSON(w, http.StatusOK, storeResponses)
+	utils.WriteJSON(w, http.StatusOK, stores)
 }
 
 // SearchStores godoc
 // @Summary      Search stores
-// @Description  Searches for vendor stores by name or username
+// @Description  Searches stores by store name or owner name/username
 // @Tags         Stores
 // @Accept       json
 // @Produce      json
 // @Param        q query string true "Search term"
 // @Success      200  {array}   dto.StoreResponse
 // @Failure      400  {object}  utils.ErrorResponse
+// @Failure      429  {object}  utils.ErrorResponse
 // @Failure      500  {object}  utils.ErrorResponse
 // @Router       /stores/search [get]
 func (sh *StoreHandler) SearchStores(w http.ResponseWriter, r *http.Request) {
@@ -308,25 +494,11 @@ func (sh *StoreHandler) SearchStores(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vendors, err := sh.userService.SearchVendors(searchTerm)
+	stores, err := sh.storeService.SearchStores(r.Context(), searchTerm)
 	if err != nil {
 		utils.HandleServiceError(w, err)
 		return
 	}
 
-	var storeResponses []*dto.StoreResponse
-	for _, vendor := range vendors {
-		storeResponses = append(storeResponses, &dto.StoreResponse{
-			ID:             vendor.ID,
-			Name:           vendor.StoreName,
-			Slug:           vendor.StoreSlug,
-			Username:       vendor.Username,
-			Bio:            vendor.Bio,
-			WhatsappNumber: vendor.WhatsappNumber,
-			Email:          vendor.Email,
-			CreatedAt:      vendor.CreatedAt.Format(time.RFC3339),
-		})
-	}
-
-	utils.WriteJSON(w, http.StatusOK, storeResponses)
+	utils.WriteJSON(w, http.StatusOK, stores)
 }
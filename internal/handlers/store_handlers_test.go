@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// renamedStoreRepo knows one store, renamed from pizza-hut to
+// pizza-hut-lagos. Methods the tests don't use panic through the nil
+// embedded interface.
+type renamedStoreRepo struct {
+	service.StoreRepository
+}
+
+func (renamedStoreRepo) GetBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	return nil, utils.ErrStoreNotFound
+}
+
+func (renamedStoreRepo) GetByPreviousSlug(ctx context.Context, slug string) (*models.Store, error) {
+	if slug == "pizza-hut" {
+		return &models.Store{ID: "s1", Slug: "pizza-hut-lagos"}, nil
+	}
+	return nil, utils.ErrStoreNotFound
+}
+
+func TestStoreHandlerRedirectsPreviousSlugs(t *testing.T) {
+	storeService := service.NewStoreService(renamedStoreRepo{}, nil, nil, "", nil, nil)
+	handler := NewStoreHandler(storeService, nil, nil)
+
+	r := chi.NewRouter()
+	r.Get("/stores/{slug}", handler.GetStoreBySlug)
+	r.Get("/stores/{slug}/products/{productSlug}", handler.GetStoreProduct)
+
+	tests := []struct {
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{path: "/stores/pizza-hut?sort=price_asc", wantStatus: http.StatusMovedPermanently, wantLocation: "/stores/pizza-hut-lagos?sort=price_asc"},
+		{path: "/stores/pizza-hut/products/pepperoni", wantStatus: http.StatusMovedPermanently, wantLocation: "/stores/pizza-hut-lagos/products/pepperoni"},
+		{path: "/stores/unknown", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+
+		if w.Code != tt.wantStatus || w.Header().Get("Location") != tt.wantLocation {
+			t.Errorf("GET %s = %d, Location %q, want %d, %q", tt.path, w.Code, w.Header().Get("Location"), tt.wantStatus, tt.wantLocation)
+		}
+	}
+}
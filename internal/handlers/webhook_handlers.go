@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/service"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// CreateWebhook godoc
+// @Summary      Register a webhook
+// @Description  Registers a URL to receive signed POSTs for the chosen events (product.created, product.updated, product.deleted, order.created, order.paid). The signing secret is only returned here.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        body body dto.CreateWebhookRequest true "Create Webhook Request"
+// @Success      201  {object}  dto.WebhookEndpointResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/webhooks [post]
+func (wh *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can register webhooks")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := wh.webhookService.CreateEndpoint(r.Context(), vendorID, req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListWebhooks godoc
+// @Summary      List my store's webhooks
+// @Description  Lists the webhook endpoints of one of the vendor's stores
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        store_id query string false "Store ID (defaults to the vendor's primary store)"
+// @Success      200  {array}   dto.WebhookEndpointResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/webhooks [get]
+func (wh *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view webhooks")
+	if !ok {
+		return
+	}
+
+	response, err := wh.webhookService.ListEndpoints(r.Context(), vendorID, r.URL.Query().Get("store_id"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// UpdateWebhook godoc
+// @Summary      Update a webhook
+// @Description  Changes the fields that are set. With rotate_secret the signing secret is replaced and the new one returned.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        webhookId path string true "Webhook ID"
+// @Param        body body dto.UpdateWebhookRequest true "Update Webhook Request"
+// @Success      200  {object}  dto.WebhookEndpointResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/webhooks/{webhookId} [put]
+func (wh *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can update webhooks")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	response, err := wh.webhookService.UpdateEndpoint(r.Context(), vendorID, chi.URLParam(r, "webhookId"), req)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook
+// @Description  Removes a webhook endpoint and its delivery log
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        webhookId path string true "Webhook ID"
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/webhooks/{webhookId} [delete]
+func (wh *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can delete webhooks")
+	if !ok {
+		return
+	}
+
+	if err := wh.webhookService.DeleteEndpoint(r.Context(), vendorID, chi.URLParam(r, "webhookId")); err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "webhook deleted successfully"})
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Returns a webhook's delivery log, newest first, with the payload, attempts and the outcome of the latest attempt. Finished deliveries are kept for 30 days.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        webhookId path string true "Webhook ID"
+// @Param        status query string false "Only deliveries with this status" Enums(pending, succeeded, failed)
+// @Param        page query int false "Page number (default: 1)"
+// @Param        page_size query int false "Page size (default: 20, max: 100)"
+// @Success      200  {array}   dto.WebhookDeliveryResponse
+// @Failure      400  {object}  utils.ErrorResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/webhooks/{webhookId}/deliveries [get]
+func (wh *WebhookHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can view webhook deliveries")
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(r)
+	response, err := wh.webhookService.ListDeliveries(r.Context(), vendorID, chi.URLParam(r, "webhookId"), r.URL.Query().Get("status"), page, pageSize)
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// RedeliverWebhook godoc
+// @Summary      Redeliver a webhook
+// @Description  Queues a delivery to be sent again right away with a fresh retry schedule, whatever its status
+// @Tags         Webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        webhookId path string true "Webhook ID"
+// @Param        deliveryId path string true "Delivery ID"
+// @Success      202  {object}  dto.WebhookDeliveryResponse
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /stores/my/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (wh *WebhookHandler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	vendorID, ok := requireVendor(w, r, "only vendors can redeliver webhooks")
+	if !ok {
+		return
+	}
+
+	response, err := wh.webhookService.Redeliver(r.Context(), vendorID, chi.URLParam(r, "webhookId"), chi.URLParam(r, "deliveryId"))
+	if err != nil {
+		utils.HandleServiceError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusAccepted, response)
+}
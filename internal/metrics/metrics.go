@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// NewRegistry returns a registry with the Go runtime and process
+// collectors, for the application's own collectors to join
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// Handler serves the registry's metrics in the Prometheus text format.
+// With a token set, scrapes must send it as "Authorization: Bearer <token>".
+// A collector that fails is logged and left out rather than failing the
+// scrape.
+func Handler(registry *prometheus.Registry, token string, logger *slog.Logger) http.Handler {
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(logger.Handler(), slog.LevelError),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+	if token == "" {
+		return handler
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			utils.WriteError(w, http.StatusUnauthorized, "invalid metrics token")
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
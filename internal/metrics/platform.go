@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+// PlatformStatsSource counts the platform-wide figures exported as
+// business metrics
+type PlatformStatsSource interface {
+	GetPlatformStats(ctx context.Context) (*models.PlatformStats, error)
+}
+
+// platformCollector queries the platform stats when scraped, so the
+// figures are as fresh as the scrape
+type platformCollector struct {
+	source PlatformStatsSource
+
+	vendors                  *prometheus.Desc
+	stores                   *prometheus.Desc
+	activeProducts           *prometheus.Desc
+	pendingWebhookDeliveries *prometheus.Desc
+	openProductImports       *prometheus.Desc
+}
+
+func NewPlatformCollector(source PlatformStatsSource) prometheus.Collector {
+	return &platformCollector{
+		source:                   source,
+		vendors:                  prometheus.NewDesc("vendorhub_vendors", "Vendor accounts by approval status.", []string{"status"}, nil),
+		stores:                   prometheus.NewDesc("vendorhub_stores", "Stores on the platform.", nil, nil),
+		activeProducts:           prometheus.NewDesc("vendorhub_active_products", "Products listed as active.", nil, nil),
+		pendingWebhookDeliveries: prometheus.NewDesc("vendorhub_pending_webhook_deliveries", "Webhook deliveries waiting to be sent or retried.", nil, nil),
+		openProductImports:       prometheus.NewDesc("vendorhub_open_product_imports", "Product CSV imports not yet completed.", nil, nil),
+	}
+}
+
+func (c *platformCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.vendors
+	ch <- c.stores
+	ch <- c.activeProducts
+	ch <- c.pendingWebhookDeliveries
+	ch <- c.openProductImports
+}
+
+func (c *platformCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := c.source.GetPlatformStats(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.vendors, err)
+		return
+	}
+
+	gauge := func(desc *prometheus.Desc, value int, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(value), labels...)
+	}
+	gauge(c.vendors, stats.PendingVendors, "pending")
+	gauge(c.vendors, stats.ApprovedVendors, "approved")
+	gauge(c.stores, stats.Stores)
+	gauge(c.activeProducts, stats.ActiveProducts)
+	gauge(c.pendingWebhookDeliveries, stats.PendingWebhookDeliveries)
+	gauge(c.openProductImports, stats.OpenProductImports)
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// accessLogEntry collects what inner handlers learn about a request, such
+// as who made it, for the line AccessLog writes once it has been served
+type accessLogEntry struct {
+	userID string
+	err    error
+}
+
+// accessLogWriter lets utils.HandleServiceError record the cause of an
+// internal server error in the access log line
+type accessLogWriter struct {
+	chimiddleware.WrapResponseWriter
+	entry *accessLogEntry
+}
+
+func (w accessLogWriter) RecordError(err error) {
+	w.entry.err = err
+}
+
+type accessLogKey struct{}
+
+// setAccessLogUserID records the authenticated user of the request ctx
+// belongs to in its access log line
+func setAccessLogUserID(ctx context.Context, userID string) {
+	if entry, ok := ctx.Value(accessLogKey{}).(*accessLogEntry); ok {
+		entry.userID = userID
+	}
+}
+
+// AccessLog writes a line to logger for every request served, with its
+// route, status, size, latency and authenticated user. Server errors are
+// logged at error level with their cause, and health checks at debug level.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := &accessLogEntry{}
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(accessLogWriter{ww, entry}, r.WithContext(context.WithValue(r.Context(), accessLogKey{}, entry)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case isHealthCheck(r.URL.Path):
+				level = slog.LevelDebug
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			}
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				attrs = append(attrs, slog.String("route", rctx.RoutePattern()))
+			}
+			if entry.userID != "" {
+				attrs = append(attrs, slog.String("user_id", entry.userID))
+			}
+			if entry.err != nil {
+				attrs = append(attrs, slog.String("error", entry.err.Error()))
+			}
+			logger.LogAttrs(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+// isHealthCheck reports whether path is one of the probe endpoints, which
+// are polled too often to be worth logging or tracing by default
+func isHealthCheck(path string) bool {
+	return path == "/health" || path == "/healthz" || path == "/readyz"
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/ratelimit"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// RateLimitKey is what requests are counted by
+type RateLimitKey int
+
+const (
+	// ByIP counts requests per client address. IPv6 clients are counted
+	// per /64, which is usually one customer's network.
+	ByIP RateLimitKey = iota
+	// ByUser counts requests per authenticated user, and falls back to
+	// ByIP for anonymous requests
+	ByUser
+	// ByAPIKey counts requests per API key, and falls back to ByUser for
+	// requests authenticated with a JWT
+	ByAPIKey
+)
+
+// RateLimitPolicy limits a group of routes. Routes sharing a policy share
+// each client's bucket.
+type RateLimitPolicy struct {
+	Name  string
+	Limit ratelimit.Limit
+	Key   RateLimitKey
+}
+
+// RateLimiter applies rate limit policies to routes, keeping buckets in a
+// ratelimit.Store
+type RateLimiter struct {
+	store          ratelimit.Store
+	clientIPHeader string
+	logger         *slog.Logger
+}
+
+// NewRateLimiter returns a limiter using store, or one letting every request
+// through when store is nil. clientIPHeader names the header a trusted
+// reverse proxy puts the client address in, e.g. X-Forwarded-For; without
+// one the connection's address is used.
+func NewRateLimiter(store ratelimit.Store, clientIPHeader string, logger *slog.Logger) *RateLimiter {
+	return &RateLimiter{store: store, clientIPHeader: clientIPHeader, logger: logger}
+}
+
+// Limit rejects requests over policy's limit with 429 Too Many Requests and
+// a Retry-After header. Every response carries the RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers. If the
+// store fails, requests are let through rather than turned away.
+func (rl *RateLimiter) Limit(policy RateLimitPolicy) func(http.Handler) http.Handler {
+	limit := policy.Limit
+	policyHeader := strconv.Itoa(limit.Burst) + ";w=" + strconv.Itoa(int(limit.Window().Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		if rl.store == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := rl.store.Take(r.Context(), policy.Name+":"+rl.key(r, policy.Key), limit)
+			if err != nil {
+				rl.logger.WarnContext(r.Context(), "rate limit check failed, allowing request", "policy", policy.Name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := w.Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", ceilSeconds(result.ResetAfter))
+			header.Set("RateLimit-Policy", policyHeader)
+
+			if !result.Allowed {
+				retryAfter := ceilSeconds(result.RetryAfter)
+				header.Set("Retry-After", retryAfter)
+				utils.WriteError(w, http.StatusTooManyRequests, "rate limit exceeded, retry in "+retryAfter+" seconds")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// key identifies the client of r as counted by kind
+func (rl *RateLimiter) key(r *http.Request, kind RateLimitKey) string {
+	if kind == ByAPIKey {
+		if apiKey, ok := utils.GetAPIKeyFromContext(r.Context()); ok {
+			return "key:" + apiKey.ID
+		}
+	}
+	if kind == ByAPIKey || kind == ByUser {
+		if userID, err := utils.GetUserIDFromContext(r.Context()); err == nil {
+			return "user:" + userID
+		}
+	}
+	return "ip:" + rl.clientNetwork(r)
+}
+
+// clientNetwork returns the address of the client, or its /64 for IPv6
+func (rl *RateLimiter) clientNetwork(r *http.Request) string {
+	address := utils.ClientIP(r, rl.clientIPHeader)
+	ip := net.ParseIP(address)
+	if ip == nil || ip.To4() != nil {
+		return address
+	}
+	return ip.Mask(net.CIDRMask(64, 128)).String()
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/ratelimit"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// failingStore is a ratelimit.Store that can't be reached
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimiterLimit(t *testing.T) {
+	limiter := NewRateLimiter(ratelimit.NewMemoryStore(), "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	policy := RateLimitPolicy{Name: "login", Limit: ratelimit.Limit{Rate: 2, Period: time.Minute, Burst: 2}, Key: ByIP}
+	handler := limiter.Limit(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/auth/login", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	tests := []struct {
+		remoteAddr    string
+		wantStatus    int
+		wantRemaining string
+		wantRetry     string
+	}{
+		{remoteAddr: "203.0.113.7:1000", wantStatus: http.StatusOK, wantRemaining: "1"},
+		{remoteAddr: "203.0.113.7:1001", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{remoteAddr: "203.0.113.7:1002", wantStatus: http.StatusTooManyRequests, wantRemaining: "0", wantRetry: "30"},
+		{remoteAddr: "203.0.113.8:1000", wantStatus: http.StatusOK, wantRemaining: "1"},
+		// Addresses in the same /64 share a bucket
+		{remoteAddr: "[2001:db8:1:2::1]:1000", wantStatus: http.StatusOK, wantRemaining: "1"},
+		{remoteAddr: "[2001:db8:1:2::ffff]:1000", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{remoteAddr: "[2001:db8:1:3::1]:1000", wantStatus: http.StatusOK, wantRemaining: "1"},
+	}
+
+	for i, tt := range tests {
+		w := request(tt.remoteAddr)
+		header := w.Header()
+		if w.Code != tt.wantStatus || header.Get("RateLimit-Remaining") != tt.wantRemaining || header.Get("Retry-After") != tt.wantRetry {
+			t.Errorf("request %d from %s = %d, remaining %q, retry after %q, want %d, %q, %q", i, tt.remoteAddr,
+				w.Code, header.Get("RateLimit-Remaining"), header.Get("Retry-After"), tt.wantStatus, tt.wantRemaining, tt.wantRetry)
+		}
+		if header.Get("RateLimit-Limit") != "2" || header.Get("RateLimit-Policy") != "2;w=60" {
+			t.Errorf("request %d: RateLimit-Limit %q, RateLimit-Policy %q", i, header.Get("RateLimit-Limit"), header.Get("RateLimit-Policy"))
+		}
+	}
+}
+
+func TestRateLimiterKey(t *testing.T) {
+	limiter := NewRateLimiter(nil, "X-Forwarded-For", nil)
+
+	tests := []struct {
+		name   string
+		kind   RateLimitKey
+		userID string
+		apiKey *models.APIKey
+		want   string
+	}{
+		{name: "ip", kind: ByIP, userID: "u1", want: "ip:198.51.100.1"},
+		{name: "user", kind: ByUser, userID: "u1", want: "user:u1"},
+		{name: "anonymous user", kind: ByUser, want: "ip:198.51.100.1"},
+		{name: "api key", kind: ByAPIKey, userID: "u1", apiKey: &models.APIKey{ID: "k1"}, want: "key:k1"},
+		{name: "api key route with a jwt", kind: ByAPIKey, userID: "u1", want: "user:u1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("X-Forwarded-For", "198.51.100.1")
+			ctx := r.Context()
+			if tt.userID != "" {
+				ctx = context.WithValue(ctx, utils.UserIDKey, tt.userID)
+			}
+			if tt.apiKey != nil {
+				ctx = context.WithValue(ctx, utils.APIKeyKey, tt.apiKey)
+			}
+
+			if got := limiter.key(r.WithContext(ctx), tt.kind); got != tt.want {
+				t.Errorf("key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterFailsOpen(t *testing.T) {
+	limiter := NewRateLimiter(failingStore{}, "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	called := false
+	handler := limiter.Limit(RateLimitPolicy{Name: "api", Limit: ratelimit.PerMinute(1)})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if !called || w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("called %t, status %d, RateLimit-Limit %q, want the request let through without headers", called, w.Code, w.Header().Get("RateLimit-Limit"))
+	}
+}
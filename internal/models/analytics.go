@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	EventStoreView     = "store_view"
+	EventProductView   = "product_view"
+	EventWhatsappClick = "whatsapp_click"
+)
+
+type AnalyticsEvent struct {
+	StoreID     string
+	ProductID   string
+	EventType   string
+	VisitorHash string
+	Day         time.Time
+}
+
+// DailyStat is the rolled-up count of one event type for a store on a day
+type DailyStat struct {
+	Day       time.Time
+	EventType string
+	Count     int
+}
+
+// ProductStat is a product's rolled-up counts over a date range
+type ProductStat struct {
+	ProductID      string
+	ProductName    string
+	Views          int
+	WhatsappClicks int
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, so keys can be told apart from JWTs
+const APIKeyPrefix = "vhk_"
+
+// APIKey lets a vendor's own systems act on one of their stores with the
+// scopes it was granted. Only a hash of the key is stored.
+type APIKey struct {
+	ID      string `json:"id"`
+	UserID  string `json:"user_id"`
+	StoreID string `json:"store_id"`
+	Name    string `json:"name"`
+	// Prefix is the start of the key, shown so vendors can tell keys apart
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Allows reports whether the key may perform an action requiring
+// permission on storeID
+func (k *APIKey) Allows(storeID, permission string) bool {
+	return k.StoreID == storeID && slices.Contains(k.Scopes, permission)
+}
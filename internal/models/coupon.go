@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type Coupon struct {
+	ID           string `json:"id"`
+	StoreID      string `json:"store_id"`
+	Code         string `json:"code"`
+	DiscountType string `json:"discount_type"`
+	// PercentOff applies to percentage coupons and AmountOff to fixed ones
+	PercentOff float64 `json:"percent_off"`
+	AmountOff  Money   `json:"amount_off"`
+	MinSpend   Money   `json:"min_spend"`
+	// MaxUses is nil for unlimited redemptions
+	MaxUses   *int       `json:"max_uses"`
+	UsedCount int        `json:"used_count"`
+	StartsAt  *time.Time `json:"starts_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+const (
+	CouponTypePercentage = "percentage"
+	CouponTypeFixed      = "fixed"
+)
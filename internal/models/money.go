@@ -0,0 +1,158 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor unit of a currency, e.g. kobo for NGN or
+// cents for USD. The currency itself comes from the store.
+type Money int64
+
+// Percent returns p percent of m, rounded half away from zero
+func (m Money) Percent(p float64) Money {
+	return Money(math.Round(float64(m) * p / 100))
+}
+
+type Currency struct {
+	Code   string
+	Symbol string
+	// Exponent is the number of minor-unit digits, 2 for kobo and cents
+	Exponent int
+}
+
+// DefaultCurrency is used for stores created before currencies existed and
+// when a store doesn't choose one
+const DefaultCurrency = "NGN"
+
+var currencies = map[string]Currency{
+	"NGN": {Code: "NGN", Symbol: "₦", Exponent: 2},
+	"GHS": {Code: "GHS", Symbol: "GH₵", Exponent: 2},
+	"KES": {Code: "KES", Symbol: "KSh", Exponent: 2},
+	"ZAR": {Code: "ZAR", Symbol: "R", Exponent: 2},
+	"EGP": {Code: "EGP", Symbol: "E£", Exponent: 2},
+	"TZS": {Code: "TZS", Symbol: "TSh", Exponent: 2},
+	"UGX": {Code: "UGX", Symbol: "USh", Exponent: 0},
+	"RWF": {Code: "RWF", Symbol: "FRw", Exponent: 0},
+	"XOF": {Code: "XOF", Symbol: "CFA", Exponent: 0},
+	"USD": {Code: "USD", Symbol: "$", Exponent: 2},
+	"EUR": {Code: "EUR", Symbol: "€", Exponent: 2},
+	"GBP": {Code: "GBP", Symbol: "£", Exponent: 2},
+}
+
+// LookupCurrency returns a supported currency by its ISO 4217 code
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	return currency, ok
+}
+
+// CurrencyOf returns the currency for code, falling back to the default
+// for unknown codes
+func CurrencyOf(code string) Currency {
+	if currency, ok := LookupCurrency(code); ok {
+		return currency
+	}
+	return currencies[DefaultCurrency]
+}
+
+// ScaleTo returns what amounts in c are multiplied and then divided by to
+// be the same value in the minor unit of to, e.g. 1 and 100 from NGN
+// (kobo) to UGX (whole shillings). One of the two is always 1.
+func (c Currency) ScaleTo(to Currency) (multiplier, divisor int64) {
+	multiplier, divisor = 1, 1
+	for range to.Exponent - c.Exponent {
+		multiplier *= 10
+	}
+	for range c.Exponent - to.Exponent {
+		divisor *= 10
+	}
+	return multiplier, divisor
+}
+
+var (
+	ErrInvalidAmount    = errors.New("amount must be a non-negative decimal like 1500.50")
+	ErrAmountTooPrecise = errors.New("amount has more decimal places than the currency allows")
+)
+
+// Parse converts a decimal amount in major units, e.g. "1500.5", to Money
+// without going through floating point
+func (c Currency) Parse(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalidAmount
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || (fraction != "" && !isDigits(fraction)) {
+		return 0, ErrInvalidAmount
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > c.Exponent {
+		return 0, ErrAmountTooPrecise
+	}
+	fraction += strings.Repeat("0", c.Exponent-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return Money(minor), nil
+}
+
+// Decimal renders m in major units with the currency's decimal places,
+// e.g. "1500.50"
+func (c Currency) Decimal(m Money) string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+
+	digits := strconv.FormatInt(int64(m), 10)
+	if c.Exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= c.Exponent {
+		digits = strings.Repeat("0", c.Exponent-len(digits)+1) + digits
+	}
+	split := len(digits) - c.Exponent
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// Format renders m for display with the currency symbol and thousands
+// separators, e.g. "₦1,500.50"
+func (c Currency) Format(m Money) string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+
+	whole, fraction, _ := strings.Cut(c.Decimal(m), ".")
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction != "" {
+		grouped.WriteString("." + fraction)
+	}
+
+	return sign + c.Symbol + grouped.String()
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
@@ -0,0 +1,140 @@
+package models
+
+import "testing"
+
+func TestCurrencyScaleTo(t *testing.T) {
+	ngn, ugx, usd := CurrencyOf("NGN"), CurrencyOf("UGX"), CurrencyOf("USD")
+
+	tests := []struct {
+		from, to            Currency
+		multiplier, divisor int64
+	}{
+		{from: ngn, to: usd, multiplier: 1, divisor: 1},
+		{from: ngn, to: ugx, multiplier: 1, divisor: 100},
+		{from: ugx, to: ngn, multiplier: 100, divisor: 1},
+	}
+
+	for _, tt := range tests {
+		multiplier, divisor := tt.from.ScaleTo(tt.to)
+		if multiplier != tt.multiplier || divisor != tt.divisor {
+			t.Errorf("%s.ScaleTo(%s) = %d, %d, want %d, %d", tt.from.Code, tt.to.Code, multiplier, divisor, tt.multiplier, tt.divisor)
+		}
+	}
+}
+
+func TestCurrencyParse(t *testing.T) {
+	ngn, ugx := CurrencyOf("NGN"), CurrencyOf("UGX")
+
+	tests := []struct {
+		name     string
+		currency Currency
+		value    string
+		want     Money
+		wantErr  error
+	}{
+		{name: "whole", currency: ngn, value: "1500", want: 150000},
+		{name: "two decimals", currency: ngn, value: "1500.50", want: 150050},
+		{name: "one decimal", currency: ngn, value: "1500.5", want: 150050},
+		{name: "trailing zeros beyond exponent", currency: ngn, value: "1.500", want: 150},
+		{name: "leading point", currency: ngn, value: ".75", want: 75},
+		{name: "trailing point", currency: ngn, value: "12.", want: 1200},
+		{name: "surrounding spaces", currency: ngn, value: " 9.99 ", want: 999},
+		{name: "zero", currency: ngn, value: "0", want: 0},
+		{name: "no minor unit", currency: ugx, value: "2500", want: 2500},
+		{name: "fraction without minor unit", currency: ugx, value: "2500.5", wantErr: ErrAmountTooPrecise},
+		{name: "too many decimals", currency: ngn, value: "1.505", wantErr: ErrAmountTooPrecise},
+		{name: "empty", currency: ngn, value: "", wantErr: ErrInvalidAmount},
+		{name: "point only", currency: ngn, value: ".", wantErr: ErrInvalidAmount},
+		{name: "negative", currency: ngn, value: "-5", wantErr: ErrInvalidAmount},
+		{name: "exponent notation", currency: ngn, value: "1e3", wantErr: ErrInvalidAmount},
+		{name: "thousands separator", currency: ngn, value: "1,500", wantErr: ErrInvalidAmount},
+		{name: "overflow", currency: ngn, value: "99999999999999999999", wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.currency.Parse(tt.value)
+			if err != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyDecimalAndFormat(t *testing.T) {
+	ngn, ugx, usd := CurrencyOf("NGN"), CurrencyOf("UGX"), CurrencyOf("USD")
+
+	tests := []struct {
+		currency      Currency
+		amount        Money
+		wantDecimal   string
+		wantFormatted string
+	}{
+		{currency: ngn, amount: 150050, wantDecimal: "1500.50", wantFormatted: "₦1,500.50"},
+		{currency: ngn, amount: 5, wantDecimal: "0.05", wantFormatted: "₦0.05"},
+		{currency: ngn, amount: 0, wantDecimal: "0.00", wantFormatted: "₦0.00"},
+		{currency: ngn, amount: 123456789, wantDecimal: "1234567.89", wantFormatted: "₦1,234,567.89"},
+		{currency: ngn, amount: -150050, wantDecimal: "-1500.50", wantFormatted: "-₦1,500.50"},
+		{currency: ugx, amount: 2500, wantDecimal: "2500", wantFormatted: "USh2,500"},
+		{currency: ugx, amount: 100, wantDecimal: "100", wantFormatted: "USh100"},
+		{currency: usd, amount: 100000, wantDecimal: "1000.00", wantFormatted: "$1,000.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.currency.Decimal(tt.amount); got != tt.wantDecimal {
+			t.Errorf("%s.Decimal(%d) = %q, want %q", tt.currency.Code, tt.amount, got, tt.wantDecimal)
+		}
+		if got := tt.currency.Format(tt.amount); got != tt.wantFormatted {
+			t.Errorf("%s.Format(%d) = %q, want %q", tt.currency.Code, tt.amount, got, tt.wantFormatted)
+		}
+	}
+}
+
+func TestCurrencyParseRoundTrip(t *testing.T) {
+	for _, code := range []string{"NGN", "UGX", "USD"} {
+		currency := CurrencyOf(code)
+		for _, amount := range []Money{0, 1, 99, 100, 150050, 1 << 40} {
+			parsed, err := currency.Parse(currency.Decimal(amount))
+			if err != nil || parsed != amount {
+				t.Errorf("%s: Parse(Decimal(%d)) = %d, %v", code, amount, parsed, err)
+			}
+		}
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	tests := []struct {
+		amount  Money
+		percent float64
+		want    Money
+	}{
+		{amount: 10000, percent: 10, want: 1000},
+		{amount: 999, percent: 10, want: 100},
+		{amount: 995, percent: 10, want: 100},
+		{amount: 994, percent: 10, want: 99},
+		{amount: 10000, percent: 12.5, want: 1250},
+		{amount: 10000, percent: 100, want: 10000},
+		{amount: 0, percent: 50, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Percent(tt.percent); got != tt.want {
+			t.Errorf("Money(%d).Percent(%v) = %d, want %d", tt.amount, tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestLookupCurrency(t *testing.T) {
+	if currency, ok := LookupCurrency(" ghs "); !ok || currency.Code != "GHS" {
+		t.Errorf("LookupCurrency(%q) = %+v, %t, want GHS", " ghs ", currency, ok)
+	}
+	if _, ok := LookupCurrency("XYZ"); ok {
+		t.Errorf("LookupCurrency(%q) found a currency", "XYZ")
+	}
+	if got := CurrencyOf("XYZ"); got.Code != DefaultCurrency {
+		t.Errorf("CurrencyOf(%q) = %s, want %s", "XYZ", got.Code, DefaultCurrency)
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type Order struct {
+	ID            string      `json:"id"`
+	StoreID       string      `json:"store_id"`
+	Reference     string      `json:"reference"`
+	CustomerName  string      `json:"customer_name"`
+	CustomerPhone string      `json:"customer_phone"`
+	CustomerEmail string      `json:"customer_email"`
+	Items         []OrderItem `json:"items"`
+	Currency      string      `json:"currency"`
+	Subtotal      Money       `json:"subtotal"`
+	Discount      Money       `json:"discount"`
+	Total         Money       `json:"total"`
+	CouponCode    string      `json:"coupon_code"`
+	PaymentStatus string      `json:"payment_status"`
+	// PaymentReference is the latest payment attempt, or the one that paid
+	PaymentReference string     `json:"payment_reference"`
+	PaidAt           *time.Time `json:"paid_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// OrderItem is a product line as priced when the order was placed
+type OrderItem struct {
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice Money  `json:"unit_price"`
+	LineTotal Money  `json:"line_total"`
+}
+
+const (
+	OrderPaymentUnpaid  = "unpaid"
+	OrderPaymentPending = "pending"
+	OrderPaymentPaid    = "paid"
+	OrderPaymentFailed  = "failed"
+)
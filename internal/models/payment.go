@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type Payment struct {
+	ID               string     `json:"id"`
+	OrderID          string     `json:"order_id"`
+	Provider         string     `json:"provider"`
+	Reference        string     `json:"reference"`
+	Amount           Money      `json:"amount"`
+	Currency         string     `json:"currency"`
+	Status           string     `json:"status"`
+	AuthorizationURL string     `json:"authorization_url"`
+	PaidAt           *time.Time `json:"paid_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+const (
+	PaymentStatusPending = "pending"
+	PaymentStatusSuccess = "success"
+	PaymentStatusFailed  = "failed"
+)
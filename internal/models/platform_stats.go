@@ -0,0 +1,12 @@
+package models
+
+// PlatformStats counts what admins watch across the whole platform,
+// exported as metrics
+type PlatformStats struct {
+	PendingVendors           int
+	ApprovedVendors          int
+	Stores                   int
+	ActiveProducts           int
+	PendingWebhookDeliveries int
+	OpenProductImports       int
+}
@@ -3,12 +3,32 @@ package models
 import "time"
 
 type Product struct {
-	ID          string    `json:"id"`
-	UserID      string    `json:"user_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	StoreID     string `json:"store_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       Money  `json:"price"`
+	IsActive    bool   `json:"is_active"`
+
+	// Copied from the store so prices can be compared in SQL
+	Currency string `json:"currency"`
+
+	// Optional sale price, in effect between the optional start and end
+	SalePrice    *Money     `json:"sale_price"`
+	SaleStartsAt *time.Time `json:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at"`
+
+	// Category is free text chosen by the vendor, e.g. "Shoes"
+	Category string `json:"category"`
+
+	// Slug addresses the product within its store, e.g. "ankara-dress"
+	Slug string `json:"slug"`
+
+	// Denormalised from published reviews
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int     `json:"rating_count"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
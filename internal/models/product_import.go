@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// ProductImportJob is a CSV import of products into a store. The file is
+// validated when it's uploaded and its valid rows are created in the
+// background.
+type ProductImportJob struct {
+	ID      string `json:"id"`
+	StoreID string `json:"store_id"`
+	UserID  string `json:"user_id"`
+	Status  string `json:"status"`
+	// TotalRows counts the data rows in the file, ValidRows those that
+	// passed validation and will be created
+	TotalRows     int `json:"total_rows"`
+	ValidRows     int `json:"valid_rows"`
+	ProcessedRows int `json:"processed_rows"`
+	CreatedRows   int `json:"created_rows"`
+	// Rows holds the valid rows until the job completes
+	Rows        []ProductImportRow   `json:"rows"`
+	Errors      []ProductImportError `json:"errors"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	CompletedAt *time.Time           `json:"completed_at"`
+}
+
+// ProductImportRow is a validated CSV row. Row is its number in the file,
+// counting the header as row 1.
+type ProductImportRow struct {
+	Row         int      `json:"row"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       Money    `json:"price"`
+	IsActive    bool     `json:"is_active"`
+	Category    string   `json:"category"`
+	ImageURLs   []string `json:"image_urls"`
+}
+
+// ProductImportError explains why a CSV row wasn't imported
+type ProductImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+const (
+	ProductImportPending   = "pending"
+	ProductImportRunning   = "running"
+	ProductImportCompleted = "completed"
+)
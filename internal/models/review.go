@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type Review struct {
+	ID              string     `json:"id"`
+	ProductID       string     `json:"product_id"`
+	StoreID         string     `json:"store_id"`
+	ReviewerName    string     `json:"reviewer_name"`
+	Rating          int        `json:"rating"`
+	Comment         string     `json:"comment"`
+	Status          string     `json:"status"`
+	ModerationNote  string     `json:"moderation_note"`
+	VendorReply     string     `json:"vendor_reply"`
+	VendorRepliedAt *time.Time `json:"vendor_replied_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+const (
+	ReviewStatusPublished = "published"
+	ReviewStatusHidden    = "hidden"
+)
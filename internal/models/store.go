@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+type Store struct {
+	ID             string `json:"id"`
+	OwnerID        string `json:"owner_id"`
+	Name           string `json:"name"`
+	Slug           string `json:"slug"`
+	Bio            string `json:"bio"`
+	WhatsappNumber string `json:"whatsapp_number"`
+	Currency       string `json:"currency"`
+
+	// Storefront profile
+	LogoURL       string `json:"logo_url"`
+	BannerURL     string `json:"banner_url"`
+	PrimaryColor  string `json:"primary_color"`
+	AccentColor   string `json:"accent_color"`
+	InstagramURL  string `json:"instagram_url"`
+	TiktokURL     string `json:"tiktok_url"`
+	FacebookURL   string `json:"facebook_url"`
+	Location      string `json:"location"`
+	OpeningHours  string `json:"opening_hours"`
+	DeliveryNotes string `json:"delivery_notes"`
+
+	// Business hours and vacation mode
+	Timezone              string          `json:"timezone"`
+	BusinessHours         []BusinessHours `json:"business_hours"`
+	VacationMode          bool            `json:"vacation_mode"`
+	VacationStartsAt      *time.Time      `json:"vacation_starts_at"`
+	VacationEndsAt        *time.Time      `json:"vacation_ends_at"`
+	VacationMessage       string          `json:"vacation_message"`
+	BlockOrdersWhenClosed bool            `json:"block_orders_when_closed"`
+
+	// Denormalised from published reviews of the store's products
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int     `json:"rating_count"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Owner details joined from users for public store responses
+	OwnerUsername string `json:"owner_username"`
+	OwnerEmail    string `json:"owner_email"`
+}
+
+// BusinessHours is one opening interval in the store's timezone. Times are
+// "HH:MM"; a Closes earlier than Opens runs past midnight into the next day.
+type BusinessHours struct {
+	Day    string `json:"day"`
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+const DefaultStoreTimezone = "Africa/Lagos"
+
+const (
+	StoreImageLogo   = "logo"
+	StoreImageBanner = "banner"
+)
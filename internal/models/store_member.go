@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	StaffRoleCatalogEditor = "catalog_editor"
+	StaffRoleOrderManager  = "order_manager"
+
+	MemberStatusInvited = "invited"
+	MemberStatusActive  = "active"
+)
+
+// StoreMember links a staff user to a vendor's store. Until the invite is
+// accepted UserID is empty and the member is identified by Email only.
+type StoreMember struct {
+	ID              string     `json:"id"`
+	StoreID         string     `json:"store_id"`
+	UserID          string     `json:"user_id"`
+	Email           string     `json:"email"`
+	Role            string     `json:"role"`
+	Status          string     `json:"status"`
+	InviteTokenHash string     `json:"-"`
+	InvitedBy       string     `json:"invited_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	AcceptedAt      *time.Time `json:"accepted_at"`
+}
@@ -10,8 +10,6 @@ type User struct {
 	WhatsappNumber string    `json:"whatsapp_number"`
 	Username       string    `json:"username"`
 	Bio            string    `json:"bio"`
-	StoreName      string    `json:"store_name"`
-	StoreSlug      string    `json:"store_slug"`
 	Role           string    `json:"role"` // admin | vendor
 	IsActive       bool      `json:"is_active"`
 	CreatedAt      time.Time `json:"created_at"`
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEndpoint is a URL a store has asked to be notified at
+type WebhookEndpoint struct {
+	ID      string `json:"id"`
+	StoreID string `json:"store_id"`
+	URL     string `json:"url"`
+	// Secret keys the HMAC signature of every payload sent to the URL
+	Secret      string    `json:"-"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is one event queued for one endpoint, along with the
+// outcome of its latest attempt
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	EndpointID     string          `json:"endpoint_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      string          `json:"last_error"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+const (
+	WebhookEventProductCreated = "product.created"
+	WebhookEventProductUpdated = "product.updated"
+	WebhookEventProductDeleted = "product.deleted"
+	WebhookEventOrderCreated   = "order.created"
+	WebhookEventOrderPaid      = "order.paid"
+)
+
+// WebhookEventTypes lists the events endpoints can subscribe to
+var WebhookEventTypes = []string{
+	WebhookEventProductCreated,
+	WebhookEventProductUpdated,
+	WebhookEventProductDeleted,
+	WebhookEventOrderCreated,
+	WebhookEventOrderPaid,
+}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
@@ -0,0 +1,60 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestFakeProviderCheckout(t *testing.T) {
+	provider := NewFakeProvider()
+	ctx := context.Background()
+
+	checkout, err := provider.Initialize(ctx, InitializeRequest{
+		Reference:   "VH-ABC-1234-1",
+		Amount:      150050,
+		Currency:    "NGN",
+		CallbackURL: "https://shop.example.com/paid?order=VH-ABC-1234",
+	})
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	callback, err := url.Parse(checkout.AuthorizationURL)
+	if err != nil {
+		t.Fatalf("AuthorizationURL %q: %v", checkout.AuthorizationURL, err)
+	}
+	if callback.Host != "shop.example.com" || callback.Query().Get("reference") != "VH-ABC-1234-1" || callback.Query().Get("order") != "VH-ABC-1234" {
+		t.Errorf("AuthorizationURL = %q", checkout.AuthorizationURL)
+	}
+
+	transaction, err := provider.Verify(ctx, "VH-ABC-1234-1")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if transaction.Status != StatusSuccess || transaction.PaidAt == nil || transaction.Amount != 150050 {
+		t.Errorf("Verify() = %+v, want a paid transaction", transaction)
+	}
+
+	if _, err := provider.Verify(ctx, "VH-UNKNOWN"); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Verify(unknown) error = %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func TestFakeProviderParseWebhook(t *testing.T) {
+	provider := NewFakeProvider()
+
+	event, err := provider.ParseWebhook(nil, []byte(`{"event":"charge.success","data":{"status":"success","reference":"VH-ABC-1234-1","amount":500,"currency":"NGN"}}`))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	// Without an event ID the reference identifies the delivery
+	if event.ID != "charge.success:VH-ABC-1234-1" || event.Transaction.Status != StatusSuccess {
+		t.Errorf("ParseWebhook() = %+v", event)
+	}
+
+	if _, err := provider.ParseWebhook(nil, []byte(`not json`)); err == nil {
+		t.Error("ParseWebhook(invalid) succeeded, want an error")
+	}
+}
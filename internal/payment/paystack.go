@@ -0,0 +1,190 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const paystackBaseURL = "https://api.paystack.co"
+
+// PaystackProvider implements Provider using Paystack's transaction API.
+// Webhooks are signed with an HMAC-SHA512 of the body keyed by the secret key.
+type PaystackProvider struct {
+	secretKey string
+	baseURL   string
+	client    *http.Client
+}
+
+// NewPaystackProvider creates a Paystack provider for the given secret key
+func NewPaystackProvider(secretKey string) *PaystackProvider {
+	return &PaystackProvider{
+		secretKey: secretKey,
+		baseURL:   paystackBaseURL,
+		client:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *PaystackProvider) Name() string {
+	return "paystack"
+}
+
+// paystackTransaction is the transaction object in verify responses and
+// webhook payloads
+type paystackTransaction struct {
+	ID        int64      `json:"id"`
+	Status    string     `json:"status"`
+	Reference string     `json:"reference"`
+	Amount    int64      `json:"amount"`
+	Currency  string     `json:"currency"`
+	PaidAt    *time.Time `json:"paid_at"`
+}
+
+func (t paystackTransaction) toTransaction() Transaction {
+	return Transaction{
+		Reference: t.Reference,
+		Status:    paystackStatus(t.Status),
+		Amount:    t.Amount,
+		Currency:  t.Currency,
+		PaidAt:    t.PaidAt,
+	}
+}
+
+// paystackStatus maps Paystack's transaction statuses onto ours
+func paystackStatus(status string) string {
+	switch status {
+	case "success":
+		return StatusSuccess
+	case "failed", "abandoned", "reversed":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+// Initialize creates a Paystack transaction and returns its checkout page
+func (p *PaystackProvider) Initialize(ctx context.Context, req InitializeRequest) (*Checkout, error) {
+	payload := map[string]any{
+		"reference": req.Reference,
+		"email":     req.Email,
+		"amount":    strconv.FormatInt(req.Amount, 10),
+		"currency":  req.Currency,
+	}
+	if req.CallbackURL != "" {
+		payload["callback_url"] = req.CallbackURL
+	}
+	if len(req.Metadata) > 0 {
+		payload["metadata"] = req.Metadata
+	}
+
+	var data struct {
+		AuthorizationURL string `json:"authorization_url"`
+		AccessCode       string `json:"access_code"`
+		Reference        string `json:"reference"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/transaction/initialize", payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to initialize paystack transaction: %w", err)
+	}
+
+	return &Checkout{
+		Reference:        data.Reference,
+		AuthorizationURL: data.AuthorizationURL,
+		AccessCode:       data.AccessCode,
+	}, nil
+}
+
+// Verify fetches a transaction from Paystack by reference
+func (p *PaystackProvider) Verify(ctx context.Context, reference string) (*Transaction, error) {
+	var data paystackTransaction
+	if err := p.do(ctx, http.MethodGet, "/transaction/verify/"+url.PathEscape(reference), nil, &data); err != nil {
+		return nil, fmt.Errorf("failed to verify paystack transaction: %w", err)
+	}
+
+	transaction := data.toTransaction()
+	return &transaction, nil
+}
+
+// ParseWebhook checks the x-paystack-signature header and decodes the event
+func (p *PaystackProvider) ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	signature, err := hex.DecodeString(header.Get("x-paystack-signature"))
+	if err != nil || len(signature) == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha512.New, []byte(p.secretKey))
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload struct {
+		Event string              `json:"event"`
+		Data  paystackTransaction `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode paystack webhook: %w", err)
+	}
+
+	return &WebhookEvent{
+		// Paystack resends the same event for the same transaction, so
+		// the pair identifies a delivery
+		ID:          payload.Event + ":" + strconv.FormatInt(payload.Data.ID, 10),
+		Type:        payload.Event,
+		Transaction: payload.Data.toTransaction(),
+	}, nil
+}
+
+// do sends an authenticated request and decodes the data field of
+// Paystack's {status, message, data} envelope into out
+func (p *PaystackProvider) do(ctx context.Context, method, path string, payload any, out any) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.secretKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTransactionNotFound
+	}
+
+	var envelope struct {
+		Status  bool            `json:"status"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&envelope); err != nil {
+		return fmt.Errorf("unexpected response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 300 || !envelope.Status {
+		return fmt.Errorf("paystack returned HTTP %d: %s", resp.StatusCode, envelope.Message)
+	}
+
+	return json.Unmarshal(envelope.Data, out)
+}
@@ -0,0 +1,182 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testSecretKey = "sk_test_secret"
+
+func paystackSignature(key string, body []byte) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestPaystackParseWebhookSignature(t *testing.T) {
+	body := []byte(`{"event":"charge.success","data":{"id":302961,"status":"success","reference":"VH-ABC-1234","amount":150050,"currency":"NGN","paid_at":"2026-01-05T10:00:00Z"}}`)
+
+	tests := []struct {
+		name      string
+		signature string
+		body      []byte
+		wantErr   error
+	}{
+		{name: "valid", signature: paystackSignature(testSecretKey, body), body: body},
+		{name: "missing", signature: "", body: body, wantErr: ErrInvalidSignature},
+		{name: "not hex", signature: "not-a-signature", body: body, wantErr: ErrInvalidSignature},
+		{name: "other key", signature: paystackSignature("sk_test_other", body), body: body, wantErr: ErrInvalidSignature},
+		{
+			name:      "tampered body",
+			signature: paystackSignature(testSecretKey, body),
+			body:      []byte(`{"event":"charge.success","data":{"id":302961,"status":"success","reference":"VH-ABC-1234","amount":1,"currency":"NGN"}}`),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "truncated signature",
+			signature: paystackSignature(testSecretKey, body)[:64],
+			body:      body,
+			wantErr:   ErrInvalidSignature,
+		},
+	}
+
+	provider := NewPaystackProvider(testSecretKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set("x-paystack-signature", tt.signature)
+			}
+
+			event, err := provider.ParseWebhook(header, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if event.ID != "charge.success:302961" || event.Type != "charge.success" {
+				t.Errorf("event = %q/%q, want charge.success:302961/charge.success", event.ID, event.Type)
+			}
+			transaction := event.Transaction
+			if transaction.Reference != "VH-ABC-1234" || transaction.Status != StatusSuccess ||
+				transaction.Amount != 150050 || transaction.Currency != "NGN" || transaction.PaidAt == nil {
+				t.Errorf("transaction = %+v", transaction)
+			}
+		})
+	}
+}
+
+func TestPaystackParseWebhookInvalidJSON(t *testing.T) {
+	body := []byte(`{"event":`)
+	header := http.Header{}
+	header.Set("x-paystack-signature", paystackSignature(testSecretKey, body))
+
+	_, err := NewPaystackProvider(testSecretKey).ParseWebhook(header, body)
+	if err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("ParseWebhook() error = %v, want a decoding error", err)
+	}
+}
+
+func TestPaystackStatus(t *testing.T) {
+	tests := map[string]string{
+		"success":   StatusSuccess,
+		"failed":    StatusFailed,
+		"abandoned": StatusFailed,
+		"reversed":  StatusFailed,
+		"ongoing":   StatusPending,
+		"pending":   StatusPending,
+		"":          StatusPending,
+	}
+	for status, want := range tests {
+		if got := paystackStatus(status); got != want {
+			t.Errorf("paystackStatus(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestPaystackVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+testSecretKey {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case "/transaction/verify/VH-ABC-1234":
+			json.NewEncoder(w).Encode(map[string]any{
+				"status":  true,
+				"message": "Verification successful",
+				"data": map[string]any{
+					"id": 1, "status": "abandoned", "reference": "VH-ABC-1234", "amount": 500, "currency": "GHS",
+				},
+			})
+		case "/transaction/verify/VH-DECLINED":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"status": false, "message": "Invalid key"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewPaystackProvider(testSecretKey)
+	provider.baseURL = server.URL
+
+	transaction, err := provider.Verify(context.Background(), "VH-ABC-1234")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if transaction.Status != StatusFailed || transaction.Amount != 500 || transaction.Currency != "GHS" {
+		t.Errorf("Verify() = %+v", transaction)
+	}
+
+	if _, err := provider.Verify(context.Background(), "VH-UNKNOWN"); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Verify(unknown) error = %v, want ErrTransactionNotFound", err)
+	}
+	if _, err := provider.Verify(context.Background(), "VH-DECLINED"); err == nil {
+		t.Error("Verify(declined) succeeded, want an error")
+	}
+}
+
+func TestPaystackInitialize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		// Paystack takes amounts in the minor unit, as a string
+		if payload["amount"] != "150050" || payload["currency"] != "NGN" || payload["reference"] != "VH-ABC-1234-1" {
+			t.Errorf("payload = %v", payload)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"status": true,
+			"data": map[string]any{
+				"authorization_url": "https://checkout.paystack.com/abc", "access_code": "abc", "reference": "VH-ABC-1234-1",
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewPaystackProvider(testSecretKey)
+	provider.baseURL = server.URL
+
+	checkout, err := provider.Initialize(context.Background(), InitializeRequest{
+		Reference: "VH-ABC-1234-1",
+		Email:     "ada@example.com",
+		Amount:    150050,
+		Currency:  "NGN",
+	})
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if checkout.AuthorizationURL != "https://checkout.paystack.com/abc" || checkout.Reference != "VH-ABC-1234-1" {
+		t.Errorf("Initialize() = %+v", checkout)
+	}
+}
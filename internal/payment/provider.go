@@ -0,0 +1,68 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Transaction statuses as reported by providers
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+var (
+	ErrInvalidSignature    = errors.New("invalid webhook signature")
+	ErrTransactionNotFound = errors.New("transaction not found")
+)
+
+// InitializeRequest starts a transaction. Amount is in the minor unit of
+// Currency, e.g. kobo for NGN.
+type InitializeRequest struct {
+	Reference   string
+	Email       string
+	Amount      int64
+	Currency    string
+	CallbackURL string
+	Metadata    map[string]string
+}
+
+// Checkout is where the buyer is sent to pay
+type Checkout struct {
+	Reference        string
+	AuthorizationURL string
+	AccessCode       string
+}
+
+// Transaction is the provider's view of a payment
+type Transaction struct {
+	Reference string
+	Status    string
+	Amount    int64
+	Currency  string
+	PaidAt    *time.Time
+}
+
+// WebhookEvent is a notification whose authenticity the provider has
+// checked. ID is unique per event and used to ignore redeliveries.
+type WebhookEvent struct {
+	ID          string
+	Type        string
+	Transaction Transaction
+}
+
+// Provider is a payment gateway that takes buyers through checkout and
+// reports the outcome
+type Provider interface {
+	// Name identifies the provider in stored payments and webhook URLs
+	Name() string
+	// Initialize creates a transaction and returns the checkout to send the buyer to
+	Initialize(ctx context.Context, req InitializeRequest) (*Checkout, error)
+	// Verify fetches the current state of a transaction
+	Verify(ctx context.Context, reference string) (*Transaction, error)
+	// ParseWebhook authenticates a webhook request and decodes its event
+	ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error)
+}
@@ -0,0 +1,155 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock tests move by hand
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestMemoryStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	store.lastSweep = clock.now
+	return store, clock
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	limit := Limit{Rate: 1, Period: time.Second, Burst: 3}
+
+	type step struct {
+		advance       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst then deny",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2},
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{wantAllowed: false, wantRemaining: 0, wantRetry: time.Second},
+			},
+		},
+		{
+			name: "partial refill",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2},
+				{wantAllowed: true, wantRemaining: 1},
+				{wantAllowed: true, wantRemaining: 0},
+				{advance: 400 * time.Millisecond, wantAllowed: false, wantRemaining: 0, wantRetry: 600 * time.Millisecond},
+				{advance: 600 * time.Millisecond, wantAllowed: true, wantRemaining: 0},
+			},
+		},
+		{
+			name: "refill is capped at the burst",
+			steps: []step{
+				{wantAllowed: true, wantRemaining: 2},
+				{advance: time.Hour, wantAllowed: true, wantRemaining: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, clock := newTestMemoryStore()
+			for i, step := range tt.steps {
+				clock.Advance(step.advance)
+				result, err := store.Take(context.Background(), "client", limit)
+				if err != nil {
+					t.Fatalf("step %d: Take() error = %v", i, err)
+				}
+				if result.Allowed != step.wantAllowed || result.Remaining != step.wantRemaining || result.RetryAfter != step.wantRetry {
+					t.Fatalf("step %d: Take() = %+v, want allowed %t, remaining %d, retry after %v",
+						i, result, step.wantAllowed, step.wantRemaining, step.wantRetry)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	store, _ := newTestMemoryStore()
+	limit := PerMinute(1)
+	ctx := context.Background()
+
+	if result, _ := store.Take(ctx, "a", limit); !result.Allowed {
+		t.Fatal("first request for a was denied")
+	}
+	if result, _ := store.Take(ctx, "a", limit); result.Allowed {
+		t.Fatal("second request for a was allowed")
+	}
+	if result, _ := store.Take(ctx, "b", limit); !result.Allowed {
+		t.Fatal("first request for b was denied")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	store, clock := newTestMemoryStore()
+	ctx := context.Background()
+
+	store.Take(ctx, "short", Limit{Rate: 1, Period: time.Second, Burst: 1})
+	store.Take(ctx, "long", PerHour(10))
+
+	clock.Advance(memorySweepInterval)
+	store.Take(ctx, "other", PerMinute(10))
+
+	if _, ok := store.buckets["short"]; ok {
+		t.Error("refilled bucket was not swept")
+	}
+	if _, ok := store.buckets["long"]; !ok {
+		t.Error("bucket still refilling was swept")
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	limit := PerMinute(60)
+
+	tests := []struct {
+		name    string
+		allowed bool
+		tokens  float64
+		want    Result
+	}{
+		{name: "full", allowed: true, tokens: 60, want: Result{Allowed: true, Remaining: 60}},
+		{name: "some left", allowed: true, tokens: 10.5, want: Result{Allowed: true, Remaining: 10, ResetAfter: 49500 * time.Millisecond}},
+		{name: "empty", allowed: false, tokens: 0.25, want: Result{Remaining: 0, ResetAfter: 59750 * time.Millisecond, RetryAfter: 750 * time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newResult(tt.allowed, tt.tokens, limit); got != tt.want {
+				t.Errorf("newResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitWindow(t *testing.T) {
+	tests := []struct {
+		limit Limit
+		want  time.Duration
+	}{
+		{limit: PerMinute(30), want: time.Minute},
+		{limit: PerHour(5), want: time.Hour},
+		{limit: Limit{Rate: 10, Period: time.Minute, Burst: 5}, want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := tt.limit.Window(); got != tt.want {
+			t.Errorf("%+v.Window() = %v, want %v", tt.limit, got, tt.want)
+		}
+	}
+}
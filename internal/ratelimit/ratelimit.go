@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: it allows Burst requests at once, and Rate
+// requests per Period after that
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// PerMinute allows n requests a minute, all of which may come at once
+func PerMinute(n int) Limit {
+	return Limit{Rate: n, Period: time.Minute, Burst: n}
+}
+
+// PerHour allows n requests an hour, all of which may come at once
+func PerHour(n int) Limit {
+	return Limit{Rate: n, Period: time.Hour, Burst: n}
+}
+
+// Window is how long an empty bucket takes to fill up again
+func (l Limit) Window() time.Duration {
+	return l.Period * time.Duration(l.Burst) / time.Duration(l.Rate)
+}
+
+// tokensPerSecond is the rate the bucket refills at
+func (l Limit) tokensPerSecond() float64 {
+	return float64(l.Rate) / l.Period.Seconds()
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// ResetAfter is how long until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is how long until a token is available, when the request
+	// wasn't allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets. MemoryStore suits a single instance; replicas
+// share a RedisStore so a client can't multiply its limit by the number of
+// instances.
+type Store interface {
+	// Take takes a token from key's bucket, creating a full bucket for
+	// keys not seen before
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// newResult describes a bucket left holding tokens after a request
+func newResult(allowed bool, tokens float64, limit Limit) Result {
+	rate := limit.tokensPerSecond()
+	result := Result{
+		Allowed:    allowed,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: seconds((float64(limit.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedisStore connects to TEST_REDIS_URL, skipping the test when
+// it isn't set
+func newTestRedisStore(t *testing.T) *RedisStore {
+	t.Helper()
+
+	url := os.Getenv("TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("TEST_REDIS_URL is not set")
+	}
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		t.Fatalf("TEST_REDIS_URL: %v", err)
+	}
+	client := redis.NewClient(options)
+	t.Cleanup(func() { client.Close() })
+
+	store := NewRedisStore(client)
+	store.prefix = "ratelimit-test:" + strconv.FormatInt(time.Now().UnixNano(), 36) + ":"
+	if err := store.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestRedisStoreTake(t *testing.T) {
+	store := newTestRedisStore(t)
+	ctx := context.Background()
+	limit := PerHour(2)
+
+	wants := []bool{true, true, false}
+	for i, want := range wants {
+		result, err := store.Take(ctx, "client", limit)
+		if err != nil {
+			t.Fatalf("request %d: Take() error = %v", i, err)
+		}
+		if result.Allowed != want {
+			t.Fatalf("request %d: Allowed = %t, want %t", i, result.Allowed, want)
+		}
+		if !result.Allowed && result.RetryAfter <= 0 {
+			t.Errorf("request %d: RetryAfter = %v, want a wait", i, result.RetryAfter)
+		}
+	}
+
+	if result, err := store.Take(ctx, "other", limit); err != nil || !result.Allowed || result.Remaining != 1 {
+		t.Errorf("Take(other) = %+v, %v, want a fresh bucket", result, err)
+	}
+
+	ttl, err := store.client.PTTL(ctx, store.prefix+"client").Result()
+	if err != nil || ttl <= 0 || ttl > limit.Window() {
+		t.Errorf("bucket TTL = %v, %v, want it to expire within %v", ttl, err, limit.Window())
+	}
+}
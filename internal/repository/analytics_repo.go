@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+type AnalyticsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAnalyticsRepository(pool *pgxpool.Pool) *AnalyticsRepository {
+	return &AnalyticsRepository{pool: pool}
+}
+
+// RecordEvent stores an event unless the same visitor already triggered it
+// for the same store, product and day. It reports whether a row was added.
+func (ar *AnalyticsRepository) RecordEvent(ctx context.Context, event *models.AnalyticsEvent) (bool, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	INSERT INTO analytics_events (store_id, product_id, event_type, visitor_hash, day)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT ON CONSTRAINT uq_events_visitor DO NOTHING
+	`
+
+	result, err := ar.pool.Exec(
+		ctx,
+		query,
+		event.StoreID,
+		event.ProductID,
+		event.EventType,
+		event.VisitorHash,
+		event.Day,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record analytics event: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// RollupSince recomputes the daily aggregates for every day from since
+// onwards. Recomputing rather than incrementing keeps reruns idempotent.
+func (ar *AnalyticsRepository) RollupSince(ctx context.Context, since time.Time) (int64, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	INSERT INTO analytics_daily (store_id, product_id, event_type, day, count)
+	SELECT store_id, product_id, event_type, day, COUNT(*)
+	FROM analytics_events
+	WHERE day >= $1
+	GROUP BY store_id, product_id, event_type, day
+	ON CONFLICT (store_id, day, event_type, product_id) DO UPDATE SET count = EXCLUDED.count
+	`
+
+	result, err := ar.pool.Exec(ctx, query, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up analytics: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// PruneEventsBefore deletes raw events for days that have been rolled up
+// and can no longer change
+func (ar *AnalyticsRepository) PruneEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
+
+	result, err := ar.pool.Exec(ctx, `DELETE FROM analytics_events WHERE day < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune analytics events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetDailyStats returns a store's per-day totals for each event type between
+// from and to inclusive
+func (ar *AnalyticsRepository) GetDailyStats(ctx context.Context, storeID string, from, to time.Time) ([]*models.DailyStat, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT day, event_type, SUM(count)
+	FROM analytics_daily
+	WHERE store_id = $1 AND day BETWEEN $2 AND $3
+	GROUP BY day, event_type
+	ORDER BY day ASC
+	`
+
+	rows, err := ar.pool.Query(ctx, query, storeID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily analytics: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*models.DailyStat{}
+
+	for rows.Next() {
+		stat := &models.DailyStat{}
+		if err := rows.Scan(&stat.Day, &stat.EventType, &stat.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan daily analytics: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating daily analytics: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetTopProducts returns the store's most viewed products between from and
+// to inclusive
+func (ar *AnalyticsRepository) GetTopProducts(ctx context.Context, storeID string, from, to time.Time, limit int) ([]*models.ProductStat, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT d.product_id, p.name,
+	       COALESCE(SUM(d.count) FILTER (WHERE d.event_type = $4), 0) AS views,
+	       COALESCE(SUM(d.count) FILTER (WHERE d.event_type = $5), 0) AS whatsapp_clicks
+	FROM analytics_daily d
+	JOIN products p ON p.id = d.product_id
+	WHERE d.store_id = $1 AND d.product_id <> '' AND d.day BETWEEN $2 AND $3
+	GROUP BY d.product_id, p.name
+	ORDER BY views DESC, whatsapp_clicks DESC
+	LIMIT $6
+	`
+
+	rows, err := ar.pool.Query(
+		ctx,
+		query,
+		storeID,
+		from,
+		to,
+		models.EventProductView,
+		models.EventWhatsappClick,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top products: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*models.ProductStat{}
+
+	for rows.Next() {
+		stat := &models.ProductStat{}
+		if err := rows.Scan(&stat.ProductID, &stat.ProductName, &stat.Views, &stat.WhatsappClicks); err != nil {
+			return nil, fmt.Errorf("failed to scan top product: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating top products: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+const apiKeyColumns = `
+	id, user_id, store_id, name, prefix, key_hash, scopes, last_used_at, expires_at, revoked_at,
+	created_at
+	`
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.StoreID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.LastUsedAt,
+		&key.ExpiresAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// CreateAPIKey inserts an API key
+func (ar *APIKeyRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	key.ID = uuid.New().String()
+
+	query := `
+	INSERT INTO api_keys (id, user_id, store_id, name, prefix, key_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING ` + apiKeyColumns
+
+	created, err := scanAPIKey(ar.pool.QueryRow(
+		ctx,
+		query,
+		key.ID,
+		key.UserID,
+		key.StoreID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.ExpiresAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetAPIKey retrieves an API key by ID
+func (ar *APIKeyRepository) GetAPIKey(ctx context.Context, keyID string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+	return ar.getAPIKey(ctx, query, keyID)
+}
+
+// GetByHash retrieves an API key by the hash of the key
+func (ar *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+	return ar.getAPIKey(ctx, query, keyHash)
+}
+
+func (ar *APIKeyRepository) getAPIKey(ctx context.Context, query string, arg string) (*models.APIKey, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	key, err := scanAPIKey(ar.pool.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListByStore returns a store's API keys, revoked ones included, newest first
+func (ar *APIKeyRepository) ListByStore(ctx context.Context, storeID string) ([]*models.APIKey, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE store_id = $1 ORDER BY created_at DESC`
+
+	rows, err := ar.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke marks an API key revoked. Revoking it again keeps the first time.
+func (ar *APIKeyRepository) Revoke(ctx context.Context, keyID string) (*models.APIKey, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+	WHERE id = $1
+	RETURNING ` + apiKeyColumns
+
+	key, err := scanAPIKey(ar.pool.QueryRow(ctx, query, keyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// TouchLastUsed records that an API key was used just now
+func (ar *APIKeyRepository) TouchLastUsed(ctx context.Context, keyID string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	_, err := ar.pool.Exec(ctx, `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+
+	return nil
+}
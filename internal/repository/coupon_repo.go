@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type CouponRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCouponRepository(pool *pgxpool.Pool) *CouponRepository {
+	return &CouponRepository{pool: pool}
+}
+
+const couponColumns = `
+	id, store_id, code, discount_type, percent_off, amount_off, min_spend, max_uses, used_count,
+	starts_at, expires_at, is_active, created_at, updated_at
+	`
+
+func scanCoupon(row pgx.Row) (*models.Coupon, error) {
+	coupon := &models.Coupon{}
+	err := row.Scan(
+		&coupon.ID,
+		&coupon.StoreID,
+		&coupon.Code,
+		&coupon.DiscountType,
+		&coupon.PercentOff,
+		&coupon.AmountOff,
+		&coupon.MinSpend,
+		&coupon.MaxUses,
+		&coupon.UsedCount,
+		&coupon.StartsAt,
+		&coupon.ExpiresAt,
+		&coupon.IsActive,
+		&coupon.CreatedAt,
+		&coupon.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// CreateCoupon inserts a coupon. Codes are unique per store.
+func (cr *CouponRepository) CreateCoupon(ctx context.Context, coupon *models.Coupon) (*models.Coupon, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	coupon.ID = uuid.New().String()
+
+	query := `
+	INSERT INTO coupons (
+		id, store_id, code, discount_type, percent_off, amount_off, min_spend, max_uses, starts_at, expires_at, is_active
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING ` + couponColumns
+
+	created, err := scanCoupon(cr.pool.QueryRow(
+		ctx,
+		query,
+		coupon.ID,
+		coupon.StoreID,
+		coupon.Code,
+		coupon.DiscountType,
+		coupon.PercentOff,
+		coupon.AmountOff,
+		coupon.MinSpend,
+		coupon.MaxUses,
+		coupon.StartsAt,
+		coupon.ExpiresAt,
+		coupon.IsActive,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, utils.ErrCouponCodeTaken
+		}
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID retrieves a coupon by ID
+func (cr *CouponRepository) GetByID(ctx context.Context, couponID string) (*models.Coupon, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + couponColumns + ` FROM coupons WHERE id = $1`
+
+	coupon, err := scanCoupon(cr.pool.QueryRow(ctx, query, couponID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	return coupon, nil
+}
+
+// GetByCode retrieves a store's coupon by its normalised code
+func (cr *CouponRepository) GetByCode(ctx context.Context, storeID, code string) (*models.Coupon, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + couponColumns + ` FROM coupons WHERE store_id = $1 AND code = $2`
+
+	coupon, err := scanCoupon(cr.pool.QueryRow(ctx, query, storeID, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+
+	return coupon, nil
+}
+
+// ListByStore returns a store's coupons, newest first
+func (cr *CouponRepository) ListByStore(ctx context.Context, storeID string) ([]*models.Coupon, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + couponColumns + ` FROM coupons WHERE store_id = $1 ORDER BY created_at DESC`
+
+	rows, err := cr.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coupons: %w", err)
+	}
+	defer rows.Close()
+
+	coupons := []*models.Coupon{}
+
+	for rows.Next() {
+		coupon, err := scanCoupon(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan coupon: %w", err)
+		}
+		coupons = append(coupons, coupon)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating coupons: %w", err)
+	}
+
+	return coupons, nil
+}
+
+// UpdateCoupon saves a coupon's editable fields. The usage count is only
+// changed by OrderRepository.CreateOrder.
+func (cr *CouponRepository) UpdateCoupon(ctx context.Context, coupon *models.Coupon) (*models.Coupon, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE coupons
+	SET code = $2, discount_type = $3, percent_off = $4, amount_off = $5, min_spend = $6,
+	    max_uses = $7, starts_at = $8, expires_at = $9, is_active = $10, updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + couponColumns
+
+	updated, err := scanCoupon(cr.pool.QueryRow(
+		ctx,
+		query,
+		coupon.ID,
+		coupon.Code,
+		coupon.DiscountType,
+		coupon.PercentOff,
+		coupon.AmountOff,
+		coupon.MinSpend,
+		coupon.MaxUses,
+		coupon.StartsAt,
+		coupon.ExpiresAt,
+		coupon.IsActive,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrCouponNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, utils.ErrCouponCodeTaken
+		}
+		return nil, fmt.Errorf("failed to update coupon: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCoupon removes a coupon
+func (cr *CouponRepository) DeleteCoupon(ctx context.Context, couponID string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	result, err := cr.pool.Exec(ctx, `DELETE FROM coupons WHERE id = $1`, couponID)
+	if err != nil {
+		return fmt.Errorf("failed to delete coupon: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return utils.ErrCouponNotFound
+	}
+
+	return nil
+}
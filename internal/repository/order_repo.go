@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type OrderRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrderRepository(pool *pgxpool.Pool) *OrderRepository {
+	return &OrderRepository{pool: pool}
+}
+
+const orderColumns = `
+	id, store_id, reference, customer_name, customer_phone, customer_email, items, currency,
+	subtotal, discount, total, coupon_code, payment_status, payment_reference, paid_at,
+	created_at, updated_at
+	`
+
+func scanOrder(row pgx.Row) (*models.Order, error) {
+	order := &models.Order{}
+	err := row.Scan(
+		&order.ID,
+		&order.StoreID,
+		&order.Reference,
+		&order.CustomerName,
+		&order.CustomerPhone,
+		&order.CustomerEmail,
+		&order.Items,
+		&order.Currency,
+		&order.Subtotal,
+		&order.Discount,
+		&order.Total,
+		&order.CouponCode,
+		&order.PaymentStatus,
+		&order.PaymentReference,
+		&order.PaidAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// CreateOrder inserts an unpaid order and counts one use of its coupon in
+// the same transaction, so a use is only spent on an order that is saved.
+// The coupon's limit is checked in the same statement so concurrent
+// checkouts can't overshoot it.
+func (or *OrderRepository) CreateOrder(ctx context.Context, order *models.Order) (*models.Order, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	tx, err := or.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if order.CouponCode != "" {
+		redeemQuery := `
+		UPDATE coupons
+		SET used_count = used_count + 1, updated_at = NOW()
+		WHERE store_id = $1 AND code = $2 AND (max_uses IS NULL OR used_count < max_uses)
+		`
+		result, err := tx.Exec(ctx, redeemQuery, order.StoreID, order.CouponCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to redeem coupon: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return nil, fmt.Errorf("%w: coupon %s has been used up", utils.ErrInvalidOperation, order.CouponCode)
+		}
+	}
+
+	order.ID = uuid.New().String()
+
+	query := `
+	INSERT INTO orders (
+		id, store_id, reference, customer_name, customer_phone, customer_email, items, currency,
+		subtotal, discount, total, coupon_code, payment_status
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	RETURNING ` + orderColumns
+
+	created, err := scanOrder(tx.QueryRow(
+		ctx,
+		query,
+		order.ID,
+		order.StoreID,
+		order.Reference,
+		order.CustomerName,
+		order.CustomerPhone,
+		order.CustomerEmail,
+		order.Items,
+		order.Currency,
+		order.Subtotal,
+		order.Discount,
+		order.Total,
+		order.CouponCode,
+		models.OrderPaymentUnpaid,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit order: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID retrieves an order by ID
+func (or *OrderRepository) GetByID(ctx context.Context, orderID string) (*models.Order, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	order, err := scanOrder(or.pool.QueryRow(ctx, query, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetByReference retrieves an order by the reference given to the buyer
+func (or *OrderRepository) GetByReference(ctx context.Context, reference string) (*models.Order, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE reference = $1`
+
+	order, err := scanOrder(or.pool.QueryRow(ctx, query, reference))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return order, nil
+}
+
+// ListByStore returns a page of a store's orders, newest first. An empty
+// paymentStatus matches every order.
+func (or *OrderRepository) ListByStore(ctx context.Context, storeID, paymentStatus string, limit, offset int) ([]*models.Order, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT ` + orderColumns + `
+	FROM orders
+	WHERE store_id = $1 AND ($2 = '' OR payment_status = $2)
+	ORDER BY created_at DESC
+	LIMIT $3 OFFSET $4
+	`
+
+	rows, err := or.pool.Query(ctx, query, storeID, paymentStatus, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.Order{}
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type PaymentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPaymentRepository(pool *pgxpool.Pool) *PaymentRepository {
+	return &PaymentRepository{pool: pool}
+}
+
+const paymentColumns = `
+	id, order_id, provider, reference, amount, currency, status, authorization_url, paid_at,
+	created_at, updated_at
+	`
+
+func scanPayment(row pgx.Row) (*models.Payment, error) {
+	payment := &models.Payment{}
+	err := row.Scan(
+		&payment.ID,
+		&payment.OrderID,
+		&payment.Provider,
+		&payment.Reference,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.Status,
+		&payment.AuthorizationURL,
+		&payment.PaidAt,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// CreatePayment inserts a pending payment and makes it the order's current
+// payment attempt. Paid orders keep the reference of the payment that paid.
+func (pr *PaymentRepository) CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	payment.ID = uuid.New().String()
+
+	tx, err := pr.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	INSERT INTO payments (id, order_id, provider, reference, amount, currency, status, authorization_url)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING ` + paymentColumns
+
+	created, err := scanPayment(tx.QueryRow(
+		ctx,
+		query,
+		payment.ID,
+		payment.OrderID,
+		payment.Provider,
+		payment.Reference,
+		payment.Amount,
+		payment.Currency,
+		models.PaymentStatusPending,
+		payment.AuthorizationURL,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+	UPDATE orders
+	SET payment_status = $2, payment_reference = $3, updated_at = NOW()
+	WHERE id = $1 AND payment_status <> $4
+	`, created.OrderID, models.OrderPaymentPending, created.Reference, models.OrderPaymentPaid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order payment: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit payment: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByReference retrieves a payment by its provider reference
+func (pr *PaymentRepository) GetByReference(ctx context.Context, reference string) (*models.Payment, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE reference = $1`
+
+	payment, err := scanPayment(pr.pool.QueryRow(ctx, query, reference))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// ListByOrder returns an order's payment attempts, newest first
+func (pr *PaymentRepository) ListByOrder(ctx context.Context, orderID string) ([]*models.Payment, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 ORDER BY created_at DESC`
+
+	rows, err := pr.pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []*models.Payment{}
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// RecordResult stores the final status of a payment and updates its order
+// in one transaction, reporting whether the payment changed. A non-empty
+// eventID from a provider webhook is recorded alongside; if it was recorded
+// before nothing changes. A successful payment is never overwritten.
+func (pr *PaymentRepository) RecordResult(ctx context.Context, provider, eventID, reference, status string, paidAt *time.Time) (bool, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	tx, err := pr.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if eventID != "" {
+		result, err := tx.Exec(ctx, `
+		INSERT INTO payment_webhook_events (provider, event_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+		`, provider, eventID)
+		if err != nil {
+			return false, fmt.Errorf("failed to record webhook event: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return false, nil
+		}
+	}
+
+	var orderID, current string
+	err = tx.QueryRow(ctx, `SELECT order_id, status FROM payments WHERE reference = $1 FOR UPDATE`, reference).
+		Scan(&orderID, &current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, utils.ErrPaymentNotFound
+		}
+		return false, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	changed := current != models.PaymentStatusSuccess
+	if changed {
+		_, err = tx.Exec(ctx, `
+		UPDATE payments SET status = $2, paid_at = $3, updated_at = NOW() WHERE reference = $1
+		`, reference, status, paidAt)
+		if err != nil {
+			return false, fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		if status == models.PaymentStatusSuccess {
+			_, err = tx.Exec(ctx, `
+			UPDATE orders
+			SET payment_status = $2, payment_reference = $3, paid_at = $4, updated_at = NOW()
+			WHERE id = $1
+			`, orderID, models.OrderPaymentPaid, reference, paidAt)
+		} else {
+			// Only the order's current attempt decides that it failed
+			_, err = tx.Exec(ctx, `
+			UPDATE orders
+			SET payment_status = $2, updated_at = NOW()
+			WHERE id = $1 AND payment_reference = $3 AND payment_status <> $4
+			`, orderID, models.OrderPaymentFailed, reference, models.OrderPaymentPaid)
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to update order payment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit payment result: %w", err)
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type ProductImportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewProductImportRepository(pool *pgxpool.Pool) *ProductImportRepository {
+	return &ProductImportRepository{pool: pool}
+}
+
+const productImportJobColumns = `
+	id, store_id, user_id, status, total_rows, valid_rows, processed_rows, created_rows,
+	pending_rows, errors, created_at, updated_at, completed_at
+	`
+
+func scanProductImportJob(row pgx.Row) (*models.ProductImportJob, error) {
+	job := &models.ProductImportJob{}
+	err := row.Scan(
+		&job.ID,
+		&job.StoreID,
+		&job.UserID,
+		&job.Status,
+		&job.TotalRows,
+		&job.ValidRows,
+		&job.ProcessedRows,
+		&job.CreatedRows,
+		&job.Rows,
+		&job.Errors,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// CreateJob inserts an import job with its validated rows and row errors
+func (pr *ProductImportRepository) CreateJob(ctx context.Context, job *models.ProductImportJob) (*models.ProductImportJob, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	job.ID = uuid.New().String()
+
+	query := `
+	INSERT INTO product_import_jobs (
+		id, store_id, user_id, status, total_rows, valid_rows, pending_rows, errors, completed_at
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING ` + productImportJobColumns
+
+	created, err := scanProductImportJob(pr.pool.QueryRow(
+		ctx,
+		query,
+		job.ID,
+		job.StoreID,
+		job.UserID,
+		job.Status,
+		job.TotalRows,
+		job.ValidRows,
+		job.Rows,
+		job.Errors,
+		job.CompletedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetJob retrieves an import job by ID
+func (pr *ProductImportRepository) GetJob(ctx context.Context, jobID string) (*models.ProductImportJob, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + productImportJobColumns + ` FROM product_import_jobs WHERE id = $1`
+
+	job, err := scanProductImportJob(pr.pool.QueryRow(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ClaimNext marks the oldest pending job, or a running job whose lease has
+// passed, as running for lease and returns it. It returns nil when no job
+// is waiting.
+func (pr *ProductImportRepository) ClaimNext(ctx context.Context, lease time.Duration) (*models.ProductImportJob, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE product_import_jobs
+	SET status = $2, lease_until = NOW() + make_interval(secs => $1), updated_at = NOW()
+	WHERE id = (
+		SELECT id FROM product_import_jobs
+		WHERE status = $3 OR (status = $2 AND lease_until < NOW())
+		ORDER BY created_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + productImportJobColumns
+
+	job, err := scanProductImportJob(pr.pool.QueryRow(
+		ctx,
+		query,
+		lease.Seconds(),
+		models.ProductImportRunning,
+		models.ProductImportPending,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// SaveProgress stores how far a running job has got and extends its lease.
+// Once the job is completed its pending rows are dropped.
+func (pr *ProductImportRepository) SaveProgress(ctx context.Context, job *models.ProductImportJob, lease time.Duration) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE product_import_jobs
+	SET status = $2, processed_rows = $3, created_rows = $4, errors = $5, completed_at = $6,
+	    pending_rows = CASE WHEN $2 = $8 THEN '[]'::jsonb ELSE pending_rows END,
+	    lease_until = NOW() + make_interval(secs => $7), updated_at = NOW()
+	WHERE id = $1
+	`
+
+	_, err := pr.pool.Exec(
+		ctx,
+		query,
+		job.ID,
+		job.Status,
+		job.ProcessedRows,
+		job.CreatedRows,
+		job.Errors,
+		job.CompletedAt,
+		lease.Seconds(),
+		models.ProductImportCompleted,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save import job progress: %w", err)
+	}
+
+	return nil
+}
+
+// PruneJobsBefore deletes completed jobs created before cutoff
+func (pr *ProductImportRepository) PruneJobsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	result, err := pr.pool.Exec(
+		ctx,
+		`DELETE FROM product_import_jobs WHERE status = $1 AND created_at < $2`,
+		models.ProductImportCompleted,
+		cutoff,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune import jobs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
 )
 
 type ProductRepository struct {
@@ -30,34 +31,52 @@ func (pr *ProductRepository) CreateProduct(ctx context.Context, product *models.
 
 	product.ID = uuid.New().String()
 
+	// user_id always mirrors the owner of the store the product belongs to
 	query := `
 	INSERT INTO products (
-		id, user_id, name, description, price, is_active
-	) 
-	VALUES ($1, $2, $3, $4, $5, $6)
-	RETURNING id, user_id, name, description, price, is_active, created_at, updated_at
+		id, user_id, store_id, name, description, price, currency, is_active, sale_price, sale_starts_at, sale_ends_at, category, slug
+	)
+	SELECT $1, owner_id, id, $3, $4, $5, currency, $6, $7, $8, $9, $10, $11 FROM stores WHERE id = $2
+	RETURNING id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	`
 
 	err := pr.pool.QueryRow(
 		ctx,
 		query,
 		product.ID,
-		product.UserID,
+		product.StoreID,
 		product.Name,
 		product.Description,
 		product.Price,
 		product.IsActive,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
+		product.Category,
+		product.Slug,
 	).Scan(
 		&product.ID,
 		&product.UserID,
+		&product.StoreID,
 		&product.Name,
 		&product.Description,
 		&product.Price,
+		&product.Currency,
 		&product.IsActive,
+		&product.RatingAverage,
+		&product.RatingCount,
+		&product.SalePrice,
+		&product.SaleStartsAt,
+		&product.SaleEndsAt,
+		&product.Category,
+		&product.Slug,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, utils.ErrSlugTaken
+		}
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
@@ -72,7 +91,7 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, productID strin
 	}
 
 	query := `
-	SELECT id, user_id, name, description, price, is_active, created_at, updated_at
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	FROM products
 	WHERE id = $1
 	`
@@ -82,16 +101,25 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, productID strin
 	err := pr.pool.QueryRow(ctx, query, productID).Scan(
 		&product.ID,
 		&product.UserID,
+		&product.StoreID,
 		&product.Name,
 		&product.Description,
 		&product.Price,
+		&product.Currency,
 		&product.IsActive,
+		&product.RatingAverage,
+		&product.RatingCount,
+		&product.SalePrice,
+		&product.SaleStartsAt,
+		&product.SaleEndsAt,
+		&product.Category,
+		&product.Slug,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("product not found")
+			return nil, utils.ErrProductNotFound
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -108,9 +136,11 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *models.
 
 	query := `
 	UPDATE products
-	SET name = $2, description = $3, price = $4, is_active = $5, updated_at = NOW()
+	SET name = $2, description = $3, price = $4, is_active = $5,
+	    sale_price = $6, sale_starts_at = $7, sale_ends_at = $8, category = $9, slug = $10,
+	    updated_at = NOW()
 	WHERE id = $1
-	RETURNING id, user_id, name, description, price, is_active, created_at, updated_at
+	RETURNING id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	`
 
 	err := pr.pool.QueryRow(
@@ -121,19 +151,36 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *models.
 		product.Description,
 		product.Price,
 		product.IsActive,
+		product.SalePrice,
+		product.SaleStartsAt,
+		product.SaleEndsAt,
+		product.Category,
+		product.Slug,
 	).Scan(
 		&product.ID,
 		&product.UserID,
+		&product.StoreID,
 		&product.Name,
 		&product.Description,
 		&product.Price,
+		&product.Currency,
 		&product.IsActive,
+		&product.RatingAverage,
+		&product.RatingCount,
+		&product.SalePrice,
+		&product.SaleStartsAt,
+		&product.SaleEndsAt,
+		&product.Category,
+		&product.Slug,
 		&product.CreatedAt,
 		&product.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("product not found")
+			return nil, utils.ErrProductNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, utils.ErrSlugTaken
 		}
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
@@ -156,7 +203,7 @@ func (pr *ProductRepository) DeleteProduct(ctx context.Context, productID string
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("product not found")
+		return utils.ErrProductNotFound
 	}
 
 	return nil
@@ -170,7 +217,7 @@ func (pr *ProductRepository) GetProductsByUserID(ctx context.Context, userID str
 	}
 
 	query := `
-	SELECT id, user_id, name, description, price, is_active, created_at, updated_at
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	FROM products
 	WHERE user_id = $1
 	ORDER BY created_at DESC
@@ -189,10 +236,19 @@ func (pr *ProductRepository) GetProductsByUserID(ctx context.Context, userID str
 		err := rows.Scan(
 			&product.ID,
 			&product.UserID,
+			&product.StoreID,
 			&product.Name,
 			&product.Description,
 			&product.Price,
+			&product.Currency,
 			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 		)
@@ -217,7 +273,7 @@ func (pr *ProductRepository) GetActiveProductsByUserID(ctx context.Context, user
 	}
 
 	query := `
-	SELECT id, user_id, name, description, price, is_active, created_at, updated_at
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	FROM products
 	WHERE user_id = $1 AND is_active = true
 	ORDER BY created_at DESC
@@ -236,10 +292,256 @@ func (pr *ProductRepository) GetActiveProductsByUserID(ctx context.Context, user
 		err := rows.Scan(
 			&product.ID,
 			&product.UserID,
+			&product.StoreID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Currency,
+			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating products: %w", err)
+	}
+
+	return products, nil
+}
+
+func (pr *ProductRepository) GetProductsByStoreID(ctx context.Context, storeID string) ([]*models.Product, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
+	FROM products
+	WHERE store_id = $1
+	ORDER BY created_at DESC
+	`
+
+	rows, err := pr.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store products: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*models.Product
+
+	for rows.Next() {
+		product := &models.Product{}
+		err := rows.Scan(
+			&product.ID,
+			&product.UserID,
+			&product.StoreID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Currency,
+			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating products: %w", err)
+	}
+
+	return products, nil
+}
+
+func (pr *ProductRepository) GetActiveProductsByStoreID(ctx context.Context, storeID string) ([]*models.Product, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
+	FROM products
+	WHERE store_id = $1 AND is_active = true
+	ORDER BY created_at DESC
+	`
+
+	rows, err := pr.pool.Query(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active products for store: %w", err)
+	}
+	defer rows.Close()
+
+	var products []*models.Product
+
+	for rows.Next() {
+		product := &models.Product{}
+		err := rows.Scan(
+			&product.ID,
+			&product.UserID,
+			&product.StoreID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Currency,
+			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating products: %w", err)
+	}
+
+	return products, nil
+}
+
+// GetProductBySlug retrieves a store's product by its slug
+func (pr *ProductRepository) GetProductBySlug(ctx context.Context, storeID, slug string) (*models.Product, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
+	FROM products
+	WHERE store_id = $1 AND slug = $2
+	`
+
+	product := &models.Product{}
+
+	err := pr.pool.QueryRow(ctx, query, storeID, slug).Scan(
+		&product.ID,
+		&product.UserID,
+		&product.StoreID,
+		&product.Name,
+		&product.Description,
+		&product.Price,
+		&product.Currency,
+		&product.IsActive,
+		&product.RatingAverage,
+		&product.RatingCount,
+		&product.SalePrice,
+		&product.SaleStartsAt,
+		&product.SaleEndsAt,
+		&product.Category,
+		&product.Slug,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// ProductSlugInUse reports whether a product of storeID other than
+// exceptProductID already uses slug
+func (pr *ProductRepository) ProductSlugInUse(ctx context.Context, storeID, slug, exceptProductID string) (bool, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM products WHERE store_id = $1 AND slug = $2 AND id <> $3)`
+
+	var inUse bool
+	if err := pr.pool.QueryRow(ctx, query, storeID, slug, exceptProductID).Scan(&inUse); err != nil {
+		return false, fmt.Errorf("failed to check product slug: %w", err)
+	}
+
+	return inUse, nil
+}
+
+// GetActiveProductsOfApprovedStores retrieves up to limit active products of
+// stores whose owner is an active vendor, most recently updated first
+func (pr *ProductRepository) GetActiveProductsOfApprovedStores(ctx context.Context, limit int) ([]*models.Product, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT p.id, p.user_id, p.store_id, p.name, p.description, p.price, p.currency, p.is_active, p.rating_average, p.rating_count, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.category, p.slug, p.created_at, p.updated_at
+	FROM products p
+	JOIN stores s ON s.id = p.store_id
+	JOIN users u ON u.id = s.owner_id
+	WHERE p.is_active = true AND u.role = 'vendor' AND u.is_active = true
+	ORDER BY p.updated_at DESC
+	LIMIT $1
+	`
+
+	rows, err := pr.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active products of approved stores: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*models.Product{}
+
+	for rows.Next() {
+		product := &models.Product{}
+		err := rows.Scan(
+			&product.ID,
+			&product.UserID,
+			&product.StoreID,
 			&product.Name,
 			&product.Description,
 			&product.Price,
+			&product.Currency,
 			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 		)
@@ -264,7 +566,7 @@ func (pr *ProductRepository) GetActiveProducts(ctx context.Context) ([]*models.P
 	}
 
 	query := `
-	SELECT id, user_id, name, description, price, is_active, created_at, updated_at
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	FROM products
 	WHERE is_active = true
 	ORDER BY created_at DESC
@@ -283,10 +585,19 @@ func (pr *ProductRepository) GetActiveProducts(ctx context.Context) ([]*models.P
 		err := rows.Scan(
 			&product.ID,
 			&product.UserID,
+			&product.StoreID,
 			&product.Name,
 			&product.Description,
 			&product.Price,
+			&product.Currency,
 			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 		)
@@ -303,7 +614,9 @@ func (pr *ProductRepository) GetActiveProducts(ctx context.Context) ([]*models.P
 	return products, nil
 }
 
-func (pr *ProductRepository) GetProductsByPriceRange(ctx context.Context, minPrice, maxPrice float64) ([]*models.Product, error) {
+// GetProductsByPriceRange returns active products priced in currency between
+// minPrice and maxPrice inclusive
+func (pr *ProductRepository) GetProductsByPriceRange(ctx context.Context, currency string, minPrice, maxPrice models.Money) ([]*models.Product, error) {
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
@@ -311,13 +624,13 @@ func (pr *ProductRepository) GetProductsByPriceRange(ctx context.Context, minPri
 	}
 
 	query := `
-	SELECT id, user_id, name, description, price, is_active, created_at, updated_at
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	FROM products
-	WHERE is_active = true AND price BETWEEN $1 AND $2
+	WHERE is_active = true AND currency = $1 AND price BETWEEN $2 AND $3
 	ORDER BY price ASC
 	`
 
-	rows, err := pr.pool.Query(ctx, query, minPrice, maxPrice)
+	rows, err := pr.pool.Query(ctx, query, currency, minPrice, maxPrice)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products by price range: %w", err)
 	}
@@ -330,10 +643,19 @@ func (pr *ProductRepository) GetProductsByPriceRange(ctx context.Context, minPri
 		err := rows.Scan(
 			&product.ID,
 			&product.UserID,
+			&product.StoreID,
 			&product.Name,
 			&product.Description,
 			&product.Price,
+			&product.Currency,
 			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 		)
@@ -358,7 +680,7 @@ func (pr *ProductRepository) SearchProducts(ctx context.Context, searchTerm stri
 	}
 
 	query := `
-	SELECT id, user_id, name, description, price, is_active, created_at, updated_at
+	SELECT id, user_id, store_id, name, description, price, currency, is_active, rating_average, rating_count, sale_price, sale_starts_at, sale_ends_at, category, slug, created_at, updated_at
 	FROM products
 	WHERE is_active = true AND (name ILIKE $1 OR description ILIKE $1)
 	ORDER BY created_at DESC
@@ -378,10 +700,19 @@ func (pr *ProductRepository) SearchProducts(ctx context.Context, searchTerm stri
 		err := rows.Scan(
 			&product.ID,
 			&product.UserID,
+			&product.StoreID,
 			&product.Name,
 			&product.Description,
 			&product.Price,
+			&product.Currency,
 			&product.IsActive,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&product.Category,
+			&product.Slug,
 			&product.CreatedAt,
 			&product.UpdatedAt,
 		)
@@ -496,7 +827,7 @@ func (pr *ProductRepository) DeleteProductImage(ctx context.Context, imageID str
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("product image not found")
+		return utils.ErrProductImageNotFound
 	}
 
 	return nil
@@ -518,7 +849,7 @@ func (pr *ProductRepository) UpdateProductImagePosition(ctx context.Context, ima
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("product image not found")
+		return utils.ErrProductImageNotFound
 	}
 
 	return nil
@@ -549,7 +880,7 @@ func (pr *ProductRepository) GetProductImage(ctx context.Context, imageID string
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("product image not found")
+			return nil, utils.ErrProductImageNotFound
 		}
 		return nil, fmt.Errorf("failed to get product image: %w", err)
 	}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type ReviewRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReviewRepository(pool *pgxpool.Pool) *ReviewRepository {
+	return &ReviewRepository{pool: pool}
+}
+
+const reviewColumns = `
+	id, product_id, store_id, reviewer_name, rating, comment, status, moderation_note,
+	vendor_reply, vendor_replied_at, created_at, updated_at
+	`
+
+func scanReview(row pgx.Row) (*models.Review, error) {
+	review := &models.Review{}
+	err := row.Scan(
+		&review.ID,
+		&review.ProductID,
+		&review.StoreID,
+		&review.ReviewerName,
+		&review.Rating,
+		&review.Comment,
+		&review.Status,
+		&review.ModerationNote,
+		&review.VendorReply,
+		&review.VendorRepliedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// refreshRatings recomputes the denormalised rating average and count of a
+// product and its store from their published reviews.
+func refreshRatings(ctx context.Context, tx pgx.Tx, productID, storeID string) error {
+	productQuery := `
+	UPDATE products SET
+		rating_average = COALESCE((SELECT ROUND(AVG(rating), 2) FROM product_reviews WHERE product_id = $1 AND status = $2), 0),
+		rating_count = (SELECT COUNT(*) FROM product_reviews WHERE product_id = $1 AND status = $2)
+	WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, productQuery, productID, models.ReviewStatusPublished); err != nil {
+		return fmt.Errorf("failed to refresh product rating: %w", err)
+	}
+
+	storeQuery := `
+	UPDATE stores SET
+		rating_average = COALESCE((SELECT ROUND(AVG(rating), 2) FROM product_reviews WHERE store_id = $1 AND status = $2), 0),
+		rating_count = (SELECT COUNT(*) FROM product_reviews WHERE store_id = $1 AND status = $2)
+	WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, storeQuery, storeID, models.ReviewStatusPublished); err != nil {
+		return fmt.Errorf("failed to refresh store rating: %w", err)
+	}
+
+	return nil
+}
+
+// CreateReview inserts a review and refreshes the product and store ratings
+func (rr *ReviewRepository) CreateReview(ctx context.Context, review *models.Review) (*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	review.ID = uuid.New().String()
+
+	tx, err := rr.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	INSERT INTO product_reviews (id, product_id, store_id, reviewer_name, rating, comment, status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING ` + reviewColumns
+
+	created, err := scanReview(tx.QueryRow(
+		ctx,
+		query,
+		review.ID,
+		review.ProductID,
+		review.StoreID,
+		review.ReviewerName,
+		review.Rating,
+		review.Comment,
+		review.Status,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	if err := refreshRatings(ctx, tx, created.ProductID, created.StoreID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit review: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID retrieves a review by ID
+func (rr *ReviewRepository) GetByID(ctx context.Context, reviewID string) (*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + reviewColumns + ` FROM product_reviews WHERE id = $1`
+
+	review, err := scanReview(rr.pool.QueryRow(ctx, query, reviewID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return review, nil
+}
+
+// ListPublishedByProduct returns a product's visible reviews, newest first
+func (rr *ReviewRepository) ListPublishedByProduct(ctx context.Context, productID string, limit, offset int) ([]*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + reviewColumns + `
+	FROM product_reviews
+	WHERE product_id = $1 AND status = $2
+	ORDER BY created_at DESC
+	LIMIT $3 OFFSET $4
+	`
+
+	return rr.queryReviews(ctx, query, productID, models.ReviewStatusPublished, limit, offset)
+}
+
+// ListByStore returns every review of a store's products, newest first
+func (rr *ReviewRepository) ListByStore(ctx context.Context, storeID string, limit, offset int) ([]*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + reviewColumns + `
+	FROM product_reviews
+	WHERE store_id = $1
+	ORDER BY created_at DESC
+	LIMIT $2 OFFSET $3
+	`
+
+	return rr.queryReviews(ctx, query, storeID, limit, offset)
+}
+
+// ListByStatus returns reviews across all stores with the given status, or
+// every review when status is empty, newest first
+func (rr *ReviewRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + reviewColumns + `
+	FROM product_reviews
+	WHERE $1 = '' OR status = $1
+	ORDER BY created_at DESC
+	LIMIT $2 OFFSET $3
+	`
+
+	return rr.queryReviews(ctx, query, status, limit, offset)
+}
+
+func (rr *ReviewRepository) queryReviews(ctx context.Context, query string, args ...any) ([]*models.Review, error) {
+	rows, err := rr.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.Review{}
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// UpdateReply sets the vendor's reply to a review. An empty reply removes it.
+func (rr *ReviewRepository) UpdateReply(ctx context.Context, reviewID, reply string) (*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE product_reviews
+	SET vendor_reply = $2,
+	    vendor_replied_at = CASE WHEN $2 = '' THEN NULL ELSE NOW() END,
+	    updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + reviewColumns
+
+	review, err := scanReview(rr.pool.QueryRow(ctx, query, reviewID, reply))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to update review reply: %w", err)
+	}
+
+	return review, nil
+}
+
+// UpdateStatus publishes or hides a review and refreshes the product and
+// store ratings
+func (rr *ReviewRepository) UpdateStatus(ctx context.Context, reviewID, status, note string) (*models.Review, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	tx, err := rr.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	UPDATE product_reviews
+	SET status = $2, moderation_note = $3, updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + reviewColumns
+
+	review, err := scanReview(tx.QueryRow(ctx, query, reviewID, status, note))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to update review status: %w", err)
+	}
+
+	if err := refreshRatings(ctx, tx, review.ProductID, review.StoreID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit review status: %w", err)
+	}
+
+	return review, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+type StatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStatsRepository(pool *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{pool: pool}
+}
+
+// GetPlatformStats counts vendors, stores, active products and the queued
+// background work in one round trip
+func (sr *StatsRepository) GetPlatformStats(ctx context.Context) (*models.PlatformStats, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT
+		(SELECT COUNT(*) FROM users WHERE role = 'vendor' AND is_active = false),
+		(SELECT COUNT(*) FROM users WHERE role = 'vendor' AND is_active = true),
+		(SELECT COUNT(*) FROM stores),
+		(SELECT COUNT(*) FROM products WHERE is_active = true),
+		(SELECT COUNT(*) FROM webhook_deliveries WHERE status = 'pending'),
+		(SELECT COUNT(*) FROM product_import_jobs WHERE status <> 'completed')
+	`
+
+	var stats models.PlatformStats
+	if err := sr.pool.QueryRow(ctx, query).Scan(
+		&stats.PendingVendors,
+		&stats.ApprovedVendors,
+		&stats.Stores,
+		&stats.ActiveProducts,
+		&stats.PendingWebhookDeliveries,
+		&stats.OpenProductImports,
+	); err != nil {
+		return nil, fmt.Errorf("failed to count platform stats: %w", err)
+	}
+
+	return &stats, nil
+}
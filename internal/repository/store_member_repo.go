@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type StoreMemberRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStoreMemberRepository(pool *pgxpool.Pool) *StoreMemberRepository {
+	return &StoreMemberRepository{pool: pool}
+}
+
+const storeMemberColumns = `id, store_id, user_id, email, role, status, invite_token_hash, invited_by, created_at, accepted_at`
+
+func scanStoreMember(row pgx.Row) (*models.StoreMember, error) {
+	member := &models.StoreMember{}
+	var userID, tokenHash *string
+
+	err := row.Scan(
+		&member.ID,
+		&member.StoreID,
+		&userID,
+		&member.Email,
+		&member.Role,
+		&member.Status,
+		&tokenHash,
+		&member.InvitedBy,
+		&member.CreatedAt,
+		&member.AcceptedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != nil {
+		member.UserID = *userID
+	}
+	if tokenHash != nil {
+		member.InviteTokenHash = *tokenHash
+	}
+
+	return member, nil
+}
+
+// CreateInvite inserts a pending membership for the given email
+func (mr *StoreMemberRepository) CreateInvite(ctx context.Context, member *models.StoreMember) (*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	member.ID = uuid.New().String()
+	member.Status = models.MemberStatusInvited
+
+	query := `
+	INSERT INTO store_members (id, store_id, email, role, status, invite_token_hash, invited_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING ` + storeMemberColumns
+
+	created, err := scanStoreMember(mr.pool.QueryRow(
+		ctx,
+		query,
+		member.ID,
+		member.StoreID,
+		member.Email,
+		member.Role,
+		member.Status,
+		member.InviteTokenHash,
+		member.InvitedBy,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, utils.ErrAlreadyInvited
+		}
+		return nil, fmt.Errorf("failed to create store invite: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID retrieves a single membership by ID
+func (mr *StoreMemberRepository) GetByID(ctx context.Context, memberID string) (*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + storeMemberColumns + ` FROM store_members WHERE id = $1`
+
+	member, err := scanStoreMember(mr.pool.QueryRow(ctx, query, memberID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get store member: %w", err)
+	}
+
+	return member, nil
+}
+
+// GetByInviteTokenHash retrieves a pending membership by its hashed invite token
+func (mr *StoreMemberRepository) GetByInviteTokenHash(ctx context.Context, tokenHash string) (*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + storeMemberColumns + ` FROM store_members WHERE invite_token_hash = $1 AND status = $2`
+
+	member, err := scanStoreMember(mr.pool.QueryRow(ctx, query, tokenHash, models.MemberStatusInvited))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrInviteNotFound
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	return member, nil
+}
+
+// GetActiveMembership returns the accepted membership of userID in storeID
+func (mr *StoreMemberRepository) GetActiveMembership(ctx context.Context, storeID, userID string) (*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + storeMemberColumns + ` FROM store_members WHERE store_id = $1 AND user_id = $2 AND status = $3`
+
+	member, err := scanStoreMember(mr.pool.QueryRow(ctx, query, storeID, userID, models.MemberStatusActive))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get store membership: %w", err)
+	}
+
+	return member, nil
+}
+
+// ListByStore returns every member and pending invite of a store
+func (mr *StoreMemberRepository) ListByStore(ctx context.Context, storeID string) ([]*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + storeMemberColumns + ` FROM store_members WHERE store_id = $1 ORDER BY created_at ASC`
+
+	return mr.queryMembers(ctx, query, storeID)
+}
+
+// ListByUser returns the active memberships of a staff user
+func (mr *StoreMemberRepository) ListByUser(ctx context.Context, userID string) ([]*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + storeMemberColumns + ` FROM store_members WHERE user_id = $1 AND status = $2 ORDER BY created_at ASC`
+
+	return mr.queryMembers(ctx, query, userID, models.MemberStatusActive)
+}
+
+func (mr *StoreMemberRepository) queryMembers(ctx context.Context, query string, args ...any) ([]*models.StoreMember, error) {
+	rows, err := mr.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*models.StoreMember{}
+
+	for rows.Next() {
+		member, err := scanStoreMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan store member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating store members: %w", err)
+	}
+
+	return members, nil
+}
+
+// AcceptInvite binds a pending membership to userID and clears the invite token
+func (mr *StoreMemberRepository) AcceptInvite(ctx context.Context, memberID, userID string) (*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE store_members
+	SET user_id = $2, status = $3, invite_token_hash = NULL, accepted_at = NOW()
+	WHERE id = $1 AND status = $4
+	RETURNING ` + storeMemberColumns
+
+	member, err := scanStoreMember(mr.pool.QueryRow(
+		ctx,
+		query,
+		memberID,
+		userID,
+		models.MemberStatusActive,
+		models.MemberStatusInvited,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrInviteNotFound
+		}
+		return nil, fmt.Errorf("failed to accept invite: %w", err)
+	}
+
+	return member, nil
+}
+
+// UpdateRole changes the role of a membership
+func (mr *StoreMemberRepository) UpdateRole(ctx context.Context, memberID, role string) (*models.StoreMember, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `UPDATE store_members SET role = $2 WHERE id = $1 RETURNING ` + storeMemberColumns
+
+	member, err := scanStoreMember(mr.pool.QueryRow(ctx, query, memberID, role))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to update store member: %w", err)
+	}
+
+	return member, nil
+}
+
+// Delete removes a membership or revokes a pending invite
+func (mr *StoreMemberRepository) Delete(ctx context.Context, memberID string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `DELETE FROM store_members WHERE id = $1`
+
+	result, err := mr.pool.Exec(ctx, query, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to delete store member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return utils.ErrStoreMemberNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,452 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type StoreRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStoreRepository(pool *pgxpool.Pool) *StoreRepository {
+	return &StoreRepository{pool: pool}
+}
+
+const storeSelect = `
+	SELECT s.id, s.owner_id, s.name, s.slug, s.bio, s.whatsapp_number, s.currency,
+	       s.logo_url, s.banner_url, s.primary_color, s.accent_color,
+	       s.instagram_url, s.tiktok_url, s.facebook_url,
+	       s.location, s.opening_hours, s.delivery_notes,
+	       s.timezone, s.business_hours, s.vacation_mode, s.vacation_starts_at, s.vacation_ends_at,
+	       s.vacation_message, s.block_orders_when_closed,
+	       s.rating_average, s.rating_count,
+	       s.created_at, s.updated_at,
+	       u.username, u.email
+	FROM stores s
+	JOIN users u ON u.id = s.owner_id
+	`
+
+func scanStore(row pgx.Row) (*models.Store, error) {
+	store := &models.Store{}
+	err := row.Scan(
+		&store.ID,
+		&store.OwnerID,
+		&store.Name,
+		&store.Slug,
+		&store.Bio,
+		&store.WhatsappNumber,
+		&store.Currency,
+		&store.LogoURL,
+		&store.BannerURL,
+		&store.PrimaryColor,
+		&store.AccentColor,
+		&store.InstagramURL,
+		&store.TiktokURL,
+		&store.FacebookURL,
+		&store.Location,
+		&store.OpeningHours,
+		&store.DeliveryNotes,
+		&store.Timezone,
+		&store.BusinessHours,
+		&store.VacationMode,
+		&store.VacationStartsAt,
+		&store.VacationEndsAt,
+		&store.VacationMessage,
+		&store.BlockOrdersWhenClosed,
+		&store.RatingAverage,
+		&store.RatingCount,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+		&store.OwnerUsername,
+		&store.OwnerEmail,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// CreateStore inserts a new store owned by store.OwnerID
+func (sr *StoreRepository) CreateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	store.ID = uuid.New().String()
+
+	query := `
+	INSERT INTO stores (id, owner_id, name, slug, bio, whatsapp_number, currency)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := sr.pool.Exec(
+		ctx,
+		query,
+		store.ID,
+		store.OwnerID,
+		store.Name,
+		store.Slug,
+		store.Bio,
+		store.WhatsappNumber,
+		store.Currency,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, utils.ErrSlugTaken
+		}
+		return nil, fmt.Errorf("failed to create store: %w", err)
+	}
+
+	return sr.GetByID(ctx, store.ID)
+}
+
+// GetByID retrieves a store by ID
+func (sr *StoreRepository) GetByID(ctx context.Context, storeID string) (*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	store, err := scanStore(sr.pool.QueryRow(ctx, storeSelect+`WHERE s.id = $1`, storeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreNotFound
+		}
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+
+	return store, nil
+}
+
+// GetBySlug retrieves a store by its public slug
+func (sr *StoreRepository) GetBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	store, err := scanStore(sr.pool.QueryRow(ctx, storeSelect+`WHERE s.slug = $1`, slug))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreNotFound
+		}
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+
+	return store, nil
+}
+
+// SlugInUse reports whether slug is the current or a previous slug of any
+// store other than exceptStoreID. Pass an empty exceptStoreID for new stores.
+func (sr *StoreRepository) SlugInUse(ctx context.Context, slug, exceptStoreID string) (bool, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT EXISTS (SELECT 1 FROM stores WHERE slug = $1 AND id <> $2)
+	    OR EXISTS (SELECT 1 FROM store_slug_history WHERE slug = $1 AND store_id <> $2)
+	`
+
+	var inUse bool
+	if err := sr.pool.QueryRow(ctx, query, slug, exceptStoreID).Scan(&inUse); err != nil {
+		return false, fmt.Errorf("failed to check store slug: %w", err)
+	}
+
+	return inUse, nil
+}
+
+// GetByPreviousSlug retrieves the store that used to be reachable at slug
+func (sr *StoreRepository) GetByPreviousSlug(ctx context.Context, slug string) (*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := storeSelect + `JOIN store_slug_history h ON h.store_id = s.id WHERE h.slug = $1`
+
+	store, err := scanStore(sr.pool.QueryRow(ctx, query, slug))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreNotFound
+		}
+		return nil, fmt.Errorf("failed to get store by previous slug: %w", err)
+	}
+
+	return store, nil
+}
+
+// ListByOwner returns the stores owned by a user, oldest first
+func (sr *StoreRepository) ListByOwner(ctx context.Context, ownerID string) ([]*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	return sr.queryStores(ctx, storeSelect+`WHERE s.owner_id = $1 ORDER BY s.created_at ASC`, ownerID)
+}
+
+// ListApproved returns stores whose owner is an approved vendor
+func (sr *StoreRepository) ListApproved(ctx context.Context, limit, offset int) ([]*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := storeSelect + `
+	WHERE u.role = 'vendor' AND u.is_active = true
+	ORDER BY s.created_at ASC
+	LIMIT $1 OFFSET $2
+	`
+
+	return sr.queryStores(ctx, query, limit, offset)
+}
+
+// SearchApproved matches approved stores by store name or owner username/name
+func (sr *StoreRepository) SearchApproved(ctx context.Context, searchTerm string) ([]*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := storeSelect + `
+	WHERE u.role = 'vendor' AND u.is_active = true
+	  AND (s.name ILIKE $1 OR u.username ILIKE $1 OR u.name ILIKE $1)
+	ORDER BY s.created_at ASC
+	`
+
+	return sr.queryStores(ctx, query, "%"+searchTerm+"%")
+}
+
+func (sr *StoreRepository) queryStores(ctx context.Context, query string, args ...any) ([]*models.Store, error) {
+	rows, err := sr.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stores: %w", err)
+	}
+	defer rows.Close()
+
+	stores := []*models.Store{}
+
+	for rows.Next() {
+		store, err := scanStore(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan store: %w", err)
+		}
+		stores = append(stores, store)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stores: %w", err)
+	}
+
+	return stores, nil
+}
+
+// UpdateStore persists the editable store settings. When the slug changes the
+// old one is recorded in store_slug_history so links to it keep resolving,
+// and a previous slug of this store that is being reused is removed from it.
+// When the currency changes, product prices and coupon amounts are rescaled
+// to its minor unit, failing with utils.ErrPricesNotConvertible if any can't be
+// converted exactly.
+func (sr *StoreRepository) UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	tx, err := sr.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var currentSlug, currentCurrency string
+	err = tx.QueryRow(ctx, `SELECT slug, currency FROM stores WHERE id = $1 FOR UPDATE`, store.ID).Scan(&currentSlug, &currentCurrency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrStoreNotFound
+		}
+		return nil, fmt.Errorf("failed to get store slug and currency: %w", err)
+	}
+
+	if currentSlug != store.Slug {
+		historyQuery := `
+		INSERT INTO store_slug_history (slug, store_id)
+		VALUES ($1, $2)
+		ON CONFLICT (slug) DO UPDATE SET store_id = EXCLUDED.store_id, created_at = NOW()
+		`
+		if _, err := tx.Exec(ctx, historyQuery, currentSlug, store.ID); err != nil {
+			return nil, fmt.Errorf("failed to record slug history: %w", err)
+		}
+
+		_, err := tx.Exec(ctx, `DELETE FROM store_slug_history WHERE slug = $1 AND store_id = $2`, store.Slug, store.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reclaim previous slug: %w", err)
+		}
+	}
+
+	query := `
+	UPDATE stores
+	SET name = $2, slug = $3, bio = $4, whatsapp_number = $5,
+	    logo_url = $6, banner_url = $7, primary_color = $8, accent_color = $9,
+	    instagram_url = $10, tiktok_url = $11, facebook_url = $12,
+	    location = $13, opening_hours = $14, delivery_notes = $15,
+	    timezone = $16, business_hours = $17, vacation_mode = $18,
+	    vacation_starts_at = $19, vacation_ends_at = $20, vacation_message = $21,
+	    block_orders_when_closed = $22, currency = $23,
+	    updated_at = NOW()
+	WHERE id = $1
+	`
+
+	// business_hours is NOT NULL, so a nil slice is stored as an empty list
+	businessHours := store.BusinessHours
+	if businessHours == nil {
+		businessHours = []models.BusinessHours{}
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		store.ID,
+		store.Name,
+		store.Slug,
+		store.Bio,
+		store.WhatsappNumber,
+		store.LogoURL,
+		store.BannerURL,
+		store.PrimaryColor,
+		store.AccentColor,
+		store.InstagramURL,
+		store.TiktokURL,
+		store.FacebookURL,
+		store.Location,
+		store.OpeningHours,
+		store.DeliveryNotes,
+		store.Timezone,
+		businessHours,
+		store.VacationMode,
+		store.VacationStartsAt,
+		store.VacationEndsAt,
+		store.VacationMessage,
+		store.BlockOrdersWhenClosed,
+		store.Currency,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, utils.ErrSlugTaken
+		}
+		return nil, fmt.Errorf("failed to update store: %w", err)
+	}
+
+	if err := rescaleStoreAmounts(ctx, tx, store.ID, models.CurrencyOf(currentCurrency), models.CurrencyOf(store.Currency)); err != nil {
+		return nil, err
+	}
+
+	// Products carry the store's currency for price range queries
+	_, err = tx.Exec(ctx, `UPDATE products SET currency = $2 WHERE store_id = $1 AND currency <> $2`, store.ID, store.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product currency: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit store update: %w", err)
+	}
+
+	return sr.GetByID(ctx, store.ID)
+}
+
+// rescaleStoreAmounts converts the minor-unit amounts of a store's products
+// and coupons from one currency's exponent to another's. Amounts that would
+// lose a fraction or overflow fail the whole update rather than change
+// value.
+func rescaleStoreAmounts(ctx context.Context, tx pgx.Tx, storeID string, from, to models.Currency) error {
+	multiplier, divisor := from.ScaleTo(to)
+	if multiplier == 1 && divisor == 1 {
+		return nil
+	}
+
+	// Amounts are only scaled up when they stay within BIGINT
+	limit := int64(math.MaxInt64) / multiplier
+
+	var convertible bool
+	checkQuery := `
+	SELECT NOT EXISTS (
+		SELECT 1 FROM products
+		WHERE store_id = $1
+		  AND (price % $2 <> 0 OR COALESCE(sale_price, 0) % $2 <> 0
+		       OR price > $3 OR COALESCE(sale_price, 0) > $3)
+	) AND NOT EXISTS (
+		SELECT 1 FROM coupons
+		WHERE store_id = $1
+		  AND (amount_off % $2 <> 0 OR min_spend % $2 <> 0
+		       OR amount_off > $3 OR min_spend > $3)
+	)
+	`
+	if err := tx.QueryRow(ctx, checkQuery, storeID, divisor, limit).Scan(&convertible); err != nil {
+		return fmt.Errorf("failed to check amounts for currency change: %w", err)
+	}
+	if !convertible {
+		return fmt.Errorf("%w: from %s to %s", utils.ErrPricesNotConvertible, from.Code, to.Code)
+	}
+
+	productQuery := `
+	UPDATE products
+	SET price = price * $2 / $3, sale_price = sale_price * $2 / $3
+	WHERE store_id = $1
+	`
+	if _, err := tx.Exec(ctx, productQuery, storeID, multiplier, divisor); err != nil {
+		return fmt.Errorf("failed to rescale product prices: %w", err)
+	}
+
+	couponQuery := `
+	UPDATE coupons
+	SET amount_off = amount_off * $2 / $3, min_spend = min_spend * $2 / $3
+	WHERE store_id = $1
+	`
+	if _, err := tx.Exec(ctx, couponQuery, storeID, multiplier, divisor); err != nil {
+		return fmt.Errorf("failed to rescale coupon amounts: %w", err)
+	}
+	return nil
+}
+
+// DeleteStore removes a store along with its products and memberships
+func (sr *StoreRepository) DeleteStore(ctx context.Context, storeID string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	result, err := sr.pool.Exec(ctx, `DELETE FROM stores WHERE id = $1`, storeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete store: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return utils.ErrStoreNotFound
+	}
+
+	return nil
+}
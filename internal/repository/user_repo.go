@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
 )
 
 type UserRepository struct {
@@ -25,9 +26,9 @@ func (r *UserRepository) CreateUser(user *models.User) (*models.User, error) {
 	user.CreatedAt = time.Now()
 
 	query := `
-		INSERT INTO users (id, name, email, password_hash, whatsapp_number, username, bio, store_name, store_slug, role, is_active, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, name, email, username, whatsapp_number, bio, store_name, store_slug, role, is_active, created_at
+		INSERT INTO users (id, name, email, password_hash, whatsapp_number, username, bio, role, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, name, email, username, whatsapp_number, bio, role, is_active, created_at
 	`
 
 	err := r.pool.QueryRow(
@@ -40,13 +41,17 @@ func (r *UserRepository) CreateUser(user *models.User) (*models.User, error) {
 		user.WhatsappNumber,
 		user.Username,
 		user.Bio,
-		user.StoreName,
-		user.StoreSlug,
 		user.Role,
 		user.IsActive,
 		user.CreatedAt,
-	).Scan(&user.ID, &user.Name, &user.Email, &user.Username, &user.WhatsappNumber, &user.Bio, &user.StoreName, &user.StoreSlug, &user.Role, &user.IsActive, &user.CreatedAt)
+	).Scan(&user.ID, &user.Name, &user.Email, &user.Username, &user.WhatsappNumber, &user.Bio, &user.Role, &user.IsActive, &user.CreatedAt)
 	if err != nil {
+		switch uniqueViolationConstraint(err) {
+		case "users_email_key":
+			return nil, utils.ErrEmailTaken
+		case "users_username_key":
+			return nil, utils.ErrUsernameTaken
+		}
 		return nil, err
 	}
 
@@ -57,7 +62,7 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	user := &models.User{}
 
 	query := `
-		SELECT id, name, email, password_hash, whatsapp_number, username, bio, store_name, store_slug, role, is_active, created_at
+		SELECT id, name, email, password_hash, whatsapp_number, username, bio, role, is_active, created_at
 		FROM users
 		WHERE email = $1
 	`
@@ -70,15 +75,13 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		&user.WhatsappNumber,
 		&user.Username,
 		&user.Bio,
-		&user.StoreName,
-		&user.StoreSlug,
 		&user.Role,
 		&user.IsActive,
 		&user.CreatedAt,
 	)
 
-	if err == pgx.ErrNoRows {
-		return nil, errors.New("user not found")
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -91,7 +94,7 @@ func (r *UserRepository) GetByID(id string) (*models.User, error) {
 	user := &models.User{}
 
 	query := `
-		SELECT id, name, email, password_hash, whatsapp_number, username, bio, store_name, store_slug, role, is_active, created_at
+		SELECT id, name, email, password_hash, whatsapp_number, username, bio, role, is_active, created_at
 		FROM users
 		WHERE id = $1
 	`
@@ -104,15 +107,13 @@ func (r *UserRepository) GetByID(id string) (*models.User, error) {
 		&user.WhatsappNumber,
 		&user.Username,
 		&user.Bio,
-		&user.StoreName,
-		&user.StoreSlug,
 		&user.Role,
 		&user.IsActive,
 		&user.CreatedAt,
 	)
 
-	if err == pgx.ErrNoRows {
-		return nil, errors.New("user not found")
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -121,59 +122,6 @@ func (r *UserRepository) GetByID(id string) (*models.User, error) {
 	return user, nil
 }
 
-func (r *UserRepository) GetByStoreSlug(slug string) (*models.User, error) {
-	user := &models.User{}
-
-	query := `
-		SELECT id, name, email, password_hash, whatsapp_number, username, bio, store_name, store_slug, role, is_active, created_at
-		FROM users
-		WHERE store_slug = $1
-	`
-
-	err := r.pool.QueryRow(context.Background(), query, slug).Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.PasswordHash,
-		&user.WhatsappNumber,
-		&user.Username,
-		&user.Bio,
-		&user.StoreName,
-		&user.StoreSlug,
-		&user.Role,
-		&user.IsActive,
-		&user.CreatedAt,
-	)
-
-	if err == pgx.ErrNoRows {
-		return nil, errors.New("store not found")
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	return user, nil
-}
-
-func (r *UserRepository) UpdateStoreSettings(userID, storeName, storeSlug, bio, whatsapp string) error {
-	query := `
-		UPDATE users
-		SET store_name = $1, store_slug = $2, bio = $3, whatsapp_number = $4
-		WHERE id = $5
-	`
-
-	result, err := r.pool.Exec(context.Background(), query, storeName, storeSlug, bio, whatsapp, userID)
-	if err != nil {
-		return err
-	}
-
-	if result.RowsAffected() == 0 {
-		return errors.New("user not found")
-	}
-
-	return nil
-}
-
 func (r *UserRepository) ApproveVendor(id string) error {
 	query := `
 		UPDATE users
@@ -187,7 +135,7 @@ func (r *UserRepository) ApproveVendor(id string) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("user not found")
+		return utils.ErrUserNotFound
 	}
 
 	return nil
@@ -195,7 +143,7 @@ func (r *UserRepository) ApproveVendor(id string) error {
 
 func (r *UserRepository) GetPendingVendors() ([]models.User, error) {
 	query := `
-		SELECT id, name, email, whatsapp_number, username, bio, role, is_active, created_at, store_name, store_slug
+		SELECT id, name, email, whatsapp_number, username, bio, role, is_active, created_at
 		FROM users
 		WHERE role = 'vendor' AND is_active = false
 	`
@@ -219,8 +167,6 @@ func (r *UserRepository) GetPendingVendors() ([]models.User, error) {
 			&user.Role,
 			&user.IsActive,
 			&user.CreatedAt,
-			&user.StoreName,
-			&user.StoreSlug,
 		); err != nil {
 			return nil, err
 		}
@@ -236,7 +182,7 @@ func (r *UserRepository) GetPendingVendors() ([]models.User, error) {
 
 func (r *UserRepository) GetApprovedVendors() ([]models.User, error) {
 	query := `
-		SELECT id, name, email, whatsapp_number, username, bio, role, is_active, created_at, store_name, store_slug
+		SELECT id, name, email, whatsapp_number, username, bio, role, is_active, created_at
 		FROM users
 		WHERE role = 'vendor' AND is_active = true
 	`
@@ -260,8 +206,6 @@ func (r *UserRepository) GetApprovedVendors() ([]models.User, error) {
 			&user.Role,
 			&user.IsActive,
 			&user.CreatedAt,
-			&user.StoreName,
-			&user.StoreSlug,
 		); err != nil {
 			return nil, err
 		}
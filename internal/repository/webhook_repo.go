@@ -0,0 +1,459 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+const webhookEndpointColumns = `
+	id, store_id, url, secret, events, description, is_active, created_at, updated_at
+	`
+
+func scanWebhookEndpoint(row pgx.Row) (*models.WebhookEndpoint, error) {
+	endpoint := &models.WebhookEndpoint{}
+	err := row.Scan(
+		&endpoint.ID,
+		&endpoint.StoreID,
+		&endpoint.URL,
+		&endpoint.Secret,
+		&endpoint.Events,
+		&endpoint.Description,
+		&endpoint.IsActive,
+		&endpoint.CreatedAt,
+		&endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+const webhookDeliveryColumns = `
+	id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, last_attempt_at, delivered_at, created_at, updated_at
+	`
+
+func scanWebhookDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.EndpointID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.LastAttemptAt,
+		&delivery.DeliveredAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// CreateEndpoint inserts a webhook endpoint
+func (wr *WebhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) (*models.WebhookEndpoint, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	endpoint.ID = uuid.New().String()
+
+	query := `
+	INSERT INTO webhook_endpoints (id, store_id, url, secret, events, description, is_active)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING ` + webhookEndpointColumns
+
+	created, err := scanWebhookEndpoint(wr.pool.QueryRow(
+		ctx,
+		query,
+		endpoint.ID,
+		endpoint.StoreID,
+		endpoint.URL,
+		endpoint.Secret,
+		endpoint.Events,
+		endpoint.Description,
+		endpoint.IsActive,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetEndpoint retrieves a webhook endpoint by ID
+func (wr *WebhookRepository) GetEndpoint(ctx context.Context, endpointID string) (*models.WebhookEndpoint, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE id = $1`
+
+	endpoint, err := scanWebhookEndpoint(wr.pool.QueryRow(ctx, query, endpointID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// ListEndpoints returns a store's webhook endpoints, oldest first
+func (wr *WebhookRepository) ListEndpoints(ctx context.Context, storeID string) ([]*models.WebhookEndpoint, error) {
+	query := `SELECT ` + webhookEndpointColumns + ` FROM webhook_endpoints WHERE store_id = $1 ORDER BY created_at`
+	return wr.listEndpoints(ctx, query, storeID)
+}
+
+// ListSubscribed returns a store's active endpoints subscribed to eventType
+func (wr *WebhookRepository) ListSubscribed(ctx context.Context, storeID, eventType string) ([]*models.WebhookEndpoint, error) {
+	query := `
+	SELECT ` + webhookEndpointColumns + `
+	FROM webhook_endpoints
+	WHERE store_id = $1 AND is_active = TRUE AND $2 = ANY(events)
+	`
+	return wr.listEndpoints(ctx, query, storeID, eventType)
+}
+
+func (wr *WebhookRepository) listEndpoints(ctx context.Context, query string, args ...any) ([]*models.WebhookEndpoint, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	rows, err := wr.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []*models.WebhookEndpoint{}
+
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// UpdateEndpoint saves a webhook endpoint's editable fields and secret
+func (wr *WebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) (*models.WebhookEndpoint, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE webhook_endpoints
+	SET url = $2, secret = $3, events = $4, description = $5, is_active = $6, updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + webhookEndpointColumns
+
+	updated, err := scanWebhookEndpoint(wr.pool.QueryRow(
+		ctx,
+		query,
+		endpoint.ID,
+		endpoint.URL,
+		endpoint.Secret,
+		endpoint.Events,
+		endpoint.Description,
+		endpoint.IsActive,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteEndpoint removes a webhook endpoint along with its deliveries
+func (wr *WebhookRepository) DeleteEndpoint(ctx context.Context, endpointID string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	result, err := wr.pool.Exec(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, endpointID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return utils.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// CreateDeliveries queues deliveries, due immediately
+func (wr *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	tx, err := wr.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, payload, status)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, delivery := range deliveries {
+		delivery.ID = uuid.New().String()
+		_, err := tx.Exec(
+			ctx,
+			query,
+			delivery.ID,
+			delivery.EndpointID,
+			delivery.EventID,
+			delivery.EventType,
+			delivery.Payload,
+			models.WebhookDeliveryPending,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue returns up to limit pending deliveries whose attempt is due and
+// pushes their next attempt back by lease, so other server instances skip
+// them while this one sends
+func (wr *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE webhook_deliveries
+	SET next_attempt_at = NOW() + make_interval(secs => $2), updated_at = NOW()
+	WHERE id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE status = $3 AND next_attempt_at <= NOW()
+		ORDER BY next_attempt_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + webhookDeliveryColumns
+
+	rows, err := wr.pool.Query(ctx, query, limit, lease.Seconds(), models.WebhookDeliveryPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt saves the outcome of a delivery attempt
+func (wr *WebhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE webhook_deliveries
+	SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6,
+	    last_attempt_at = $7, delivered_at = $8, updated_at = NOW()
+	WHERE id = $1
+	`
+
+	_, err := wr.pool.Exec(
+		ctx,
+		query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.LastAttemptAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	return nil
+}
+
+// GetDelivery retrieves a webhook delivery by ID
+func (wr *WebhookRepository) GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	delivery, err := scanWebhookDelivery(wr.pool.QueryRow(ctx, query, deliveryID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ListDeliveries returns a page of an endpoint's deliveries, newest first.
+// An empty status matches every delivery.
+func (wr *WebhookRepository) ListDeliveries(ctx context.Context, endpointID, status string, limit, offset int) ([]*models.WebhookDelivery, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	SELECT ` + webhookDeliveryColumns + `
+	FROM webhook_deliveries
+	WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+	ORDER BY created_at DESC
+	LIMIT $3 OFFSET $4
+	`
+
+	rows, err := wr.pool.Query(ctx, query, endpointID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver puts a delivery back in the queue, due now, with a fresh
+// retry schedule
+func (wr *WebhookRepository) Redeliver(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	query := `
+	UPDATE webhook_deliveries
+	SET status = $2, attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + webhookDeliveryColumns
+
+	delivery, err := scanWebhookDelivery(wr.pool.QueryRow(ctx, query, deliveryID, models.WebhookDeliveryPending))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// PruneDeliveriesBefore deletes finished deliveries created before cutoff
+// and returns how many were removed
+func (wr *WebhookRepository) PruneDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	result, err := wr.pool.Exec(
+		ctx,
+		`DELETE FROM webhook_deliveries WHERE status <> $1 AND created_at < $2`,
+		models.WebhookDeliveryPending,
+		cutoff,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune webhook deliveries: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
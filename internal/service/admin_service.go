@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/falasefemi2/vendorhub/internal/models"
@@ -15,11 +16,12 @@ type AdminRepository interface {
 }
 
 type AdminService struct {
-	userRepo AdminRepository
+	userRepo     AdminRepository
+	storeService *StoreService
 }
 
-func NewAdminService(repo AdminRepository) *AdminService {
-	return &AdminService{userRepo: repo}
+func NewAdminService(repo AdminRepository, storeService *StoreService) *AdminService {
+	return &AdminService{userRepo: repo, storeService: storeService}
 }
 
 func (s *AdminService) ApproveVendor(adminID, vendorID string) error {
@@ -49,6 +51,8 @@ func (s *AdminService) ApproveVendor(adminID, vendorID string) error {
 	if err := s.userRepo.ApproveVendor(vendorID); err != nil {
 		return err
 	}
+	// The vendor's stores are now listed
+	s.storeService.InvalidateOwnerCatalog(context.Background(), vendorID)
 	return nil
 }
 
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// analyticsRollupWindow is how many past days each rollup recomputes.
+	// Events are bucketed by the store's local day, so "today" in UTC can
+	// lag or lead a store by up to a day.
+	analyticsRollupWindow = 2
+	// analyticsEventRetention is how long raw events are kept after their
+	// day has been rolled up
+	analyticsEventRetention = 7
+	maxAnalyticsRange       = 366
+	topProductsLimit        = 10
+)
+
+// botUserAgent matches crawlers, link preview fetchers (including
+// WhatsApp's own, which fires whenever a store link is shared) and HTTP
+// libraries, none of which are real visitors.
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|preview|facebookexternalhit|whatsapp|headless|lighthouse|curl|wget|python-requests|go-http-client|okhttp|axios|node-fetch`)
+
+type AnalyticsRepository interface {
+	RecordEvent(ctx context.Context, event *models.AnalyticsEvent) (bool, error)
+	RollupSince(ctx context.Context, since time.Time) (int64, error)
+	PruneEventsBefore(ctx context.Context, before time.Time) (int64, error)
+	GetDailyStats(ctx context.Context, storeID string, from, to time.Time) ([]*models.DailyStat, error)
+	GetTopProducts(ctx context.Context, storeID string, from, to time.Time, limit int) ([]*models.ProductStat, error)
+}
+
+type AnalyticsService struct {
+	repo         AnalyticsRepository
+	products     ProductLookup
+	storeService *StoreService
+	salt         string
+	logger       *slog.Logger
+}
+
+func NewAnalyticsService(repo AnalyticsRepository, products ProductLookup, storeService *StoreService, salt string, logger *slog.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		repo:         repo,
+		products:     products,
+		storeService: storeService,
+		salt:         salt,
+		logger:       logger,
+	}
+}
+
+// IsBot reports whether a user agent belongs to an automated client
+func IsBot(userAgent string) bool {
+	return strings.TrimSpace(userAgent) == "" || botUserAgent.MatchString(userAgent)
+}
+
+// TrackEvent records a storefront event. Bots are ignored silently and a
+// visitor counts once per event, store, product and day.
+func (s *AnalyticsService) TrackEvent(ctx context.Context, req dto.TrackEventRequest, clientIP, userAgent string) error {
+	if IsBot(userAgent) {
+		return nil
+	}
+
+	switch req.Type {
+	case models.EventStoreView:
+		req.ProductID = ""
+	case models.EventProductView:
+		if req.ProductID == "" {
+			return fmt.Errorf("%w: product_id is required for product_view", utils.ErrInvalidOperation)
+		}
+	case models.EventWhatsappClick:
+	default:
+		return fmt.Errorf("%w: unknown event type %q", utils.ErrInvalidOperation, req.Type)
+	}
+
+	if req.StoreID == "" {
+		return fmt.Errorf("%w: store_id is required", utils.ErrInvalidOperation)
+	}
+
+	store, err := s.storeService.GetStoreByID(ctx, req.StoreID)
+	if err != nil {
+		return err
+	}
+
+	if req.ProductID != "" {
+		product, err := s.products.GetProductByID(ctx, req.ProductID)
+		if err != nil {
+			return err
+		}
+		if product.StoreID != store.ID {
+			return fmt.Errorf("%w: product does not belong to this store", utils.ErrInvalidOperation)
+		}
+	}
+
+	visitor := req.VisitorID
+	if visitor == "" {
+		visitor = clientIP + "|" + userAgent
+	}
+
+	local := time.Now().In(storeLocation(store))
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	// The day is part of the hash so visitors can't be followed across days
+	_, err = s.repo.RecordEvent(ctx, &models.AnalyticsEvent{
+		StoreID:     store.ID,
+		ProductID:   req.ProductID,
+		EventType:   req.Type,
+		VisitorHash: utils.HashToken(s.salt + "|" + day.Format(dateLayout) + "|" + visitor),
+		Day:         day,
+	})
+	return err
+}
+
+// Rollup refreshes the daily aggregates for recent days and prunes raw
+// events that are past retention
+func (s *AnalyticsService) Rollup(ctx context.Context) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	if _, err := s.repo.RollupSince(ctx, today.AddDate(0, 0, -analyticsRollupWindow)); err != nil {
+		return err
+	}
+
+	_, err := s.repo.PruneEventsBefore(ctx, today.AddDate(0, 0, -analyticsEventRetention))
+	return err
+}
+
+// StartRollupJob runs Rollup every interval until ctx is cancelled
+func (s *AnalyticsService) StartRollupJob(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Rollup(ctx); err != nil {
+				s.logger.ErrorContext(ctx, "analytics rollup failed", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GetStoreAnalytics returns daily totals and top products of a store owned
+// by ownerID between from and to (YYYY-MM-DD, inclusive). The range defaults
+// to the last 30 days.
+func (s *AnalyticsService) GetStoreAnalytics(ctx context.Context, ownerID, storeID, from, to string) (*dto.StoreAnalyticsResponse, error) {
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	local := time.Now().In(storeLocation(store))
+	toDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		if toDay, err = time.Parse(dateLayout, to); err != nil {
+			return nil, fmt.Errorf("%w: to must be a date like 2025-01-31", utils.ErrInvalidOperation)
+		}
+	}
+
+	fromDay := toDay.AddDate(0, 0, -29)
+	if from != "" {
+		if fromDay, err = time.Parse(dateLayout, from); err != nil {
+			return nil, fmt.Errorf("%w: from must be a date like 2025-01-01", utils.ErrInvalidOperation)
+		}
+	}
+
+	if fromDay.After(toDay) {
+		return nil, fmt.Errorf("%w: from must not be after to", utils.ErrInvalidOperation)
+	}
+	if toDay.Sub(fromDay) > maxAnalyticsRange*24*time.Hour {
+		return nil, fmt.Errorf("%w: date range may span at most %d days", utils.ErrInvalidOperation, maxAnalyticsRange)
+	}
+
+	daily, err := s.repo.GetDailyStats(ctx, store.ID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+
+	topProducts, err := s.repo.GetTopProducts(ctx, store.ID, fromDay, toDay, topProductsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zero-fill the series so charts get one point per day
+	byDate := map[string]*dto.AnalyticsDay{}
+	series := []dto.AnalyticsDay{}
+	for day := fromDay; !day.After(toDay); day = day.AddDate(0, 0, 1) {
+		series = append(series, dto.AnalyticsDay{Date: day.Format(dateLayout)})
+	}
+	for i := range series {
+		byDate[series[i].Date] = &series[i]
+	}
+
+	response := &dto.StoreAnalyticsResponse{
+		StoreID:     store.ID,
+		From:        fromDay.Format(dateLayout),
+		To:          toDay.Format(dateLayout),
+		Series:      series,
+		TopProducts: make([]*dto.ProductAnalytics, len(topProducts)),
+	}
+
+	for _, stat := range daily {
+		day, ok := byDate[stat.Day.Format(dateLayout)]
+		if !ok {
+			continue
+		}
+		addEventCount(&day.AnalyticsTotals, stat.EventType, stat.Count)
+		addEventCount(&response.Totals, stat.EventType, stat.Count)
+	}
+
+	for i, stat := range topProducts {
+		response.TopProducts[i] = &dto.ProductAnalytics{
+			ProductID:      stat.ProductID,
+			Name:           stat.ProductName,
+			Views:          stat.Views,
+			WhatsappClicks: stat.WhatsappClicks,
+		}
+	}
+
+	return response, nil
+}
+
+func addEventCount(totals *dto.AnalyticsTotals, eventType string, count int) {
+	switch eventType {
+	case models.EventStoreView:
+		totals.StoreViews += count
+	case models.EventProductView:
+		totals.ProductViews += count
+	case models.EventWhatsappClick:
+		totals.WhatsappClicks += count
+	}
+}
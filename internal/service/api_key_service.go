@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// apiKeyScopes are the permissions an API key can be granted
+var apiKeyScopes = []string{PermissionManageCatalog, PermissionManageOrders}
+
+// apiKeyTouchInterval limits how often a key's last use is written, so
+// busy integrations don't cost a write per request
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+	GetAPIKey(ctx context.Context, keyID string) (*models.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	ListByStore(ctx context.Context, storeID string) ([]*models.APIKey, error)
+	Revoke(ctx context.Context, keyID string) (*models.APIKey, error)
+	TouchLastUsed(ctx context.Context, keyID string) error
+}
+
+type APIKeyService struct {
+	repo         APIKeyRepository
+	userRepo     UserRepository
+	storeService *StoreService
+	logger       *slog.Logger
+}
+
+func NewAPIKeyService(repo APIKeyRepository, userRepo UserRepository, storeService *StoreService, logger *slog.Logger) *APIKeyService {
+	return &APIKeyService{repo: repo, userRepo: userRepo, storeService: storeService, logger: logger}
+}
+
+// CreateAPIKey issues an API key for a store owned by ownerID. The response
+// carries the key itself, which isn't stored and can't be shown again.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, ownerID string, req dto.CreateAPIKeyRequest) (*dto.APIKeyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	scopes, err := normaliseAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := parseOptionalTime(req.ExpiresAt)
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", utils.ErrInvalidOperation)
+	}
+
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateToken(24)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := models.APIKeyPrefix + token
+
+	created, err := s.repo.CreateAPIKey(ctx, &models.APIKey{
+		UserID:    ownerID,
+		StoreID:   store.ID,
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    key[:len(models.APIKeyPrefix)+8],
+		KeyHash:   utils.HashToken(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapAPIKeyToResponse(created)
+	response.Key = key
+	return response, nil
+}
+
+// ListAPIKeys returns the API keys of a store owned by ownerID, including
+// revoked and expired ones
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, ownerID, storeID string) ([]*dto.APIKeyResponse, error) {
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.ListByStore(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = mapAPIKeyToResponse(key)
+	}
+	return responses, nil
+}
+
+// RevokeAPIKey stops an API key of a store owned by ownerID from working.
+// The key stays listed with its revocation time.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, ownerID, keyID string) (*dto.APIKeyResponse, error) {
+	key, err := s.repo.GetAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.storeService.GetOwnedStore(ctx, ownerID, key.StoreID); err != nil {
+		if errors.Is(err, utils.ErrStoreNotFound) {
+			return nil, utils.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	revoked, err := s.repo.Revoke(ctx, key.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAPIKeyToResponse(revoked), nil
+}
+
+// AuthenticateAPIKey resolves a key sent with a request to the API key it
+// belongs to and records its use. Unknown, revoked and expired keys, and
+// keys of vendors who are no longer active, are rejected as unauthorized.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if !strings.HasPrefix(key, models.APIKeyPrefix) {
+		return nil, utils.ErrUnauthorized
+	}
+
+	apiKey, err := s.repo.GetByHash(ctx, utils.HashToken(key))
+	if err != nil {
+		if errors.Is(err, utils.ErrAPIKeyNotFound) {
+			return nil, utils.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)) {
+		return nil, utils.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, utils.ErrUserNotFound) {
+			return nil, utils.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, utils.ErrUnauthorized
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.repo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+			s.logger.WarnContext(ctx, "failed to record API key use", "api_key_id", apiKey.ID, "error", err)
+		}
+	}
+
+	return apiKey, nil
+}
+
+// normaliseAPIKeyScopes checks scopes against the supported ones and drops
+// duplicates
+func normaliseAPIKeyScopes(scopes []string) ([]string, error) {
+	normalised := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(apiKeyScopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q, expected one of %s",
+				utils.ErrInvalidOperation, scope, strings.Join(apiKeyScopes, ", "))
+		}
+		if !slices.Contains(normalised, scope) {
+			normalised = append(normalised, scope)
+		}
+	}
+	return normalised, nil
+}
+
+func mapAPIKeyToResponse(key *models.APIKey) *dto.APIKeyResponse {
+	response := &dto.APIKeyResponse{
+		ID:        key.ID,
+		StoreID:   key.StoreID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+	}
+	if key.LastUsedAt != nil {
+		response.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+	if key.ExpiresAt != nil {
+		response.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+	if key.RevokedAt != nil {
+		response.RevokedAt = key.RevokedAt.Format(time.RFC3339)
+	}
+	return response
+}
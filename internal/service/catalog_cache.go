@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/cache"
+	"github.com/falasefemi2/vendorhub/internal/dto"
+)
+
+const catalogCacheActiveProducts = "catalog:active-products"
+
+// CatalogCache keeps the active product lists that storefront pages and
+// link previews fetch, with their images, so they aren't loaded from the
+// database on every request. Changes to a store or its products drop its
+// lists; the TTL covers changes that don't, such as sales starting on their
+// own and new ratings.
+type CatalogCache struct {
+	cache  cache.Cache
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+// NewCatalogCache returns a catalog cache keeping lists in c for ttl, or
+// one that caches nothing when c is nil
+func NewCatalogCache(c cache.Cache, ttl time.Duration, logger *slog.Logger) *CatalogCache {
+	return &CatalogCache{cache: c, ttl: ttl, logger: logger}
+}
+
+func catalogCacheStoreProducts(storeID string) string {
+	return "catalog:store-products:" + storeID
+}
+
+// catalogCacheEntry is a cached list with the time it was loaded, which
+// is when its contents last changed as far as readers can tell
+type catalogCacheEntry struct {
+	LoadedAt time.Time              `json:"loaded_at"`
+	Products []*dto.ProductResponse `json:"products"`
+}
+
+// products returns the list under key and when it was loaded, loading and
+// caching it on a miss. The time is zero when caching is off, as every
+// request then loads its own list. Each call returns its own copy, so
+// callers may sort it.
+func (c *CatalogCache) products(ctx context.Context, key string, load func() ([]*dto.ProductResponse, error)) ([]*dto.ProductResponse, time.Time, error) {
+	if c.cache == nil {
+		products, err := load()
+		return products, time.Time{}, err
+	}
+
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to read catalog cache", "key", key, "error", err)
+	}
+	if ok {
+		var entry catalogCacheEntry
+		err := json.Unmarshal(data, &entry)
+		if err == nil && entry.LoadedAt.IsZero() {
+			// Written before entries recorded when they were loaded
+			err = errors.New("entry has no load time")
+		}
+		if err == nil {
+			return entry.Products, entry.LoadedAt, nil
+		}
+		c.logger.WarnContext(ctx, "dropping unreadable catalog cache entry", "key", key, "error", err)
+	}
+
+	products, err := load()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	entry := catalogCacheEntry{LoadedAt: time.Now().UTC(), Products: products}
+	if data, err := json.Marshal(entry); err == nil {
+		if err := c.cache.Set(ctx, key, data, c.ttl); err != nil {
+			c.logger.WarnContext(ctx, "failed to write catalog cache", "key", key, "error", err)
+		}
+	}
+	return products, entry.LoadedAt, nil
+}
+
+// InvalidateStore drops the cached lists containing a store's products,
+// once a change to them is saved. A request that loaded the lists just
+// before the change may still cache them, until the TTL runs out.
+func (c *CatalogCache) InvalidateStore(ctx context.Context, storeID string) {
+	if c.cache == nil {
+		return
+	}
+	if err := c.cache.Delete(ctx, catalogCacheActiveProducts, catalogCacheStoreProducts(storeID)); err != nil {
+		c.logger.WarnContext(ctx, "failed to invalidate catalog cache", "store_id", storeID, "error", err)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/cache"
+	"github.com/falasefemi2/vendorhub/internal/dto"
+)
+
+func TestCatalogCacheInvalidateStore(t *testing.T) {
+	ctx := context.Background()
+	catalog := NewCatalogCache(cache.NewLRU(10), time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	loads := map[string]int{}
+	loader := func(key string) func() ([]*dto.ProductResponse, error) {
+		return func() ([]*dto.ProductResponse, error) {
+			loads[key]++
+			return []*dto.ProductResponse{{ID: key}}, nil
+		}
+	}
+	fetch := func(key string) time.Time {
+		products, loadedAt, err := catalog.products(ctx, key, loader(key))
+		if err != nil || len(products) != 1 || products[0].ID != key || loadedAt.IsZero() {
+			t.Fatalf("products(%q) = %v, %v, %v", key, products, loadedAt, err)
+		}
+		return loadedAt
+	}
+
+	keys := []string{catalogCacheActiveProducts, catalogCacheStoreProducts("store-1"), catalogCacheStoreProducts("store-2")}
+	for _, key := range keys {
+		loadedAt := fetch(key)
+		if cached := fetch(key); !cached.Equal(loadedAt) {
+			t.Errorf("%s cached with load time %v, want %v", key, cached, loadedAt)
+		}
+	}
+
+	catalog.InvalidateStore(ctx, "store-1")
+	for _, key := range keys {
+		fetch(key)
+	}
+
+	want := map[string]int{
+		catalogCacheActiveProducts:           2,
+		catalogCacheStoreProducts("store-1"): 2,
+		catalogCacheStoreProducts("store-2"): 1,
+	}
+	for key, n := range want {
+		if loads[key] != n {
+			t.Errorf("%s loaded %d times, want %d", key, loads[key], n)
+		}
+	}
+}
+
+func TestCatalogCacheDisabled(t *testing.T) {
+	catalog := NewCatalogCache(nil, time.Minute, slog.Default())
+
+	loads := 0
+	for range 2 {
+		_, loadedAt, _ := catalog.products(context.Background(), catalogCacheActiveProducts, func() ([]*dto.ProductResponse, error) {
+			loads++
+			return nil, nil
+		})
+		if !loadedAt.IsZero() {
+			t.Errorf("load time = %v, want none without a cache", loadedAt)
+		}
+	}
+	catalog.InvalidateStore(context.Background(), "store-1")
+
+	if loads != 2 {
+		t.Errorf("loaded %d times, want every request loaded", loads)
+	}
+}
+
+func TestCatalogCacheReloadsEntriesWithoutLoadTime(t *testing.T) {
+	ctx := context.Background()
+	lru := cache.NewLRU(10)
+	catalog := NewCatalogCache(lru, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Lists were cached as bare arrays before they recorded a load time
+	if err := lru.Set(ctx, catalogCacheActiveProducts, []byte(`[{"id":"old"}]`), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	products, loadedAt, err := catalog.products(ctx, catalogCacheActiveProducts, func() ([]*dto.ProductResponse, error) {
+		return []*dto.ProductResponse{{ID: "new"}}, nil
+	})
+	if err != nil || len(products) != 1 || products[0].ID != "new" || loadedAt.IsZero() {
+		t.Errorf("products() = %v, %v, %v, want the reloaded list", products, loadedAt, err)
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+// Catalog feed formats served for each store
+const (
+	CatalogFeedMetaCSV = "meta.csv"
+	CatalogFeedMetaXML = "meta.xml"
+	CatalogFeedGoogle  = "google.xml"
+)
+
+const (
+	// catalogFeedMaxTitle is Google's title limit; Meta allows longer
+	catalogFeedMaxTitle = 150
+	// catalogFeedMaxAdditionalImages is Google's limit; Meta allows more
+	catalogFeedMaxAdditionalImages = 10
+)
+
+// metaFeedCSVColumns is the header of the Meta Commerce CSV feed
+var metaFeedCSVColumns = []string{
+	"id", "title", "description", "availability", "condition", "price", "link", "image_link",
+	"brand", "additional_image_link", "sale_price", "sale_price_effective_date", "product_type",
+}
+
+// catalogFeedItem is one product as listed in every feed format
+type catalogFeedItem struct {
+	ID                   string
+	Title                string
+	Description          string
+	Link                 string
+	ImageLink            string
+	AdditionalImageLinks []string
+	InStock              bool
+	Price                string
+	SalePrice            string
+	SaleEffectiveDate    string
+	Brand                string
+	ProductType          string
+}
+
+// newCatalogFeedItem describes a product of store at now, linking to it at
+// link. Products without images return false: Meta and Google both reject
+// items without one.
+func newCatalogFeedItem(store *models.Store, product *models.Product, link string, images []*models.ProductImage, now time.Time) (catalogFeedItem, bool) {
+	if len(images) == 0 {
+		return catalogFeedItem{}, false
+	}
+
+	currency := models.CurrencyOf(product.Currency)
+	item := catalogFeedItem{
+		ID:          product.ID,
+		Title:       truncateRunes(product.Name, catalogFeedMaxTitle),
+		Description: product.Description,
+		Link:        link,
+		ImageLink:   images[0].ImageURL,
+		InStock:     !isOnVacation(store, now),
+		Price:       feedPrice(currency, product.Price),
+		Brand:       store.Name,
+		ProductType: product.Category,
+	}
+	for _, image := range images[1:] {
+		if len(item.AdditionalImageLinks) == catalogFeedMaxAdditionalImages {
+			break
+		}
+		item.AdditionalImageLinks = append(item.AdditionalImageLinks, image.ImageURL)
+	}
+
+	if isOnSale(product, now) || isUpcomingSale(product, now) {
+		item.SalePrice = feedPrice(currency, *product.SalePrice)
+		// Both platforms need both ends of the window; a running sale
+		// without a start is given the time the feed was generated
+		if product.SaleEndsAt != nil {
+			startsAt := now
+			if product.SaleStartsAt != nil {
+				startsAt = *product.SaleStartsAt
+			}
+			item.SaleEffectiveDate = startsAt.UTC().Format(time.RFC3339) + "/" + product.SaleEndsAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return item, true
+}
+
+// isUpcomingSale reports whether the product has a sale with a fixed window
+// that starts after now, which feeds can announce ahead of time
+func isUpcomingSale(product *models.Product, now time.Time) bool {
+	if product.SaleStartsAt == nil || product.SaleEndsAt == nil || !now.Before(*product.SaleStartsAt) {
+		return false
+	}
+	return isOnSale(product, *product.SaleStartsAt)
+}
+
+// feedPrice renders an amount the way both platforms expect, e.g.
+// "1500.50 NGN"
+func feedPrice(currency models.Currency, amount models.Money) string {
+	return currency.Decimal(amount) + " " + currency.Code
+}
+
+func truncateRunes(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit])
+}
+
+// writeMetaFeedCSV writes items as a Meta Commerce Manager data feed
+func writeMetaFeedCSV(w io.Writer, items []catalogFeedItem) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(metaFeedCSVColumns); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		record := []string{
+			item.ID,
+			item.Title,
+			item.Description,
+			metaAvailability(item.InStock),
+			"new",
+			item.Price,
+			item.Link,
+			item.ImageLink,
+			item.Brand,
+			strings.Join(item.AdditionalImageLinks, ","),
+			item.SalePrice,
+			item.SaleEffectiveDate,
+			item.ProductType,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// rssFeed is an RSS 2.0 document with Google's product namespace, read by
+// both Google Merchant Center and Meta Commerce Manager
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	Namespace string     `xml:"xmlns:g,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	ID                   string   `xml:"g:id"`
+	Title                string   `xml:"g:title"`
+	Description          string   `xml:"g:description"`
+	Link                 string   `xml:"g:link"`
+	ImageLink            string   `xml:"g:image_link"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link"`
+	Availability         string   `xml:"g:availability"`
+	Condition            string   `xml:"g:condition"`
+	Price                string   `xml:"g:price"`
+	SalePrice            string   `xml:"g:sale_price,omitempty"`
+	SaleEffectiveDate    string   `xml:"g:sale_price_effective_date,omitempty"`
+	Brand                string   `xml:"g:brand"`
+	ProductType          string   `xml:"g:product_type,omitempty"`
+	// Vendors' own goods have no GTIN or MPN
+	IdentifierExists string `xml:"g:identifier_exists"`
+}
+
+// writeRSSFeed writes items of store, whose storefront is at link, as an
+// RSS product feed, spelling availability as the reading platform expects
+func writeRSSFeed(w io.Writer, store *models.Store, link string, items []catalogFeedItem, availability func(inStock bool) string) error {
+	description := store.Bio
+	if description == "" {
+		description = store.Name
+	}
+
+	feed := rssFeed{
+		Version:   "2.0",
+		Namespace: "http://base.google.com/ns/1.0",
+		Channel: rssChannel{
+			Title:       store.Name,
+			Link:        link,
+			Description: description,
+			Items:       make([]rssItem, len(items)),
+		},
+	}
+	for i, item := range items {
+		feed.Channel.Items[i] = rssItem{
+			ID:                   item.ID,
+			Title:                item.Title,
+			Description:          item.Description,
+			Link:                 item.Link,
+			ImageLink:            item.ImageLink,
+			AdditionalImageLinks: item.AdditionalImageLinks,
+			Availability:         availability(item.InStock),
+			Condition:            "new",
+			Price:                item.Price,
+			SalePrice:            item.SalePrice,
+			SaleEffectiveDate:    item.SaleEffectiveDate,
+			Brand:                item.Brand,
+			ProductType:          item.ProductType,
+			IdentifierExists:     "no",
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func metaAvailability(inStock bool) string {
+	if inStock {
+		return "in stock"
+	}
+	return "out of stock"
+}
+
+func googleAvailability(inStock bool) string {
+	if inStock {
+		return "in_stock"
+	}
+	return "out_of_stock"
+}
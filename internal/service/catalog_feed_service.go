@@ -0,0 +1,140 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// catalogFeedTTL bounds how long a generated feed is served. Product events
+// clear a store's feeds sooner; the TTL covers changes that send none, such
+// as new images, and sales or vacations starting on their own.
+const catalogFeedTTL = 15 * time.Minute
+
+// CatalogFeedProducts lists a store's active products and their images
+type CatalogFeedProducts interface {
+	GetActiveProductsByStoreID(ctx context.Context, storeID string) ([]*models.Product, error)
+	GetProductImages(ctx context.Context, productID string) ([]*models.ProductImage, error)
+}
+
+// CatalogFeed is a generated product feed of a store
+type CatalogFeed struct {
+	StoreSlug   string
+	ContentType string
+	Body        []byte
+	GeneratedAt time.Time
+}
+
+type cachedCatalogFeed struct {
+	feed           *CatalogFeed
+	storeUpdatedAt time.Time
+}
+
+// CatalogFeedService generates the product feeds that Meta Commerce and
+// Google Merchant Center fetch, keeping each in memory until the store's
+// products change. It is an EventPublisher so product events reach it.
+type CatalogFeedService struct {
+	products     CatalogFeedProducts
+	storeService *StoreService
+
+	mu    sync.Mutex
+	cache map[string]*cachedCatalogFeed
+}
+
+func NewCatalogFeedService(products CatalogFeedProducts, storeService *StoreService) *CatalogFeedService {
+	return &CatalogFeedService{
+		products:     products,
+		storeService: storeService,
+		cache:        make(map[string]*cachedCatalogFeed),
+	}
+}
+
+// GetFeed returns a store's feed in format, generating it when no fresh copy
+// is cached. Old slugs find the store; callers compare StoreSlug to
+// redirect to the current one.
+func (s *CatalogFeedService) GetFeed(ctx context.Context, slug, format string) (*CatalogFeed, error) {
+	if format != CatalogFeedMetaCSV && format != CatalogFeedMetaXML && format != CatalogFeedGoogle {
+		return nil, fmt.Errorf("%w: unknown feed format %q", utils.ErrInvalidOperation, format)
+	}
+
+	store, err := s.storeService.GetStoreBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	key := store.ID + "/" + format
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && cached.storeUpdatedAt.Equal(store.UpdatedAt) && now.Sub(cached.feed.GeneratedAt) < catalogFeedTTL {
+		return cached.feed, nil
+	}
+
+	feed, err := s.generate(ctx, store, format, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = &cachedCatalogFeed{feed: feed, storeUpdatedAt: store.UpdatedAt}
+	s.mu.Unlock()
+
+	return feed, nil
+}
+
+// Publish drops a store's cached feeds when one of its products changes
+func (s *CatalogFeedService) Publish(ctx context.Context, storeID, eventType string, data any) {
+	if !strings.HasPrefix(eventType, "product.") {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, format := range []string{CatalogFeedMetaCSV, CatalogFeedMetaXML, CatalogFeedGoogle} {
+		delete(s.cache, storeID+"/"+format)
+	}
+}
+
+func (s *CatalogFeedService) generate(ctx context.Context, store *models.Store, format string, now time.Time) (*CatalogFeed, error) {
+	products, err := s.products.GetActiveProductsByStoreID(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []catalogFeedItem{}
+	for _, product := range products {
+		images, err := s.products.GetProductImages(ctx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		if item, ok := newCatalogFeedItem(store, product, s.storeService.ProductURL(store, product.Slug), images, now); ok {
+			items = append(items, item)
+		}
+	}
+
+	feed := &CatalogFeed{StoreSlug: store.Slug, ContentType: "application/xml; charset=utf-8", GeneratedAt: now}
+	var buf bytes.Buffer
+	switch format {
+	case CatalogFeedMetaCSV:
+		feed.ContentType = "text/csv; charset=utf-8"
+		err = writeMetaFeedCSV(&buf, items)
+	case CatalogFeedMetaXML:
+		err = writeRSSFeed(&buf, store, s.storeService.StoreURL(store), items, metaAvailability)
+	case CatalogFeedGoogle:
+		err = writeRSSFeed(&buf, store, s.storeService.StoreURL(store), items, googleAvailability)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s feed: %w", format, err)
+	}
+
+	feed.Body = buf.Bytes()
+	return feed, nil
+}
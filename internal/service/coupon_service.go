@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+type CouponRepository interface {
+	CreateCoupon(ctx context.Context, coupon *models.Coupon) (*models.Coupon, error)
+	GetByID(ctx context.Context, couponID string) (*models.Coupon, error)
+	GetByCode(ctx context.Context, storeID, code string) (*models.Coupon, error)
+	ListByStore(ctx context.Context, storeID string) ([]*models.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *models.Coupon) (*models.Coupon, error)
+	DeleteCoupon(ctx context.Context, couponID string) error
+}
+
+type CouponService struct {
+	couponRepo   CouponRepository
+	products     ProductLookup
+	storeService *StoreService
+}
+
+func NewCouponService(couponRepo CouponRepository, products ProductLookup, storeService *StoreService) *CouponService {
+	return &CouponService{
+		couponRepo:   couponRepo,
+		products:     products,
+		storeService: storeService,
+	}
+}
+
+// CreateCoupon adds a coupon to a store owned by ownerID
+func (s *CouponService) CreateCoupon(ctx context.Context, ownerID string, req dto.CreateCouponRequest) (*dto.CouponResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon := &models.Coupon{
+		StoreID:      store.ID,
+		Code:         normaliseCouponCode(req.Code),
+		DiscountType: req.DiscountType,
+		MaxUses:      req.MaxUses,
+		StartsAt:     parseOptionalTime(req.StartsAt),
+		ExpiresAt:    parseOptionalTime(req.ExpiresAt),
+		IsActive:     true,
+	}
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+
+	currency := models.CurrencyOf(store.Currency)
+	if err := setCouponValue(coupon, currency, req.Value); err != nil {
+		return nil, err
+	}
+	if req.MinSpend != "" {
+		if coupon.MinSpend, err = parseAmount(currency, req.MinSpend, "min_spend"); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateCouponWindow(coupon); err != nil {
+		return nil, err
+	}
+
+	created, err := s.couponRepo.CreateCoupon(ctx, coupon)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapCouponToResponse(created, currency), nil
+}
+
+// ListCoupons returns the coupons of a store owned by ownerID
+func (s *CouponService) ListCoupons(ctx context.Context, ownerID, storeID string) ([]*dto.CouponResponse, error) {
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	coupons, err := s.couponRepo.ListByStore(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := models.CurrencyOf(store.Currency)
+	responses := make([]*dto.CouponResponse, len(coupons))
+	for i, coupon := range coupons {
+		responses[i] = mapCouponToResponse(coupon, currency)
+	}
+	return responses, nil
+}
+
+// UpdateCoupon changes a coupon of a store owned by ownerID
+func (s *CouponService) UpdateCoupon(ctx context.Context, ownerID, couponID string, req dto.UpdateCouponRequest) (*dto.CouponResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	coupon, store, err := s.getOwnedCoupon(ctx, ownerID, couponID)
+	if err != nil {
+		return nil, err
+	}
+	currency := models.CurrencyOf(store.Currency)
+
+	if req.Code != nil {
+		coupon.Code = normaliseCouponCode(*req.Code)
+	}
+	if req.DiscountType != nil && *req.DiscountType != coupon.DiscountType {
+		// The old value means something else under the new type
+		if req.Value == nil {
+			return nil, fmt.Errorf("%w: value is required when changing discount_type", utils.ErrInvalidOperation)
+		}
+		coupon.DiscountType = *req.DiscountType
+	}
+	if req.Value != nil {
+		if err := setCouponValue(coupon, currency, *req.Value); err != nil {
+			return nil, err
+		}
+	}
+	if req.MinSpend != nil {
+		if coupon.MinSpend, err = parseAmount(currency, *req.MinSpend, "min_spend"); err != nil {
+			return nil, err
+		}
+	}
+	if req.MaxUses != nil {
+		coupon.MaxUses = req.MaxUses
+		if *req.MaxUses == 0 {
+			coupon.MaxUses = nil
+		}
+	}
+	if req.StartsAt != nil {
+		coupon.StartsAt = parseOptionalTime(*req.StartsAt)
+	}
+	if req.ExpiresAt != nil {
+		coupon.ExpiresAt = parseOptionalTime(*req.ExpiresAt)
+	}
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+
+	if err := validateCouponWindow(coupon); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.couponRepo.UpdateCoupon(ctx, coupon)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapCouponToResponse(updated, currency), nil
+}
+
+// DeleteCoupon removes a coupon of a store owned by ownerID
+func (s *CouponService) DeleteCoupon(ctx context.Context, ownerID, couponID string) error {
+	coupon, _, err := s.getOwnedCoupon(ctx, ownerID, couponID)
+	if err != nil {
+		return err
+	}
+	return s.couponRepo.DeleteCoupon(ctx, coupon.ID)
+}
+
+// getOwnedCoupon loads a coupon and its store, reporting coupons of other
+// owners' stores as not found
+func (s *CouponService) getOwnedCoupon(ctx context.Context, ownerID, couponID string) (*models.Coupon, *models.Store, error) {
+	coupon, err := s.couponRepo.GetByID(ctx, couponID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, coupon.StoreID)
+	if err != nil {
+		if errors.Is(err, utils.ErrStoreNotFound) {
+			return nil, nil, utils.ErrCouponNotFound
+		}
+		return nil, nil, err
+	}
+
+	return coupon, store, nil
+}
+
+// setCouponValue sets the percentage or amount off according to the
+// coupon's discount type
+func setCouponValue(coupon *models.Coupon, currency models.Currency, value dto.Amount) error {
+	if coupon.DiscountType == models.CouponTypePercentage {
+		percent, err := strconv.ParseFloat(string(value), 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return fmt.Errorf("%w: a percentage value must be greater than 0 and at most 100", utils.ErrInvalidOperation)
+		}
+		coupon.PercentOff = percent
+		coupon.AmountOff = 0
+		return nil
+	}
+
+	amount, err := parseAmount(currency, value, "value")
+	if err != nil {
+		return err
+	}
+	coupon.PercentOff = 0
+	coupon.AmountOff = amount
+	return nil
+}
+
+// Quote prices a cart at current sale prices and applies an optional
+// coupon. Coupon uses are only counted when an order is placed, so
+// storefronts may quote as often as the buyer edits the cart.
+func (s *CouponService) Quote(ctx context.Context, req dto.QuoteRequest) (*dto.QuoteResponse, error) {
+	store, err := s.storeService.GetStoreByID(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	currency := models.CurrencyOf(store.Currency)
+	response := &dto.QuoteResponse{
+		StoreID:  store.ID,
+		Currency: currency.Code,
+		Items:    make([]*dto.QuoteLineResponse, len(req.Items)),
+	}
+
+	var subtotal, regularTotal models.Money
+	for i, item := range req.Items {
+		product, err := s.products.GetProductByID(ctx, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product.StoreID != store.ID || !product.IsActive {
+			return nil, fmt.Errorf("%w: product %s is not available in this store", utils.ErrInvalidOperation, item.ProductID)
+		}
+
+		unitPrice := effectivePrice(product, now)
+		lineTotal := unitPrice * models.Money(item.Quantity)
+		response.Items[i] = &dto.QuoteLineResponse{
+			ProductID:        product.ID,
+			Name:             product.Name,
+			Quantity:         item.Quantity,
+			UnitPrice:        mapMoney(currency, unitPrice),
+			RegularUnitPrice: mapMoney(currency, product.Price),
+			OnSale:           unitPrice < product.Price,
+			LineTotal:        mapMoney(currency, lineTotal),
+		}
+		subtotal += lineTotal
+		regularTotal += product.Price * models.Money(item.Quantity)
+	}
+
+	var discount models.Money
+	if code := normaliseCouponCode(req.CouponCode); code != "" {
+		coupon, err := s.couponRepo.GetByCode(ctx, store.ID, code)
+		if err != nil {
+			if errors.Is(err, utils.ErrCouponNotFound) {
+				return nil, fmt.Errorf("%w: coupon %s is not valid for this store", utils.ErrInvalidOperation, code)
+			}
+			return nil, err
+		}
+
+		discount, err = couponDiscount(coupon, currency, subtotal, now)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Coupon = &dto.AppliedCouponResponse{
+			Code:         coupon.Code,
+			DiscountType: coupon.DiscountType,
+			PercentOff:   coupon.PercentOff,
+		}
+		if coupon.DiscountType == models.CouponTypeFixed {
+			response.Coupon.AmountOff = mapOptionalMoney(currency, &coupon.AmountOff)
+		}
+	}
+
+	response.SaleSavings = mapMoney(currency, regularTotal-subtotal)
+	response.Subtotal = mapMoney(currency, subtotal)
+	response.Discount = mapMoney(currency, discount)
+	response.Total = mapMoney(currency, subtotal-discount)
+	response.OrderSummary = orderSummary(store, response)
+	response.WhatsappURL = whatsappURL(store.WhatsappNumber, response.OrderSummary)
+
+	return response, nil
+}
+
+// couponDiscount checks that a coupon can be used on a cart worth subtotal
+// at now and returns the amount it takes off. The discount never exceeds
+// the subtotal.
+func couponDiscount(coupon *models.Coupon, currency models.Currency, subtotal models.Money, now time.Time) (models.Money, error) {
+	switch {
+	case !coupon.IsActive:
+		return 0, fmt.Errorf("%w: coupon %s is not active", utils.ErrInvalidOperation, coupon.Code)
+	case coupon.StartsAt != nil && now.Before(*coupon.StartsAt):
+		return 0, fmt.Errorf("%w: coupon %s is not valid yet", utils.ErrInvalidOperation, coupon.Code)
+	case coupon.ExpiresAt != nil && !now.Before(*coupon.ExpiresAt):
+		return 0, fmt.Errorf("%w: coupon %s has expired", utils.ErrInvalidOperation, coupon.Code)
+	case coupon.MaxUses != nil && coupon.UsedCount >= *coupon.MaxUses:
+		return 0, fmt.Errorf("%w: coupon %s has been used up", utils.ErrInvalidOperation, coupon.Code)
+	case subtotal < coupon.MinSpend:
+		return 0, fmt.Errorf("%w: coupon %s needs a minimum spend of %s", utils.ErrInvalidOperation, coupon.Code, currency.Format(coupon.MinSpend))
+	}
+
+	discount := coupon.AmountOff
+	if coupon.DiscountType == models.CouponTypePercentage {
+		discount = subtotal.Percent(coupon.PercentOff)
+	}
+	if discount > subtotal {
+		discount = subtotal
+	}
+	return discount, nil
+}
+
+// orderSummary renders a quote as the plain-text order a buyer sends to
+// the store on WhatsApp
+func orderSummary(store *models.Store, quote *dto.QuoteResponse) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Hello %s, I'd like to order:\n", store.Name)
+	for _, line := range quote.Items {
+		fmt.Fprintf(&b, "- %d x %s @ %s = %s", line.Quantity, line.Name, line.UnitPrice.Formatted, line.LineTotal.Formatted)
+		if line.OnSale {
+			fmt.Fprintf(&b, " (was %s)", line.RegularUnitPrice.Formatted)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "Subtotal: %s\n", quote.Subtotal.Formatted)
+	if quote.Coupon != nil {
+		fmt.Fprintf(&b, "Coupon %s: -%s\n", quote.Coupon.Code, quote.Discount.Formatted)
+	}
+	fmt.Fprintf(&b, "Total: %s", quote.Total.Formatted)
+
+	return b.String()
+}
+
+// whatsappURL returns a wa.me link that opens a chat with number prefilled
+// with text, or "" when the store has no WhatsApp number
+func whatsappURL(number, text string) string {
+	digits := nonDigits.ReplaceAllString(number, "")
+	if digits == "" {
+		return ""
+	}
+	return "https://wa.me/" + digits + "?text=" + url.QueryEscape(text)
+}
+
+func validateCouponWindow(coupon *models.Coupon) error {
+	if coupon.StartsAt != nil && coupon.ExpiresAt != nil && !coupon.ExpiresAt.After(*coupon.StartsAt) {
+		return fmt.Errorf("%w: expires_at must be after starts_at", utils.ErrInvalidOperation)
+	}
+	return nil
+}
+
+// normaliseCouponCode makes codes case-insensitive for buyers
+func normaliseCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func mapCouponToResponse(coupon *models.Coupon, currency models.Currency) *dto.CouponResponse {
+	response := &dto.CouponResponse{
+		ID:           coupon.ID,
+		StoreID:      coupon.StoreID,
+		Code:         coupon.Code,
+		DiscountType: coupon.DiscountType,
+		PercentOff:   coupon.PercentOff,
+		MinSpend:     mapMoney(currency, coupon.MinSpend),
+		MaxUses:      coupon.MaxUses,
+		UsedCount:    coupon.UsedCount,
+		IsActive:     coupon.IsActive,
+		CreatedAt:    coupon.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    coupon.UpdatedAt.Format(time.RFC3339),
+	}
+	if coupon.DiscountType == models.CouponTypeFixed {
+		response.AmountOff = mapOptionalMoney(currency, &coupon.AmountOff)
+	}
+	if coupon.StartsAt != nil {
+		response.StartsAt = coupon.StartsAt.Format(time.RFC3339)
+	}
+	if coupon.ExpiresAt != nil {
+		response.ExpiresAt = coupon.ExpiresAt.Format(time.RFC3339)
+	}
+	return response
+}
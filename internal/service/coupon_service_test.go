@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+func TestCouponDiscount(t *testing.T) {
+	ngn := models.CurrencyOf("NGN")
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	yesterday, tomorrow := now.Add(-24*time.Hour), now.Add(24*time.Hour)
+	maxUses := 5
+
+	tests := []struct {
+		name     string
+		coupon   models.Coupon
+		inactive bool
+		subtotal models.Money
+		want     models.Money
+		wantErr  string
+	}{
+		{
+			name:     "fixed",
+			coupon:   models.Coupon{DiscountType: models.CouponTypeFixed, AmountOff: 50000},
+			subtotal: 200000,
+			want:     50000,
+		},
+		{
+			name:     "fixed capped at the subtotal",
+			coupon:   models.Coupon{DiscountType: models.CouponTypeFixed, AmountOff: 50000},
+			subtotal: 30000,
+			want:     30000,
+		},
+		{
+			name:     "percentage rounds to the minor unit",
+			coupon:   models.Coupon{DiscountType: models.CouponTypePercentage, PercentOff: 12.5},
+			subtotal: 999,
+			want:     125,
+		},
+		{
+			name:     "full percentage",
+			coupon:   models.Coupon{DiscountType: models.CouponTypePercentage, PercentOff: 100},
+			subtotal: 150050,
+			want:     150050,
+		},
+		{
+			name:     "minimum spend met",
+			coupon:   models.Coupon{DiscountType: models.CouponTypeFixed, AmountOff: 1000, MinSpend: 100000},
+			subtotal: 100000,
+			want:     1000,
+		},
+		{
+			name:     "minimum spend not met",
+			coupon:   models.Coupon{DiscountType: models.CouponTypeFixed, AmountOff: 1000, MinSpend: 100000},
+			subtotal: 99999,
+			wantErr:  "needs a minimum spend of ₦1,000.00",
+		},
+		{
+			name:     "inactive",
+			inactive: true,
+			wantErr:  "is not active",
+		},
+		{
+			name:    "not started",
+			coupon:  models.Coupon{StartsAt: &tomorrow},
+			wantErr: "is not valid yet",
+		},
+		{
+			name:    "expired",
+			coupon:  models.Coupon{ExpiresAt: &yesterday},
+			wantErr: "has expired",
+		},
+		{
+			name:    "expires now",
+			coupon:  models.Coupon{ExpiresAt: &now},
+			wantErr: "has expired",
+		},
+		{
+			name:    "used up",
+			coupon:  models.Coupon{MaxUses: &maxUses, UsedCount: 5},
+			wantErr: "has been used up",
+		},
+		{
+			name:     "within its window and limit",
+			coupon:   models.Coupon{DiscountType: models.CouponTypeFixed, AmountOff: 100, StartsAt: &yesterday, ExpiresAt: &tomorrow, MaxUses: &maxUses, UsedCount: 4},
+			subtotal: 1000,
+			want:     100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := tt.coupon
+			coupon.Code = "SAVE10"
+			coupon.IsActive = !tt.inactive
+
+			got, err := couponDiscount(&coupon, ngn, tt.subtotal, now)
+			if tt.wantErr != "" {
+				if !errors.Is(err, utils.ErrInvalidOperation) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("couponDiscount() = %v, want an invalid operation containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("couponDiscount() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetCouponValue(t *testing.T) {
+	ngn := models.CurrencyOf("NGN")
+
+	tests := []struct {
+		discountType string
+		value        dto.Amount
+		wantPercent  float64
+		wantAmount   models.Money
+		wantErr      bool
+	}{
+		{discountType: models.CouponTypePercentage, value: "12.5", wantPercent: 12.5},
+		{discountType: models.CouponTypePercentage, value: "100", wantPercent: 100},
+		{discountType: models.CouponTypePercentage, value: "0", wantErr: true},
+		{discountType: models.CouponTypePercentage, value: "100.5", wantErr: true},
+		{discountType: models.CouponTypeFixed, value: "500.50", wantAmount: 50050},
+		{discountType: models.CouponTypeFixed, value: "500.505", wantErr: true},
+		{discountType: models.CouponTypeFixed, value: "five", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		// Switching type clears the other value
+		coupon := models.Coupon{DiscountType: tt.discountType, PercentOff: 50, AmountOff: 999}
+		err := setCouponValue(&coupon, ngn, tt.value)
+		if tt.wantErr {
+			if !errors.Is(err, utils.ErrInvalidOperation) {
+				t.Errorf("setCouponValue(%s, %q) = %v, want an invalid operation", tt.discountType, tt.value, err)
+			}
+			continue
+		}
+		if err != nil || coupon.PercentOff != tt.wantPercent || coupon.AmountOff != tt.wantAmount {
+			t.Errorf("setCouponValue(%s, %q) = %v, percent %v, amount %d", tt.discountType, tt.value, err, coupon.PercentOff, coupon.AmountOff)
+		}
+	}
+}
+
+func TestEffectivePrice(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+	money := func(m models.Money) *models.Money { return &m }
+
+	tests := []struct {
+		name    string
+		product models.Product
+		want    models.Money
+	}{
+		{name: "no sale", product: models.Product{Price: 1000}, want: 1000},
+		{name: "open-ended sale", product: models.Product{Price: 1000, SalePrice: money(800)}, want: 800},
+		{name: "sale not started", product: models.Product{Price: 1000, SalePrice: money(800), SaleStartsAt: &later}, want: 1000},
+		{name: "sale running", product: models.Product{Price: 1000, SalePrice: money(800), SaleStartsAt: &earlier, SaleEndsAt: &later}, want: 800},
+		{name: "sale ended", product: models.Product{Price: 1000, SalePrice: money(800), SaleEndsAt: &now}, want: 1000},
+		{name: "sale price not lower", product: models.Product{Price: 1000, SalePrice: money(1000)}, want: 1000},
+		{name: "zero sale price", product: models.Product{Price: 1000, SalePrice: money(0)}, want: 1000},
+	}
+
+	for _, tt := range tests {
+		if got := effectivePrice(&tt.product, now); got != tt.want {
+			t.Errorf("%s: effectivePrice() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWhatsappURL(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+	}{
+		{number: "+234 801 234 5678", want: "https://wa.me/2348012345678?text=Hi+there%21"},
+		{number: "", want: ""},
+		{number: "n/a", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := whatsappURL(tt.number, "Hi there!"); got != tt.want {
+			t.Errorf("whatsappURL(%q) = %q, want %q", tt.number, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+)
+
+const (
+	healthStatusOK       = "ok"
+	healthStatusFailing  = "failing"
+	healthStatusDraining = "draining"
+)
+
+// HealthChecker checks one dependency the service needs to handle
+// requests, returning an error when it can't be used
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckFunc adapts a function, e.g. a connection pool's Ping, to a
+// HealthChecker
+type HealthCheckFunc func(ctx context.Context) error
+
+func (f HealthCheckFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+type healthCheck struct {
+	name    string
+	checker HealthChecker
+}
+
+// HealthService answers liveness and readiness probes. The process is live
+// while it can serve requests at all, and ready while every registered
+// check passes and it isn't shutting down.
+type HealthService struct {
+	timeout  time.Duration
+	checks   []healthCheck
+	draining atomic.Bool
+	logger   *slog.Logger
+}
+
+// NewHealthService returns a service giving each check up to timeout to
+// pass
+func NewHealthService(timeout time.Duration, logger *slog.Logger) *HealthService {
+	return &HealthService{timeout: timeout, logger: logger}
+}
+
+// AddCheck registers a readiness check. Checks are added while wiring up
+// the server, before it serves requests.
+func (s *HealthService) AddCheck(name string, checker HealthChecker) {
+	s.checks = append(s.checks, healthCheck{name: name, checker: checker})
+}
+
+// StartDraining fails readiness from now on, so load balancers stop
+// routing new requests here before the server shuts down
+func (s *HealthService) StartDraining() {
+	s.draining.Store(true)
+}
+
+func (s *HealthService) Liveness() *dto.HealthResponse {
+	return &dto.HealthResponse{Status: healthStatusOK}
+}
+
+// Readiness runs every check concurrently and reports whether all passed.
+// Check errors are logged rather than returned, as probes are
+// unauthenticated.
+func (s *HealthService) Readiness(ctx context.Context) (*dto.HealthResponse, bool) {
+	results := make(map[string]dto.HealthCheckResult, len(s.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := s.runCheck(ctx, check)
+			mu.Lock()
+			results[check.name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	resp := &dto.HealthResponse{Status: healthStatusOK, Checks: results}
+	for _, result := range results {
+		if result.Status != healthStatusOK {
+			resp.Status = healthStatusFailing
+		}
+	}
+	if s.draining.Load() {
+		resp.Status = healthStatusDraining
+	}
+	return resp, resp.Status == healthStatusOK
+}
+
+// runCheck runs one check within the timeout. A checker that ignores its
+// context is abandoned once the timeout passes.
+func (s *HealthService) runCheck(ctx context.Context, check healthCheck) dto.HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.checker.CheckHealth(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := dto.HealthCheckResult{
+		Status:     healthStatusOK,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = healthStatusFailing
+		result.Error = "check failed"
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Error = "timed out"
+		}
+		s.logger.WarnContext(ctx, "health check failed", "check", check.name, "error", err)
+	}
+	return result
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *models.Order) (*models.Order, error)
+	GetByID(ctx context.Context, orderID string) (*models.Order, error)
+	GetByReference(ctx context.Context, reference string) (*models.Order, error)
+	ListByStore(ctx context.Context, storeID, paymentStatus string, limit, offset int) ([]*models.Order, error)
+}
+
+type OrderService struct {
+	orderRepo     OrderRepository
+	paymentRepo   PaymentRepository
+	couponService *CouponService
+	storeService  *StoreService
+	access        StoreAuthorizer
+	events        EventPublisher
+}
+
+func NewOrderService(orderRepo OrderRepository, paymentRepo PaymentRepository, couponService *CouponService, storeService *StoreService, access StoreAuthorizer, events EventPublisher) *OrderService {
+	return &OrderService{
+		orderRepo:     orderRepo,
+		paymentRepo:   paymentRepo,
+		couponService: couponService,
+		storeService:  storeService,
+		access:        access,
+		events:        events,
+	}
+}
+
+// PlaceOrder prices a cart like a quote and saves it as an unpaid order,
+// redeeming any coupon along with it. The buyer can then pay online or
+// send the returned summary to the store on WhatsApp.
+func (s *OrderService) PlaceOrder(ctx context.Context, req dto.PlaceOrderRequest) (*dto.OrderResponse, error) {
+	store, err := s.storeService.GetStoreByID(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if !MapStoreToResponse(store).AcceptingOrders {
+		return nil, fmt.Errorf("%w: the store is not taking orders right now", utils.ErrInvalidOperation)
+	}
+
+	quote, err := s.couponService.Quote(ctx, req.QuoteRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := newOrderReference()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate order reference: %w", err)
+	}
+
+	order := &models.Order{
+		StoreID:       store.ID,
+		Reference:     reference,
+		CustomerName:  strings.TrimSpace(req.CustomerName),
+		CustomerPhone: strings.TrimSpace(req.CustomerPhone),
+		CustomerEmail: strings.TrimSpace(req.CustomerEmail),
+		Items:         make([]models.OrderItem, len(quote.Items)),
+		Currency:      quote.Currency,
+		Subtotal:      models.Money(quote.Subtotal.Amount),
+		Discount:      models.Money(quote.Discount.Amount),
+		Total:         models.Money(quote.Total.Amount),
+	}
+	for i, line := range quote.Items {
+		order.Items[i] = models.OrderItem{
+			ProductID: line.ProductID,
+			Name:      line.Name,
+			Quantity:  line.Quantity,
+			UnitPrice: models.Money(line.UnitPrice.Amount),
+			LineTotal: models.Money(line.LineTotal.Amount),
+		}
+	}
+	if quote.Coupon != nil {
+		order.CouponCode = quote.Coupon.Code
+	}
+
+	created, err := s.orderRepo.CreateOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, created.StoreID, models.WebhookEventOrderCreated, mapOrderToResponse(created))
+
+	response := mapOrderToResponse(created)
+	response.OrderSummary = quote.OrderSummary + "\nOrder reference: " + created.Reference
+	response.WhatsappURL = whatsappURL(store.WhatsappNumber, response.OrderSummary)
+	return response, nil
+}
+
+// GetOrder returns an order by the reference given to the buyer
+func (s *OrderService) GetOrder(ctx context.Context, reference string) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.GetByReference(ctx, strings.ToUpper(strings.TrimSpace(reference)))
+	if err != nil {
+		return nil, err
+	}
+	return mapOrderToResponse(order), nil
+}
+
+// ListStoreOrders returns a page of a store's orders for its owner or order
+// staff, optionally only those with the given payment status
+func (s *OrderService) ListStoreOrders(ctx context.Context, userID, storeID, paymentStatus string, page, pageSize int) ([]*dto.OrderResponse, error) {
+	switch paymentStatus {
+	case "", models.OrderPaymentUnpaid, models.OrderPaymentPending, models.OrderPaymentPaid, models.OrderPaymentFailed:
+	default:
+		return nil, fmt.Errorf("%w: payment_status must be unpaid, pending, paid or failed", utils.ErrInvalidOperation)
+	}
+
+	if storeID == "" {
+		defaultStoreID, err := s.access.DefaultStoreID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		storeID = defaultStoreID
+	}
+
+	if err := s.requireOrderAccess(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalisePage(page, pageSize)
+	orders, err := s.orderRepo.ListByStore(ctx, storeID, paymentStatus, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.OrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = mapOrderToResponse(order)
+	}
+	return responses, nil
+}
+
+// GetStoreOrder returns one of a store's orders with all its payment
+// attempts for the store's owner or order staff
+func (s *OrderService) GetStoreOrder(ctx context.Context, userID, orderID string) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requireOrderAccess(ctx, order.StoreID, userID); err != nil {
+		return nil, err
+	}
+
+	payments, err := s.paymentRepo.ListByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapOrderToResponse(order)
+	response.Payments = make([]*dto.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		response.Payments[i] = mapPaymentToResponse(payment, order.Reference)
+	}
+	return response, nil
+}
+
+func (s *OrderService) requireOrderAccess(ctx context.Context, storeID, userID string) error {
+	allowed, err := s.access.CanAccessStore(ctx, storeID, userID, PermissionManageOrders)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return utils.ErrForbidden
+	}
+	return nil
+}
+
+// newOrderReference returns a short reference buyers can quote to the
+// store, e.g. VH-3F9A1C07B2
+func newOrderReference() (string, error) {
+	token, err := utils.GenerateToken(5)
+	if err != nil {
+		return "", err
+	}
+	return "VH-" + strings.ToUpper(token), nil
+}
+
+func mapOrderToResponse(order *models.Order) *dto.OrderResponse {
+	currency := models.CurrencyOf(order.Currency)
+	response := &dto.OrderResponse{
+		ID:               order.ID,
+		StoreID:          order.StoreID,
+		Reference:        order.Reference,
+		CustomerName:     order.CustomerName,
+		CustomerPhone:    order.CustomerPhone,
+		CustomerEmail:    order.CustomerEmail,
+		Items:            make([]*dto.OrderItemResponse, len(order.Items)),
+		Currency:         currency.Code,
+		Subtotal:         mapMoney(currency, order.Subtotal),
+		Discount:         mapMoney(currency, order.Discount),
+		Total:            mapMoney(currency, order.Total),
+		CouponCode:       order.CouponCode,
+		PaymentStatus:    order.PaymentStatus,
+		PaymentReference: order.PaymentReference,
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
+	}
+	for i, item := range order.Items {
+		response.Items[i] = &dto.OrderItemResponse{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: mapMoney(currency, item.UnitPrice),
+			LineTotal: mapMoney(currency, item.LineTotal),
+		}
+	}
+	if order.PaidAt != nil {
+		response.PaidAt = order.PaidAt.Format(time.RFC3339)
+	}
+	return response
+}
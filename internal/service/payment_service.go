@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/payment"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type PaymentRepository interface {
+	CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error)
+	GetByReference(ctx context.Context, reference string) (*models.Payment, error)
+	ListByOrder(ctx context.Context, orderID string) ([]*models.Payment, error)
+	RecordResult(ctx context.Context, provider, eventID, reference, status string, paidAt *time.Time) (bool, error)
+}
+
+type PaymentService struct {
+	provider    payment.Provider
+	paymentRepo PaymentRepository
+	orderRepo   OrderRepository
+	// callbackURL is where buyers return after checkout unless the
+	// storefront asks for another page
+	callbackURL string
+	events      EventPublisher
+	logger      *slog.Logger
+}
+
+func NewPaymentService(provider payment.Provider, paymentRepo PaymentRepository, orderRepo OrderRepository, callbackURL string, events EventPublisher, logger *slog.Logger) *PaymentService {
+	return &PaymentService{
+		provider:    provider,
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		callbackURL: callbackURL,
+		events:      events,
+		logger:      logger,
+	}
+}
+
+// InitializePayment starts an online payment of an order's total and
+// returns the provider checkout to send the buyer to. Each call is a new
+// attempt with its own reference.
+func (s *PaymentService) InitializePayment(ctx context.Context, orderReference string, req dto.InitializePaymentRequest) (*dto.InitializePaymentResponse, error) {
+	order, err := s.orderRepo.GetByReference(ctx, strings.ToUpper(strings.TrimSpace(orderReference)))
+	if err != nil {
+		return nil, err
+	}
+	if order.PaymentStatus == models.OrderPaymentPaid {
+		return nil, fmt.Errorf("%w: order %s has already been paid", utils.ErrInvalidOperation, order.Reference)
+	}
+	if order.Total <= 0 {
+		return nil, fmt.Errorf("%w: order %s has nothing to pay", utils.ErrInvalidOperation, order.Reference)
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		email = order.CustomerEmail
+	}
+	if email == "" {
+		return nil, fmt.Errorf("%w: an email is required to pay online", utils.ErrInvalidOperation)
+	}
+
+	callbackURL := req.CallbackURL
+	if callbackURL == "" {
+		callbackURL = s.callbackURL
+	}
+
+	token, err := utils.GenerateToken(4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payment reference: %w", err)
+	}
+	reference := order.Reference + "-" + strings.ToUpper(token)
+
+	checkout, err := s.provider.Initialize(ctx, payment.InitializeRequest{
+		Reference:   reference,
+		Email:       email,
+		Amount:      int64(order.Total),
+		Currency:    order.Currency,
+		CallbackURL: callbackURL,
+		Metadata: map[string]string{
+			"order_reference": order.Reference,
+			"store_id":        order.StoreID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start payment: %w", err)
+	}
+
+	created, err := s.paymentRepo.CreatePayment(ctx, &models.Payment{
+		OrderID:          order.ID,
+		Provider:         s.provider.Name(),
+		Reference:        reference,
+		Amount:           order.Total,
+		Currency:         order.Currency,
+		AuthorizationURL: checkout.AuthorizationURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.InitializePaymentResponse{
+		OrderReference:   order.Reference,
+		Reference:        created.Reference,
+		Provider:         created.Provider,
+		AuthorizationURL: created.AuthorizationURL,
+		AccessCode:       checkout.AccessCode,
+		Amount:           mapMoney(models.CurrencyOf(created.Currency), created.Amount),
+	}, nil
+}
+
+// VerifyPayment asks the provider for the outcome of a pending payment,
+// records it and returns the payment. Storefronts call it when the buyer
+// returns from checkout; webhooks deliver the same result independently.
+func (s *PaymentService) VerifyPayment(ctx context.Context, reference string) (*dto.PaymentResponse, error) {
+	record, err := s.paymentRepo.GetByReference(ctx, strings.TrimSpace(reference))
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Status == models.PaymentStatusPending && record.Provider == s.provider.Name() {
+		transaction, err := s.provider.Verify(ctx, record.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify payment: %w", err)
+		}
+		if err := s.applyTransaction(ctx, record, "", transaction); err != nil {
+			return nil, err
+		}
+		if record, err = s.paymentRepo.GetByReference(ctx, record.Reference); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, record.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPaymentToResponse(record, order.Reference), nil
+}
+
+// HandleWebhook authenticates and applies a webhook from the named
+// provider. Redelivered events and events for unknown payments are
+// acknowledged without changes so the provider stops retrying.
+func (s *PaymentService) HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) error {
+	if providerName != s.provider.Name() {
+		return fmt.Errorf("%w: payment provider %q is not enabled", utils.ErrInvalidOperation, providerName)
+	}
+
+	event, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			return fmt.Errorf("%w: %v", utils.ErrUnauthorized, err)
+		}
+		return fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	if event.Transaction.Reference == "" {
+		return nil
+	}
+
+	record, err := s.paymentRepo.GetByReference(ctx, event.Transaction.Reference)
+	if err != nil {
+		if errors.Is(err, utils.ErrPaymentNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return s.applyTransaction(ctx, record, event.ID, &event.Transaction)
+}
+
+// applyTransaction records a provider's final result for a payment and
+// announces orders that became paid. A success for a different amount or
+// currency than was charged is treated as a failure.
+func (s *PaymentService) applyTransaction(ctx context.Context, record *models.Payment, eventID string, transaction *payment.Transaction) error {
+	var status string
+	paidAt := transaction.PaidAt
+
+	switch transaction.Status {
+	case payment.StatusSuccess:
+		status = models.PaymentStatusSuccess
+		if transaction.Amount != int64(record.Amount) || !strings.EqualFold(transaction.Currency, record.Currency) {
+			s.logger.WarnContext(ctx, "payment amount does not match the charge",
+				"reference", record.Reference,
+				"amount", transaction.Amount, "currency", transaction.Currency,
+				"expected_amount", record.Amount, "expected_currency", record.Currency)
+			status = models.PaymentStatusFailed
+			paidAt = nil
+		} else if paidAt == nil {
+			now := time.Now()
+			paidAt = &now
+		}
+	case payment.StatusFailed:
+		status = models.PaymentStatusFailed
+		paidAt = nil
+	default:
+		// Still in progress; a later webhook or verify settles it
+		return nil
+	}
+
+	changed, err := s.paymentRepo.RecordResult(ctx, record.Provider, eventID, record.Reference, status, paidAt)
+	if err != nil || !changed || status != models.PaymentStatusSuccess {
+		return err
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, record.OrderID)
+	if err != nil {
+		return err
+	}
+	s.events.Publish(ctx, order.StoreID, models.WebhookEventOrderPaid, mapOrderToResponse(order))
+	return nil
+}
+
+func mapPaymentToResponse(record *models.Payment, orderReference string) *dto.PaymentResponse {
+	response := &dto.PaymentResponse{
+		Reference:      record.Reference,
+		OrderReference: orderReference,
+		Provider:       record.Provider,
+		Amount:         mapMoney(models.CurrencyOf(record.Currency), record.Amount),
+		Status:         record.Status,
+		CreatedAt:      record.CreatedAt.Format(time.RFC3339),
+	}
+	if record.PaidAt != nil {
+		response.PaidAt = record.PaidAt.Format(time.RFC3339)
+	}
+	return response
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+	"github.com/falasefemi2/vendorhub/internal/validate"
+)
+
+// productCSVColumns is the header of product exports. Imports need only
+// name, description and price; columns may come in any order and unknown
+// columns are ignored.
+var productCSVColumns = []string{"name", "description", "price", "active", "category", "image_urls"}
+
+const (
+	productImportMaxRows   = 1000
+	productImportMaxImages = 10
+	// productCSVImageSeparator separates the URLs in an image_urls cell
+	productCSVImageSeparator = "|"
+)
+
+// productCSV is a parsed import file: the rows that passed validation and
+// why the others didn't
+type productCSV struct {
+	totalRows int
+	rows      []models.ProductImportRow
+	errors    []models.ProductImportError
+}
+
+// parseProductCSV reads an import file and validates each row for a store
+// using currency. Invalid rows are reported rather than failing the file;
+// only an unreadable file, a missing required column or too many rows do.
+func parseProductCSV(r io.Reader, currency models.Currency) (*productCSV, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: the file is empty", utils.ErrInvalidOperation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid CSV: %v", utils.ErrInvalidOperation, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheets often save UTF-8 with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	for _, required := range []string{"name", "description", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: the header must include a %s column", utils.ErrInvalidOperation, required)
+		}
+	}
+
+	parsed := &productCSV{rows: []models.ProductImportRow{}, errors: []models.ProductImportError{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid CSV: %v", utils.ErrInvalidOperation, err)
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		parsed.totalRows++
+		if parsed.totalRows > productImportMaxRows {
+			return nil, fmt.Errorf("%w: a file can hold at most %d products", utils.ErrInvalidOperation, productImportMaxRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, err := parseProductCSVRow(field, currency)
+		if err != nil {
+			parsed.errors = append(parsed.errors, models.ProductImportError{Row: line, Message: err.Error()})
+			continue
+		}
+		row.Row = line
+		parsed.rows = append(parsed.rows, row)
+	}
+
+	return parsed, nil
+}
+
+// parseProductCSVRow validates one row with the same rules as creating a
+// product through the API
+func parseProductCSVRow(field func(string) string, currency models.Currency) (models.ProductImportRow, error) {
+	req := dto.CreateProductRequest{
+		Name:        field("name"),
+		Description: field("description"),
+		Price:       dto.Amount(field("price")),
+		Category:    field("category"),
+	}
+	if err := validate.Struct(&req); err != nil {
+		// The row error already says the request was invalid
+		return models.ProductImportRow{}, errors.New(strings.TrimPrefix(err.Error(), "invalid request: "))
+	}
+	if err := req.Validate(); err != nil {
+		return models.ProductImportRow{}, err
+	}
+
+	price, err := parseAmount(currency, req.Price, "price")
+	if err != nil {
+		// The row error already says the request was invalid
+		return models.ProductImportRow{}, errors.New(strings.TrimPrefix(err.Error(), utils.ErrInvalidOperation.Error()+": "))
+	}
+
+	isActive, err := parseCSVBool(field("active"))
+	if err != nil {
+		return models.ProductImportRow{}, errors.New("active must be true or false")
+	}
+
+	imageURLs := []string{}
+	for _, imageURL := range strings.Split(field("image_urls"), productCSVImageSeparator) {
+		imageURL = strings.TrimSpace(imageURL)
+		if imageURL == "" {
+			continue
+		}
+		if !isImageURL(imageURL) {
+			return models.ProductImportRow{}, fmt.Errorf("image_urls must be http or https URLs separated by %q", productCSVImageSeparator)
+		}
+		imageURLs = append(imageURLs, imageURL)
+	}
+	if len(imageURLs) > productImportMaxImages {
+		return models.ProductImportRow{}, fmt.Errorf("a product can have at most %d image_urls", productImportMaxImages)
+	}
+
+	return models.ProductImportRow{
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       price,
+		IsActive:    isActive,
+		Category:    req.Category,
+		ImageURLs:   imageURLs,
+	}, nil
+}
+
+// parseCSVBool reads a yes/no cell; an empty cell means yes
+func parseCSVBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", value)
+}
+
+func isImageURL(raw string) bool {
+	imageURL, err := url.Parse(raw)
+	return err == nil && (imageURL.Scheme == "http" || imageURL.Scheme == "https") &&
+		imageURL.Host != "" && len(raw) <= 2000
+}
+
+// writeProductCSV writes products in the import format, with each
+// product's image URLs in position order
+func writeProductCSV(w io.Writer, products []*models.Product, images map[string][]*models.ProductImage) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(productCSVColumns); err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		imageURLs := make([]string, len(images[product.ID]))
+		for i, image := range images[product.ID] {
+			imageURLs[i] = image.ImageURL
+		}
+
+		record := []string{
+			product.Name,
+			product.Description,
+			models.CurrencyOf(product.Currency).Decimal(product.Price),
+			strconv.FormatBool(product.IsActive),
+			product.Category,
+			strings.Join(imageURLs, productCSVImageSeparator),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
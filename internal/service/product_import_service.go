@@ -0,0 +1,347 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+const (
+	// productImportLease is how long a running job is held before another
+	// run may pick it up; progress is saved after every row and renews it
+	productImportLease = 5 * time.Minute
+	// productImportRetention is how long completed jobs can be polled
+	productImportRetention = 30 * 24 * time.Hour
+)
+
+type ProductImportRepository interface {
+	CreateJob(ctx context.Context, job *models.ProductImportJob) (*models.ProductImportJob, error)
+	GetJob(ctx context.Context, jobID string) (*models.ProductImportJob, error)
+	ClaimNext(ctx context.Context, lease time.Duration) (*models.ProductImportJob, error)
+	SaveProgress(ctx context.Context, job *models.ProductImportJob, lease time.Duration) error
+	PruneJobsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// ProductCatalog creates and lists a store's products and images and
+// checks product slugs
+type ProductCatalog interface {
+	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
+	CreateProductImage(ctx context.Context, image *models.ProductImage) (*models.ProductImage, error)
+	GetProductsByStoreID(ctx context.Context, storeID string) ([]*models.Product, error)
+	GetProductImages(ctx context.Context, productID string) ([]*models.ProductImage, error)
+	ProductSlugChecker
+}
+
+type ProductImportService struct {
+	repo         ProductImportRepository
+	catalog      ProductCatalog
+	storeService *StoreService
+	access       StoreAuthorizer
+	events       EventPublisher
+	catalogCache *CatalogCache
+	logger       *slog.Logger
+}
+
+func NewProductImportService(repo ProductImportRepository, catalog ProductCatalog, storeService *StoreService, access StoreAuthorizer, events EventPublisher, catalogCache *CatalogCache, logger *slog.Logger) *ProductImportService {
+	return &ProductImportService{
+		repo:         repo,
+		catalog:      catalog,
+		storeService: storeService,
+		access:       access,
+		events:       events,
+		catalogCache: catalogCache,
+		logger:       logger,
+	}
+}
+
+// PreviewImport validates a product CSV for a store the user manages the
+// catalog of and reports what an import would create, without creating it
+func (s *ProductImportService) PreviewImport(ctx context.Context, userID, storeID string, file io.Reader) (*dto.ProductImportPreviewResponse, error) {
+	store, err := s.catalogStore(ctx, userID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := models.CurrencyOf(store.Currency)
+	parsed, err := parseProductCSV(file, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ProductImportPreviewResponse{
+		StoreID:   store.ID,
+		TotalRows: parsed.totalRows,
+		ValidRows: len(parsed.rows),
+		Products:  make([]*dto.ProductImportRowPreview, len(parsed.rows)),
+		Errors:    mapProductImportErrors(parsed.errors),
+	}
+	for i, row := range parsed.rows {
+		response.Products[i] = &dto.ProductImportRowPreview{
+			Row:       row.Row,
+			Name:      row.Name,
+			Price:     mapMoney(currency, row.Price),
+			IsActive:  row.IsActive,
+			Category:  row.Category,
+			ImageURLs: row.ImageURLs,
+		}
+	}
+	return response, nil
+}
+
+// StartImport validates a product CSV for a store the user manages the
+// catalog of and queues its valid rows to be created in the background.
+// Invalid rows are skipped and listed in the job's errors.
+func (s *ProductImportService) StartImport(ctx context.Context, userID, storeID string, file io.Reader) (*dto.ProductImportJobResponse, error) {
+	store, err := s.catalogStore(ctx, userID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseProductCSV(file, models.CurrencyOf(store.Currency))
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.ProductImportJob{
+		StoreID:   store.ID,
+		UserID:    userID,
+		Status:    models.ProductImportPending,
+		TotalRows: parsed.totalRows,
+		ValidRows: len(parsed.rows),
+		Rows:      parsed.rows,
+		Errors:    parsed.errors,
+	}
+	if len(parsed.rows) == 0 {
+		now := time.Now()
+		job.Status = models.ProductImportCompleted
+		job.CompletedAt = &now
+	}
+
+	created, err := s.repo.CreateJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapProductImportJobToResponse(created), nil
+}
+
+// GetImportJob returns the progress and row errors of an import into a
+// store the user manages the catalog of
+func (s *ProductImportService) GetImportJob(ctx context.Context, userID, jobID string) (*dto.ProductImportJobResponse, error) {
+	job, err := s.repo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.access.CanAccessStore(ctx, job.StoreID, userID, PermissionManageCatalog)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, utils.ErrImportJobNotFound
+	}
+
+	return mapProductImportJobToResponse(job), nil
+}
+
+// ExportProducts returns every product of a store the user manages the
+// catalog of as CSV in the import format, along with a file name for it
+func (s *ProductImportService) ExportProducts(ctx context.Context, userID, storeID string) (string, []byte, error) {
+	store, err := s.catalogStore(ctx, userID, storeID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	products, err := s.catalog.GetProductsByStoreID(ctx, store.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	images := make(map[string][]*models.ProductImage, len(products))
+	for _, product := range products {
+		if images[product.ID], err = s.catalog.GetProductImages(ctx, product.ID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeProductCSV(&buf, products, images); err != nil {
+		return "", nil, fmt.Errorf("failed to write products CSV: %w", err)
+	}
+
+	name := store.Slug
+	if name == "" {
+		name = store.ID
+	}
+	return "products-" + name + ".csv", buf.Bytes(), nil
+}
+
+// RunPendingImports works through waiting import jobs one at a time, then
+// prunes old completed jobs
+func (s *ProductImportService) RunPendingImports(ctx context.Context) error {
+	for {
+		job, err := s.repo.ClaimNext(ctx, productImportLease)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			break
+		}
+		if err := s.runImport(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.repo.PruneJobsBefore(ctx, time.Now().Add(-productImportRetention))
+	return err
+}
+
+// runImport creates a job's remaining rows, saving progress after each so
+// a job interrupted by a restart resumes where it stopped
+func (s *ProductImportService) runImport(ctx context.Context, job *models.ProductImportJob) error {
+	for job.ProcessedRows < len(job.Rows) {
+		row := job.Rows[job.ProcessedRows]
+		if err := s.importRow(ctx, job.StoreID, row); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.logger.ErrorContext(ctx, "failed to import product row", "job_id", job.ID, "row", row.Row, "error", err)
+			job.Errors = append(job.Errors, models.ProductImportError{
+				Row:     row.Row,
+				Message: "the product could not be created; import this row again",
+			})
+		} else {
+			job.CreatedRows++
+		}
+		job.ProcessedRows++
+
+		if err := s.repo.SaveProgress(ctx, job, productImportLease); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	job.Status = models.ProductImportCompleted
+	job.CompletedAt = &now
+	return s.repo.SaveProgress(ctx, job, productImportLease)
+}
+
+// importRow creates the product of one row with its images and announces
+// it. An image that fails to save is logged and skipped.
+func (s *ProductImportService) importRow(ctx context.Context, storeID string, row models.ProductImportRow) error {
+	slug, err := allocateProductSlug(ctx, s.catalog, storeID, row.Name, "")
+	if err != nil {
+		return err
+	}
+
+	product, err := s.catalog.CreateProduct(ctx, &models.Product{
+		StoreID:     storeID,
+		Name:        row.Name,
+		Description: row.Description,
+		Price:       row.Price,
+		IsActive:    row.IsActive,
+		Category:    row.Category,
+		Slug:        slug,
+	})
+	if err != nil {
+		return err
+	}
+
+	response := mapProductToResponse(product)
+	for position, imageURL := range row.ImageURLs {
+		image, err := s.catalog.CreateProductImage(ctx, &models.ProductImage{
+			ProductID: product.ID,
+			ImageURL:  imageURL,
+			Position:  position,
+		})
+		if err != nil {
+			s.logger.WarnContext(ctx, "failed to add imported product image", "product_id", product.ID, "error", err)
+			continue
+		}
+		response.Images = append(response.Images, &dto.ProductImageResponse{
+			ID:       image.ID,
+			ImageURL: image.ImageURL,
+			Position: image.Position,
+		})
+	}
+
+	s.catalogCache.InvalidateStore(ctx, storeID)
+	s.events.Publish(ctx, storeID, models.WebhookEventProductCreated, response)
+	return nil
+}
+
+// StartImportJob runs RunPendingImports every interval until ctx is
+// cancelled
+func (s *ProductImportService) StartImportJob(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.RunPendingImports(ctx); err != nil && ctx.Err() == nil {
+				s.logger.ErrorContext(ctx, "product import failed", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// catalogStore resolves the store a user imports into or exports from,
+// defaulting to their primary store, and checks they manage its catalog
+func (s *ProductImportService) catalogStore(ctx context.Context, userID, storeID string) (*models.Store, error) {
+	if storeID == "" {
+		defaultStoreID, err := s.access.DefaultStoreID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: store_id is required", utils.ErrInvalidOperation)
+		}
+		storeID = defaultStoreID
+	}
+
+	allowed, err := s.access.CanAccessStore(ctx, storeID, userID, PermissionManageCatalog)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, utils.ErrForbidden
+	}
+
+	return s.storeService.GetStoreByID(ctx, storeID)
+}
+
+func mapProductImportErrors(rowErrors []models.ProductImportError) []*dto.ProductImportRowError {
+	responses := make([]*dto.ProductImportRowError, len(rowErrors))
+	for i, rowError := range rowErrors {
+		responses[i] = &dto.ProductImportRowError{Row: rowError.Row, Message: rowError.Message}
+	}
+	return responses
+}
+
+func mapProductImportJobToResponse(job *models.ProductImportJob) *dto.ProductImportJobResponse {
+	response := &dto.ProductImportJobResponse{
+		ID:            job.ID,
+		StoreID:       job.StoreID,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ValidRows:     job.ValidRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedRows:   job.CreatedRows,
+		Errors:        mapProductImportErrors(job.Errors),
+		CreatedAt:     job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.CompletedAt != nil {
+		response.CompletedAt = job.CompletedAt.Format(time.RFC3339)
+	}
+	return response
+}
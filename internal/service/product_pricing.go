@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// isOnSale reports whether the product's sale price applies at now. A sale
+// without a start is running already and one without an end never expires.
+func isOnSale(product *models.Product, now time.Time) bool {
+	if product.SalePrice == nil || *product.SalePrice <= 0 || *product.SalePrice >= product.Price {
+		return false
+	}
+	if product.SaleStartsAt != nil && now.Before(*product.SaleStartsAt) {
+		return false
+	}
+	if product.SaleEndsAt != nil && !now.Before(*product.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// effectivePrice returns what a buyer pays for one unit of the product at now
+func effectivePrice(product *models.Product, now time.Time) models.Money {
+	if isOnSale(product, now) {
+		return *product.SalePrice
+	}
+	return product.Price
+}
+
+// parseAmount converts a request amount to minor units of currency
+func parseAmount(currency models.Currency, amount dto.Amount, field string) (models.Money, error) {
+	money, err := currency.Parse(string(amount))
+	if err != nil {
+		if errors.Is(err, models.ErrAmountTooPrecise) {
+			return 0, fmt.Errorf("%w: %s has more than %d decimal places for %s", utils.ErrInvalidOperation, field, currency.Exponent, currency.Code)
+		}
+		return 0, fmt.Errorf("%w: %s must be a decimal amount like 1500.50", utils.ErrInvalidOperation, field)
+	}
+	return money, nil
+}
+
+// lookupCurrency validates a currency code from a request, with an empty
+// code selecting the default currency
+func lookupCurrency(code string) (models.Currency, error) {
+	if code == "" {
+		return models.CurrencyOf(models.DefaultCurrency), nil
+	}
+	currency, ok := models.LookupCurrency(code)
+	if !ok {
+		return models.Currency{}, fmt.Errorf("%w: unsupported currency %q", utils.ErrInvalidOperation, code)
+	}
+	return currency, nil
+}
+
+func mapMoney(currency models.Currency, amount models.Money) dto.Money {
+	return dto.Money{
+		Amount:    int64(amount),
+		Currency:  currency.Code,
+		Value:     currency.Decimal(amount),
+		Formatted: currency.Format(amount),
+	}
+}
+
+func mapOptionalMoney(currency models.Currency, amount *models.Money) *dto.Money {
+	if amount == nil {
+		return nil
+	}
+	money := mapMoney(currency, *amount)
+	return &money
+}
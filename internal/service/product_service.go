@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"path"
 	"strings"
 	"time"
@@ -11,28 +12,100 @@ import (
 	"github.com/falasefemi2/vendorhub/internal/models"
 	"github.com/falasefemi2/vendorhub/internal/repository"
 	"github.com/falasefemi2/vendorhub/internal/storage"
+	"github.com/falasefemi2/vendorhub/internal/utils"
 )
 
+// StoreAuthorizer decides whether a user may act on behalf of a store,
+// either as its owner or as a staff member holding the permission.
+type StoreAuthorizer interface {
+	CanAccessStore(ctx context.Context, storeID, userID, permission string) (bool, error)
+	DefaultStoreID(ctx context.Context, userID string) (string, error)
+}
+
 type ProductService struct {
-	repo    *repository.ProductRepository
-	storage storage.Storage
+	repo         *repository.ProductRepository
+	storage      storage.Storage
+	access       StoreAuthorizer
+	storeService *StoreService
+	events       EventPublisher
+	catalogCache *CatalogCache
+	logger       *slog.Logger
 }
 
-func NewProductService(repo *repository.ProductRepository, storage storage.Storage) *ProductService {
-	return &ProductService{repo: repo, storage: storage}
+func NewProductService(repo *repository.ProductRepository, storage storage.Storage, access StoreAuthorizer, storeService *StoreService, events EventPublisher, catalogCache *CatalogCache, logger *slog.Logger) *ProductService {
+	return &ProductService{repo: repo, storage: storage, access: access, storeService: storeService, events: events, catalogCache: catalogCache, logger: logger}
+}
+
+// canManageCatalog reports whether userID may edit the products of storeID
+func (ps *ProductService) canManageCatalog(ctx context.Context, storeID, userID string) (bool, error) {
+	return ps.access.CanAccessStore(ctx, storeID, userID, PermissionManageCatalog)
 }
 
 func (ps *ProductService) CreateProduct(ctx context.Context, vendorID string, req dto.CreateProductRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.CreateProduct")
+	defer span.End()
+
 	if err := req.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	storeID := req.StoreID
+	if storeID == "" {
+		defaultStoreID, err := ps.access.DefaultStoreID(ctx, vendorID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: store_id is required", utils.ErrInvalidOperation)
+		}
+		storeID = defaultStoreID
+	}
+
+	allowed, err := ps.canManageCatalog(ctx, storeID, vendorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check store access: %w", err)
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: product does not belong to this vendor", utils.ErrForbidden)
+	}
+
+	store, err := ps.storeService.GetStoreByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	currency := models.CurrencyOf(store.Currency)
+
+	price, err := parseAmount(currency, req.Price, "price")
+	if err != nil {
+		return nil, err
 	}
 
 	product := &models.Product{
-		UserID:      vendorID,
+		StoreID:     storeID,
 		Name:        req.Name,
 		Description: req.Description,
-		Price:       req.Price,
+		Price:       price,
+		Currency:    currency.Code,
 		IsActive:    true,
+		Category:    strings.TrimSpace(req.Category),
+	}
+	if req.SalePrice != nil {
+		salePrice, err := parseAmount(currency, *req.SalePrice, "sale_price")
+		if err != nil {
+			return nil, err
+		}
+		product.SalePrice = &salePrice
+	}
+	product.SaleStartsAt = parseOptionalTime(req.SaleStartsAt)
+	product.SaleEndsAt = parseOptionalTime(req.SaleEndsAt)
+	if err := validateSaleWindow(product); err != nil {
+		return nil, err
+	}
+
+	if req.Slug != "" {
+		product.Slug, err = claimProductSlug(ctx, ps.repo, storeID, req.Slug, "")
+	} else {
+		product.Slug, err = allocateProductSlug(ctx, ps.repo, storeID, req.Name, "")
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	createdProduct, err := ps.repo.CreateProduct(ctx, product)
@@ -40,12 +113,18 @@ func (ps *ProductService) CreateProduct(ctx context.Context, vendorID string, re
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
-	return mapProductToResponse(createdProduct), nil
+	ps.catalogCache.InvalidateStore(ctx, createdProduct.StoreID)
+	response := mapProductToResponse(createdProduct)
+	ps.events.Publish(ctx, createdProduct.StoreID, models.WebhookEventProductCreated, response)
+	return response, nil
 }
 
 func (ps *ProductService) GetProduct(ctx context.Context, productID string) (*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProduct")
+	defer span.End()
+
 	if productID == "" {
-		return nil, fmt.Errorf("product ID cannot be empty")
+		return nil, fmt.Errorf("%w: product ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -63,8 +142,11 @@ func (ps *ProductService) GetProduct(ctx context.Context, productID string) (*dt
 }
 
 func (ps *ProductService) GetUserProducts(ctx context.Context, userID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetUserProducts")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID cannot be empty")
+		return nil, fmt.Errorf("%w: user ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -84,9 +166,38 @@ func (ps *ProductService) GetUserProducts(ctx context.Context, userID string) ([
 	return responses, nil
 }
 
+// GetManagedProducts returns all products of storeID for a user who owns the
+// store or manages its catalog as staff. An empty storeID selects the user's
+// primary store.
+func (ps *ProductService) GetManagedProducts(ctx context.Context, storeID string, userID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetManagedProducts")
+	defer span.End()
+
+	if storeID == "" {
+		defaultStoreID, err := ps.access.DefaultStoreID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: store_id is required", utils.ErrInvalidOperation)
+		}
+		storeID = defaultStoreID
+	}
+
+	allowed, err := ps.canManageCatalog(ctx, storeID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check store access: %w", err)
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: product does not belong to this vendor", utils.ErrForbidden)
+	}
+
+	return ps.GetProductsByStoreID(ctx, storeID)
+}
+
 func (ps *ProductService) UpdateProduct(ctx context.Context, productID string, vendorID string, req dto.UpdateProductRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.UpdateProduct")
+	defer span.End()
+
 	if productID == "" || vendorID == "" {
-		return nil, fmt.Errorf("product ID and vendor ID cannot be empty")
+		return nil, fmt.Errorf("%w: product ID and vendor ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -97,11 +208,16 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, productID string, v
 
 	existingProduct, err := ps.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		return nil, fmt.Errorf("product not found: %w", err)
+		return nil, err
+	}
+
+	allowed, err := ps.canManageCatalog(ctx, existingProduct.StoreID, vendorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check store access: %w", err)
 	}
 
-	if existingProduct.UserID != vendorID {
-		return nil, fmt.Errorf("unauthorized: product does not belong to this vendor")
+	if !allowed {
+		return nil, fmt.Errorf("%w: product does not belong to this vendor", utils.ErrForbidden)
 	}
 
 	if req.Name != nil && *req.Name != "" {
@@ -110,24 +226,62 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, productID string, v
 	if req.Description != nil && *req.Description != "" {
 		existingProduct.Description = *req.Description
 	}
-	if req.Price != nil && *req.Price > 0 {
-		existingProduct.Price = *req.Price
+	currency := models.CurrencyOf(existingProduct.Currency)
+	if req.Price != nil {
+		price, err := parseAmount(currency, *req.Price, "price")
+		if err != nil {
+			return nil, err
+		}
+		existingProduct.Price = price
 	}
 	if req.IsActive != nil {
 		existingProduct.IsActive = *req.IsActive
 	}
+	if req.SalePrice != nil {
+		salePrice, err := parseAmount(currency, *req.SalePrice, "sale_price")
+		if err != nil {
+			return nil, err
+		}
+		existingProduct.SalePrice = &salePrice
+		if salePrice == 0 {
+			existingProduct.SalePrice = nil
+		}
+	}
+	if req.SaleStartsAt != nil {
+		existingProduct.SaleStartsAt = parseOptionalTime(*req.SaleStartsAt)
+	}
+	if req.SaleEndsAt != nil {
+		existingProduct.SaleEndsAt = parseOptionalTime(*req.SaleEndsAt)
+	}
+	if req.Category != nil {
+		existingProduct.Category = strings.TrimSpace(*req.Category)
+	}
+	if err := validateSaleWindow(existingProduct); err != nil {
+		return nil, err
+	}
+	if req.Slug != nil && *req.Slug != existingProduct.Slug {
+		if existingProduct.Slug, err = claimProductSlug(ctx, ps.repo, existingProduct.StoreID, *req.Slug, existingProduct.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	updatedProduct, err := ps.repo.UpdateProduct(ctx, existingProduct)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
-	return mapProductToResponse(updatedProduct), nil
+	ps.catalogCache.InvalidateStore(ctx, updatedProduct.StoreID)
+	response := mapProductToResponse(updatedProduct)
+	ps.events.Publish(ctx, updatedProduct.StoreID, models.WebhookEventProductUpdated, response)
+	return response, nil
 }
 
 func (ps *ProductService) DeleteProduct(ctx context.Context, productID string, vendorID string) error {
+	ctx, span := tracer.Start(ctx, "ProductService.DeleteProduct")
+	defer span.End()
+
 	if productID == "" || vendorID == "" {
-		return fmt.Errorf("product ID and vendor ID cannot be empty")
+		return fmt.Errorf("%w: product ID and vendor ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -138,37 +292,62 @@ func (ps *ProductService) DeleteProduct(ctx context.Context, productID string, v
 
 	product, err := ps.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		return fmt.Errorf("product not found: %w", err)
+		return err
 	}
 
-	if product.UserID != vendorID {
-		return fmt.Errorf("unauthorized: product does not belong to this vendor")
+	allowed, err := ps.canManageCatalog(ctx, product.StoreID, vendorID)
+	if err != nil {
+		return fmt.Errorf("failed to check store access: %w", err)
 	}
 
-	return ps.repo.DeleteProduct(ctx, productID)
+	if !allowed {
+		return fmt.Errorf("%w: product does not belong to this vendor", utils.ErrForbidden)
+	}
+
+	if err := ps.repo.DeleteProduct(ctx, productID); err != nil {
+		return err
+	}
+	ps.catalogCache.InvalidateStore(ctx, product.StoreID)
+
+	ps.events.Publish(ctx, product.StoreID, models.WebhookEventProductDeleted, map[string]string{
+		"id":       product.ID,
+		"store_id": product.StoreID,
+	})
+	return nil
 }
 
-func (ps *ProductService) GetActiveProducts(ctx context.Context) ([]*dto.ProductResponse, error) {
+// GetActiveProducts returns every active product with images, from the
+// catalog cache when they're in it, and when the list was loaded. The time
+// is zero when caching is off.
+func (ps *ProductService) GetActiveProducts(ctx context.Context) ([]*dto.ProductResponse, time.Time, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetActiveProducts")
+	defer span.End()
+
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
 	}
 
-	products, err := ps.repo.GetActiveProducts(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get active products: %w", err)
-	}
+	return ps.catalogCache.products(ctx, catalogCacheActiveProducts, func() ([]*dto.ProductResponse, error) {
+		products, err := ps.repo.GetActiveProducts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active products: %w", err)
+		}
 
-	responses := mapProductsToResponse(products)
-	// Enrich with images
-	ps.enrichProductResponsesWithImages(ctx, responses)
-	return responses, nil
+		responses := mapProductsToResponse(products)
+		// Enrich with images
+		ps.enrichProductResponsesWithImages(ctx, responses)
+		return responses, nil
+	})
 }
 
 func (ps *ProductService) GetActiveUserProducts(ctx context.Context, userID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetActiveUserProducts")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID cannot be empty")
+		return nil, fmt.Errorf("%w: user ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -189,8 +368,11 @@ func (ps *ProductService) GetActiveUserProducts(ctx context.Context, userID stri
 }
 
 func (ps *ProductService) ToggleProductStatus(ctx context.Context, productID string, vendorID string, isActive bool) (*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ToggleProductStatus")
+	defer span.End()
+
 	if productID == "" || vendorID == "" {
-		return nil, fmt.Errorf("product ID and vendor ID cannot be empty")
+		return nil, fmt.Errorf("%w: product ID and vendor ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -201,11 +383,16 @@ func (ps *ProductService) ToggleProductStatus(ctx context.Context, productID str
 
 	product, err := ps.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		return nil, fmt.Errorf("product not found: %w", err)
+		return nil, err
+	}
+
+	allowed, err := ps.canManageCatalog(ctx, product.StoreID, vendorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check store access: %w", err)
 	}
 
-	if product.UserID != vendorID {
-		return nil, fmt.Errorf("unauthorized: product does not belong to this vendor")
+	if !allowed {
+		return nil, fmt.Errorf("%w: product does not belong to this vendor", utils.ErrForbidden)
 	}
 
 	product.IsActive = isActive
@@ -215,12 +402,18 @@ func (ps *ProductService) ToggleProductStatus(ctx context.Context, productID str
 		return nil, fmt.Errorf("failed to update product status: %w", err)
 	}
 
-	return mapProductToResponse(updated), nil
+	ps.catalogCache.InvalidateStore(ctx, updated.StoreID)
+	response := mapProductToResponse(updated)
+	ps.events.Publish(ctx, updated.StoreID, models.WebhookEventProductUpdated, response)
+	return response, nil
 }
 
 func (ps *ProductService) SearchProducts(ctx context.Context, searchTerm string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.SearchProducts")
+	defer span.End()
+
 	if searchTerm == "" {
-		return nil, fmt.Errorf("search term cannot be empty")
+		return nil, fmt.Errorf("%w: search term cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -240,9 +433,28 @@ func (ps *ProductService) SearchProducts(ctx context.Context, searchTerm string)
 	return responses, nil
 }
 
-func (ps *ProductService) GetProductsByPriceRange(ctx context.Context, minPrice, maxPrice float64) ([]*dto.ProductResponse, error) {
-	if minPrice < 0 || maxPrice < 0 || minPrice > maxPrice {
-		return nil, fmt.Errorf("invalid price range")
+// GetProductsByPriceRange returns active products priced in currencyCode
+// between minPrice and maxPrice, given as decimals in major units. Products
+// of stores using other currencies are never compared.
+func (ps *ProductService) GetProductsByPriceRange(ctx context.Context, currencyCode, minPrice, maxPrice string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductsByPriceRange")
+	defer span.End()
+
+	currency, err := lookupCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	minAmount, err := parseAmount(currency, dto.Amount(minPrice), "min")
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := parseAmount(currency, dto.Amount(maxPrice), "max")
+	if err != nil {
+		return nil, err
+	}
+	if minAmount > maxAmount {
+		return nil, fmt.Errorf("%w: min must not be greater than max", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -251,7 +463,7 @@ func (ps *ProductService) GetProductsByPriceRange(ctx context.Context, minPrice,
 		defer cancel()
 	}
 
-	products, err := ps.repo.GetProductsByPriceRange(ctx, minPrice, maxPrice)
+	products, err := ps.repo.GetProductsByPriceRange(ctx, currency.Code, minAmount, maxAmount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products by price range: %w", err)
 	}
@@ -262,22 +474,58 @@ func (ps *ProductService) GetProductsByPriceRange(ctx context.Context, minPrice,
 	return responses, nil
 }
 
-func mapProductToResponse(product *models.Product) *dto.ProductResponse {
-	return &dto.ProductResponse{
-		ID:          product.ID,
-		UserID:      product.UserID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		IsActive:    product.IsActive,
-		Images:      []*dto.ProductImageResponse{},
-		CreatedAt:   product.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   product.UpdatedAt.Format(time.RFC3339),
+// validateSaleWindow checks a product's sale settings after a create or
+// update has been applied
+func validateSaleWindow(product *models.Product) error {
+	if product.SalePrice != nil && *product.SalePrice >= product.Price {
+		return fmt.Errorf("%w: sale_price must be less than price", utils.ErrInvalidOperation)
 	}
+	if product.SaleStartsAt != nil && product.SaleEndsAt != nil && !product.SaleEndsAt.After(*product.SaleStartsAt) {
+		return fmt.Errorf("%w: sale_ends_at must be after sale_starts_at", utils.ErrInvalidOperation)
+	}
+	return nil
+}
+
+func mapProductToResponse(product *models.Product) *dto.ProductResponse {
+	now := time.Now()
+	currency := models.CurrencyOf(product.Currency)
+	response := &dto.ProductResponse{
+		ID:             product.ID,
+		UserID:         product.UserID,
+		StoreID:        product.StoreID,
+		Name:           product.Name,
+		Slug:           product.Slug,
+		Description:    product.Description,
+		Price:          mapMoney(currency, product.Price),
+		IsActive:       product.IsActive,
+		Category:       product.Category,
+		RatingAverage:  product.RatingAverage,
+		RatingCount:    product.RatingCount,
+		SalePrice:      mapOptionalMoney(currency, product.SalePrice),
+		OnSale:         isOnSale(product, now),
+		EffectivePrice: mapMoney(currency, effectivePrice(product, now)),
+		Images:         []*dto.ProductImageResponse{},
+		CreatedAt:      product.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      product.UpdatedAt.Format(time.RFC3339),
+	}
+	if product.SaleStartsAt != nil {
+		response.SaleStartsAt = product.SaleStartsAt.Format(time.RFC3339)
+	}
+	if product.SaleEndsAt != nil {
+		response.SaleEndsAt = product.SaleEndsAt.Format(time.RFC3339)
+	}
+	if response.OnSale {
+		compareAt := mapMoney(currency, product.Price)
+		response.CompareAtPrice = &compareAt
+	}
+	return response
 }
 
 // enrichProductResponseWithImages adds images to a product response
 func (ps *ProductService) enrichProductResponseWithImages(ctx context.Context, response *dto.ProductResponse) error {
+	ctx, span := tracer.Start(ctx, "ProductService.enrichProductResponseWithImages")
+	defer span.End()
+
 	images, err := ps.repo.GetProductImages(ctx, response.ID)
 	if err != nil {
 		// Don't fail if we can't get images, just return without images
@@ -289,6 +537,9 @@ func (ps *ProductService) enrichProductResponseWithImages(ctx context.Context, r
 
 // enrichProductResponsesWithImages adds images to multiple product responses
 func (ps *ProductService) enrichProductResponsesWithImages(ctx context.Context, responses []*dto.ProductResponse) error {
+	ctx, span := tracer.Start(ctx, "ProductService.enrichProductResponsesWithImages")
+	defer span.End()
+
 	for _, response := range responses {
 		if err := ps.enrichProductResponseWithImages(ctx, response); err != nil {
 			// Continue enriching even if one fails
@@ -311,8 +562,11 @@ func mapProductsToResponse(products []*models.Product) []*dto.ProductResponse {
 }
 
 func (ps *ProductService) GetProductsByUserID(ctx context.Context, userID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductsByUserID")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID cannot be empty")
+		return nil, fmt.Errorf("%w: user ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -333,8 +587,11 @@ func (ps *ProductService) GetProductsByUserID(ctx context.Context, userID string
 }
 
 func (ps *ProductService) GetActiveProductsByUserID(ctx context.Context, userID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetActiveProductsByUserID")
+	defer span.End()
+
 	if userID == "" {
-		return nil, fmt.Errorf("user ID cannot be empty")
+		return nil, fmt.Errorf("%w: user ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -361,10 +618,86 @@ func (ps *ProductService) GetActiveProductsByUserID(ctx context.Context, userID
 	return responses, nil
 }
 
+// GetProductsByStoreID returns every product of a store with images
+func (ps *ProductService) GetProductsByStoreID(ctx context.Context, storeID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductsByStoreID")
+	defer span.End()
+
+	if storeID == "" {
+		return nil, fmt.Errorf("%w: store ID cannot be empty", utils.ErrInvalidOperation)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+	}
+
+	products, err := ps.repo.GetProductsByStoreID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store products: %w", err)
+	}
+
+	responses := mapProductsToResponse(products)
+	_ = ps.enrichProductResponsesWithImages(ctx, responses)
+	return responses, nil
+}
+
+// GetActiveProductsByStoreID returns the active products of a store with
+// images, from the catalog cache when they're in it
+func (ps *ProductService) GetActiveProductsByStoreID(ctx context.Context, storeID string) ([]*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetActiveProductsByStoreID")
+	defer span.End()
+
+	if storeID == "" {
+		return nil, fmt.Errorf("%w: store ID cannot be empty", utils.ErrInvalidOperation)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+	}
+
+	products, _, err := ps.catalogCache.products(ctx, catalogCacheStoreProducts(storeID), func() ([]*dto.ProductResponse, error) {
+		products, err := ps.repo.GetActiveProductsByStoreID(ctx, storeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active store products: %w", err)
+		}
+
+		responses := mapProductsToResponse(products)
+		_ = ps.enrichProductResponsesWithImages(ctx, responses)
+		return responses, nil
+	})
+	return products, err
+}
+
+// GetStoreProductBySlug returns an active product of a store by its slug,
+// with images
+func (ps *ProductService) GetStoreProductBySlug(ctx context.Context, storeID, productSlug string) (*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetStoreProductBySlug")
+	defer span.End()
+
+	product, err := ps.repo.GetProductBySlug(ctx, storeID, productSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsActive {
+		return nil, utils.ErrProductNotFound
+	}
+
+	response := mapProductToResponse(product)
+	_ = ps.enrichProductResponseWithImages(ctx, response)
+	return response, nil
+}
+
 // GetProductWithImages retrieves a product with its images
 func (ps *ProductService) GetProductWithImages(ctx context.Context, productID string) (*dto.ProductResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductWithImages")
+	defer span.End()
+
 	if productID == "" {
-		return nil, fmt.Errorf("product ID cannot be empty")
+		return nil, fmt.Errorf("%w: product ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	product, err := ps.GetProduct(ctx, productID)
@@ -384,18 +717,26 @@ func (ps *ProductService) GetProductWithImages(ctx context.Context, productID st
 
 // CreateProductImage saves an image file and creates image record
 func (ps *ProductService) CreateProductImage(ctx context.Context, productID string, vendorID string, req *dto.UploadProductImageRequest, file *models.ProductImage) (*dto.ProductImageResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.CreateProductImage")
+	defer span.End()
+
 	if productID == "" || vendorID == "" {
-		return nil, fmt.Errorf("product ID and vendor ID cannot be empty")
+		return nil, fmt.Errorf("%w: product ID and vendor ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	// Verify product belongs to vendor
 	product, err := ps.repo.GetProductByID(ctx, productID)
 	if err != nil {
-		return nil, fmt.Errorf("product not found: %w", err)
+		return nil, err
+	}
+
+	allowed, err := ps.canManageCatalog(ctx, product.StoreID, vendorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check store access: %w", err)
 	}
 
-	if product.UserID != vendorID {
-		return nil, fmt.Errorf("unauthorized: product does not belong to this vendor")
+	if !allowed {
+		return nil, fmt.Errorf("%w: product does not belong to this vendor", utils.ErrForbidden)
 	}
 
 	// Normalize image URL before creating the DB record
@@ -426,69 +767,94 @@ func (ps *ProductService) CreateProductImage(ctx context.Context, productID stri
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product image: %w", err)
 	}
+	ps.catalogCache.InvalidateStore(ctx, product.StoreID)
 
 	return ps.mapProductImageToResponse(createdImage), nil
 }
 
 // DeleteProductImage removes an image file and database record
 func (ps *ProductService) DeleteProductImage(ctx context.Context, imageID string, vendorID string) error {
+	ctx, span := tracer.Start(ctx, "ProductService.DeleteProductImage")
+	defer span.End()
+
 	if imageID == "" || vendorID == "" {
-		return fmt.Errorf("image ID and vendor ID cannot be empty")
+		return fmt.Errorf("%w: image ID and vendor ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	// Get the image to find the product
 	image, err := ps.repo.GetProductImage(ctx, imageID)
 	if err != nil {
-		return fmt.Errorf("image not found: %w", err)
+		return err
 	}
 
 	// Verify product belongs to vendor
 	product, err := ps.repo.GetProductByID(ctx, image.ProductID)
 	if err != nil {
-		return fmt.Errorf("product not found: %w", err)
+		return err
 	}
 
-	if product.UserID != vendorID {
-		return fmt.Errorf("unauthorized: image does not belong to this vendor")
+	allowed, err := ps.canManageCatalog(ctx, product.StoreID, vendorID)
+	if err != nil {
+		return fmt.Errorf("failed to check store access: %w", err)
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: image does not belong to this vendor", utils.ErrForbidden)
 	}
 
 	// Delete file from storage
 	if err := ps.storage.DeleteFile(ctx, image.ImageURL); err != nil {
 		// Log error but don't fail the whole operation
-		fmt.Printf("warning: failed to delete image file %s: %v\n", image.ImageURL, err)
+		ps.logger.WarnContext(ctx, "failed to delete image file", "url", image.ImageURL, "error", err)
 	}
 
 	// Delete image record from database
-	return ps.repo.DeleteProductImage(ctx, imageID)
+	if err := ps.repo.DeleteProductImage(ctx, imageID); err != nil {
+		return err
+	}
+	ps.catalogCache.InvalidateStore(ctx, product.StoreID)
+	return nil
 }
 
 // UpdateProductImagePosition changes the position of an image
 func (ps *ProductService) UpdateProductImagePosition(ctx context.Context, imageID string, vendorID string, newPosition int) error {
+	ctx, span := tracer.Start(ctx, "ProductService.UpdateProductImagePosition")
+	defer span.End()
+
 	if imageID == "" || vendorID == "" {
-		return fmt.Errorf("image ID and vendor ID cannot be empty")
+		return fmt.Errorf("%w: image ID and vendor ID cannot be empty", utils.ErrInvalidOperation)
 	}
 
 	if newPosition < 0 {
-		return fmt.Errorf("image position cannot be negative")
+		return fmt.Errorf("%w: image position cannot be negative", utils.ErrInvalidOperation)
 	}
 
 	// Get the image to find the product
 	image, err := ps.repo.GetProductImage(ctx, imageID)
 	if err != nil {
-		return fmt.Errorf("image not found: %w", err)
+		return err
 	}
 
 	// Verify product belongs to vendor
 	product, err := ps.repo.GetProductByID(ctx, image.ProductID)
 	if err != nil {
-		return fmt.Errorf("product not found: %w", err)
+		return err
 	}
 
-	if product.UserID != vendorID {
-		return fmt.Errorf("unauthorized: image does not belong to this vendor")
+	allowed, err := ps.canManageCatalog(ctx, product.StoreID, vendorID)
+	if err != nil {
+		return fmt.Errorf("failed to check store access: %w", err)
 	}
 
-	return ps.repo.UpdateProductImagePosition(ctx, imageID, newPosition)
+	if !allowed {
+		return fmt.Errorf("%w: image does not belong to this vendor", utils.ErrForbidden)
+	}
+
+	if err := ps.repo.UpdateProductImagePosition(ctx, imageID, newPosition); err != nil {
+		return err
+	}
+	ps.catalogCache.InvalidateStore(ctx, product.StoreID)
+	return nil
 }
 
 // mapProductImageToResponse maps a models.ProductImage to a DTO, converting stored
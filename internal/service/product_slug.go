@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// ProductSlugChecker reports whether a product slug is taken within a store
+type ProductSlugChecker interface {
+	ProductSlugInUse(ctx context.Context, storeID, slug, exceptProductID string) (bool, error)
+}
+
+// allocateProductSlug generates a slug from name that no product of storeID
+// other than productID uses
+func allocateProductSlug(ctx context.Context, products ProductSlugChecker, storeID, name, productID string) (string, error) {
+	baseSlug := utils.GenerateSlug(name)
+	if len(baseSlug) > utils.MaxSlugLength-4 {
+		baseSlug = strings.TrimRight(baseSlug[:utils.MaxSlugLength-4], "-")
+	}
+	if baseSlug == "" {
+		baseSlug = "product"
+	}
+
+	slug := baseSlug
+	for i := 2; ; i++ {
+		inUse, err := products.ProductSlugInUse(ctx, storeID, slug, productID)
+		if err != nil {
+			return "", err
+		}
+		if !inUse {
+			return slug, nil
+		}
+		slug = baseSlug + "-" + strconv.Itoa(i)
+	}
+}
+
+// claimProductSlug normalises and validates a vendor-chosen slug for a
+// product of storeID
+func claimProductSlug(ctx context.Context, products ProductSlugChecker, storeID, slug, productID string) (string, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if err := utils.ValidateSlugFormat(slug); err != nil {
+		return "", fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	inUse, err := products.ProductSlugInUse(ctx, storeID, slug, productID)
+	if err != nil {
+		return "", err
+	}
+	if inUse {
+		return "", utils.ErrSlugTaken
+	}
+	return slug, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// fakeProductSlugs holds the slugs taken in a store, by product ID
+type fakeProductSlugs map[string]string
+
+func (f fakeProductSlugs) ProductSlugInUse(ctx context.Context, storeID, slug, exceptProductID string) (bool, error) {
+	for productID, taken := range f {
+		if taken == slug && productID != exceptProductID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestAllocateProductSlug(t *testing.T) {
+	products := fakeProductSlugs{"p1": "pepperoni-pizza", "p2": "pepperoni-pizza-2"}
+
+	tests := []struct {
+		name      string
+		productID string
+		want      string
+	}{
+		{name: "Margherita Pizza", want: "margherita-pizza"},
+		{name: "Pepperoni Pizza", want: "pepperoni-pizza-3"},
+		{name: "Pepperoni Pizza", productID: "p1", want: "pepperoni-pizza"},
+		// Product pages aren't routes of their own, so reserved words are fine
+		{name: "New", want: "new"},
+		{name: "???", want: "product"},
+		{name: strings.Repeat("x", 80), want: strings.Repeat("x", utils.MaxSlugLength-4)},
+	}
+
+	for _, tt := range tests {
+		got, err := allocateProductSlug(context.Background(), products, "s1", tt.name, tt.productID)
+		if err != nil || got != tt.want {
+			t.Errorf("allocateProductSlug(%q, %q) = %q, %v, want %q", tt.name, tt.productID, got, err, tt.want)
+		}
+	}
+}
+
+func TestClaimProductSlug(t *testing.T) {
+	products := fakeProductSlugs{"p1": "pepperoni-pizza"}
+
+	tests := []struct {
+		slug      string
+		productID string
+		want      string
+		wantErr   error
+	}{
+		{slug: " Margherita ", want: "margherita"},
+		{slug: "pepperoni-pizza", productID: "p1", want: "pepperoni-pizza"},
+		{slug: "pepperoni-pizza", productID: "p2", wantErr: utils.ErrSlugTaken},
+		{slug: "pizza!", wantErr: utils.ErrInvalidOperation},
+		{slug: "pi", wantErr: utils.ErrInvalidOperation},
+	}
+
+	for _, tt := range tests {
+		got, err := claimProductSlug(context.Background(), products, "s1", tt.slug, tt.productID)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("claimProductSlug(%q, %q) = %q, %v, want %q, %v", tt.slug, tt.productID, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+const (
+	SortNewest    = "newest"
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortRating    = "rating"
+)
+
+// SortProducts orders product responses in place. An empty sortBy keeps the
+// order the list was loaded in. Rating sorts by average, then by number of
+// reviews, so a single five-star review doesn't outrank fifty 4.8s.
+func SortProducts(products []*dto.ProductResponse, sortBy string) error {
+	var less func(a, b *dto.ProductResponse) bool
+
+	switch sortBy {
+	case "":
+		return nil
+	case SortNewest:
+		less = func(a, b *dto.ProductResponse) bool {
+			return parseResponseTime(a.CreatedAt).After(parseResponseTime(b.CreatedAt))
+		}
+	case SortPriceAsc:
+		less = func(a, b *dto.ProductResponse) bool { return a.EffectivePrice.Amount < b.EffectivePrice.Amount }
+	case SortPriceDesc:
+		less = func(a, b *dto.ProductResponse) bool { return a.EffectivePrice.Amount > b.EffectivePrice.Amount }
+	case SortRating:
+		less = func(a, b *dto.ProductResponse) bool {
+			if a.RatingAverage != b.RatingAverage {
+				return a.RatingAverage > b.RatingAverage
+			}
+			return a.RatingCount > b.RatingCount
+		}
+	default:
+		return fmt.Errorf("%w: unknown sort %q, expected one of newest, price_asc, price_desc, rating", utils.ErrInvalidOperation, sortBy)
+	}
+
+	sort.SliceStable(products, func(i, j int) bool { return less(products[i], products[j]) })
+	return nil
+}
+
+func parseResponseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	// qrLogoShare is the fraction of the code's width the centre logo takes.
+	// High error correction restores the modules it covers.
+	qrLogoShare = 0.22
+	// qrFlyerImageSize is the resolution of the code printed on flyers
+	qrFlyerImageSize = 1024
+)
+
+var qrLevels = map[string]qrcode.RecoveryLevel{
+	"low":     qrcode.Low,
+	"medium":  qrcode.Medium,
+	"high":    qrcode.High,
+	"highest": qrcode.Highest,
+}
+
+// newQRCode encodes content at level, raised to high when a logo will
+// cover the centre
+func newQRCode(content, level string, withLogo bool) (*qrcode.QRCode, error) {
+	recovery := qrLevels[level]
+	if withLogo && recovery < qrcode.High {
+		recovery = qrcode.High
+	}
+	return qrcode.New(content, recovery)
+}
+
+// renderQRCodePNG draws code as a size×size PNG with logo, if any, centred
+// on a white square. Codes too dense for size come out larger.
+func renderQRCodePNG(code *qrcode.QRCode, size int, logo image.Image) ([]byte, error) {
+	symbol := code.Image(size)
+	size = symbol.Bounds().Dx()
+	canvas := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(canvas, canvas.Bounds(), symbol, image.Point{}, draw.Src)
+
+	if logo != nil {
+		box := qrLogoBox(size)
+		draw.Draw(canvas, box, image.NewUniform(color.White), image.Point{}, draw.Src)
+		xdraw.CatmullRom.Scale(canvas, fitRect(logo.Bounds(), box.Inset(box.Dx()/10)), logo, logo.Bounds(), draw.Over, nil)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderQRCodeSVG draws code as an SVG of size pixels, one unit per module,
+// embedding logo, if any, as a PNG
+func renderQRCodeSVG(w io.Writer, code *qrcode.QRCode, size int, logo image.Image) error {
+	bitmap := code.Bitmap()
+	modules := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			run := 1
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", x, y, run, run)
+			x += run - 1
+		}
+	}
+
+	if _, err := fmt.Fprintf(w,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+			`<rect width="%d" height="%d" fill="#fff"/><path d="%s" fill="#000"/>`,
+		size, size, modules, modules, modules, modules, path.String()); err != nil {
+		return err
+	}
+
+	if logo != nil {
+		box := qrLogoBox(modules)
+		inner := fitRect(logo.Bounds(), box.Inset(max(box.Dx()/10, 1)))
+		var logoPNG bytes.Buffer
+		if err := png.Encode(&logoPNG, logo); err != nil {
+			return fmt.Errorf("failed to encode logo: %w", err)
+		}
+		if _, err := fmt.Fprintf(w,
+			`<rect x="%d" y="%d" width="%d" height="%d" fill="#fff"/>`+
+				`<image x="%d" y="%d" width="%d" height="%d" href="data:image/png;base64,%s"/>`,
+			box.Min.X, box.Min.Y, box.Dx(), box.Dy(),
+			inner.Min.X, inner.Min.Y, inner.Dx(), inner.Dy(), base64.StdEncoding.EncodeToString(logoPNG.Bytes())); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "</svg>\n")
+	return err
+}
+
+// qrLogoBox is the square in the middle of a code size units wide that the
+// logo covers
+func qrLogoBox(size int) image.Rectangle {
+	side := int(float64(size) * qrLogoShare)
+	offset := (size - side) / 2
+	return image.Rect(offset, offset, offset+side, offset+side)
+}
+
+// fitRect scales src to fit inside box, keeping its aspect ratio, and
+// centres it there
+func fitRect(src, box image.Rectangle) image.Rectangle {
+	width, height := box.Dx(), box.Dy()
+	if src.Dx()*height > src.Dy()*width {
+		height = max(src.Dy()*width/src.Dx(), 1)
+	} else {
+		width = max(src.Dx()*height/src.Dy(), 1)
+	}
+	offset := image.Pt(box.Min.X+(box.Dx()-width)/2, box.Min.Y+(box.Dy()-height)/2)
+	return image.Rect(0, 0, width, height).Add(offset)
+}
+
+// qrFlyer is the text printed around the code on an A4 flyer
+type qrFlyer struct {
+	Title    string
+	Subtitle string
+	Caption  string
+	URL      string
+	Whatsapp string
+}
+
+// writeQRFlyerPDF lays out an A4 page with the flyer's title, the code as a
+// PNG and the link and WhatsApp number to reach the store
+func writeQRFlyerPDF(w io.Writer, flyer qrFlyer, qrPNG []byte) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(flyer.Title, true)
+	pdf.SetMargins(20, 25, 20)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	// The core fonts only cover Windows-1252
+	text := pdf.UnicodeTranslatorFromDescriptor("")
+	pageWidth, _ := pdf.GetPageSize()
+	contentWidth := pageWidth - 40
+
+	pdf.SetFont("Helvetica", "B", 30)
+	pdf.MultiCell(contentWidth, 13, text(flyer.Title), "", "C", false)
+	if flyer.Subtitle != "" {
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "", 18)
+		pdf.MultiCell(contentWidth, 9, text(flyer.Subtitle), "", "C", false)
+	}
+
+	const qrSide = 120.0
+	pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrPNG))
+	pdf.ImageOptions("qr", (pageWidth-qrSide)/2, pdf.GetY()+10, qrSide, qrSide, true, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.Ln(8)
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.MultiCell(contentWidth, 10, text(flyer.Caption), "", "C", false)
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.MultiCell(contentWidth, 7, text(flyer.URL), "", "C", false)
+	if flyer.Whatsapp != "" {
+		pdf.Ln(6)
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.MultiCell(contentWidth, 9, text("WhatsApp: "+flyer.Whatsapp), "", "C", false)
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // logo formats accepted by storage
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/falasefemi2/vendorhub/internal/cache"
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+const (
+	qrDefaultSize  = 512
+	qrDefaultLevel = "medium"
+	// qrLogoMaxBytes bounds the logo download, and qrLogoMaxPixels the
+	// decoded image, as a small compressed file can declare huge dimensions
+	qrLogoMaxBytes  = 5 << 20
+	qrLogoMaxPixels = 4096 * 4096
+	// qrLogoMaxSide is the resolution logos are scaled down to before
+	// being drawn or embedded
+	qrLogoMaxSide = 256
+	// Scaled logos are kept in memory for qrLogoCacheTTL, so codes don't
+	// download and decode them on every request. Logos that can't be
+	// used are remembered for qrLogoFailureTTL.
+	qrLogoCacheEntries = 1000
+	qrLogoCacheTTL     = time.Hour
+	qrLogoFailureTTL   = 5 * time.Minute
+)
+
+// QRCodeProducts looks up the products QR codes point at
+type QRCodeProducts interface {
+	GetProductBySlug(ctx context.Context, storeID, slug string) (*models.Product, error)
+}
+
+// QRCode is a rendered code or flyer ready to be served
+type QRCode struct {
+	ContentType string
+	Filename    string
+	Body        []byte
+}
+
+// QRCodeService renders QR codes for storefront links, for vendors to
+// print and show at physical markets
+type QRCodeService struct {
+	products     QRCodeProducts
+	storeService *StoreService
+	client       *http.Client
+	logos        cache.Cache
+	logger       *slog.Logger
+}
+
+func NewQRCodeService(products QRCodeProducts, storeService *StoreService, logger *slog.Logger) *QRCodeService {
+	return &QRCodeService{
+		products:     products,
+		storeService: storeService,
+		client:       &http.Client{Timeout: 5 * time.Second},
+		logos:        cache.NewLRU(qrLogoCacheEntries),
+		logger:       logger,
+	}
+}
+
+// StoreQRCode renders a code for a store's storefront link. The pdf format
+// is an A4 flyer with the store name and WhatsApp number.
+func (s *QRCodeService) StoreQRCode(ctx context.Context, storeSlug string, req dto.QRCodeRequest) (*QRCode, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	store, err := s.storeService.GetStoreBySlug(ctx, storeSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	flyer := qrFlyer{
+		Title:    store.Name,
+		Caption:  "Scan to shop",
+		URL:      s.storeService.StoreURL(store),
+		Whatsapp: store.WhatsappNumber,
+	}
+	return s.render(ctx, store, flyer, "qr-"+store.Slug, req)
+}
+
+// ProductQRCode renders a code for an active product's storefront link.
+// The pdf format is an A4 flyer with the product's name and current price
+// under the store name.
+func (s *QRCodeService) ProductQRCode(ctx context.Context, storeSlug, productSlug string, req dto.QRCodeRequest) (*QRCode, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	store, err := s.storeService.GetStoreBySlug(ctx, storeSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.products.GetProductBySlug(ctx, store.ID, productSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsActive {
+		return nil, utils.ErrProductNotFound
+	}
+
+	currency := models.CurrencyOf(product.Currency)
+	flyer := qrFlyer{
+		Title:    store.Name,
+		Subtitle: product.Name + " - " + feedPrice(currency, effectivePrice(product, time.Now())),
+		Caption:  "Scan to order",
+		URL:      s.storeService.ProductURL(store, product.Slug),
+		Whatsapp: store.WhatsappNumber,
+	}
+	return s.render(ctx, store, flyer, "qr-"+store.Slug+"-"+product.Slug, req)
+}
+
+// render encodes the flyer's URL in the requested format. A logo that
+// can't be loaded is left out rather than failing the code.
+func (s *QRCodeService) render(ctx context.Context, store *models.Store, flyer qrFlyer, name string, req dto.QRCodeRequest) (*QRCode, error) {
+	if req.Format == "" {
+		req.Format = "png"
+	}
+	if req.Size == 0 {
+		req.Size = qrDefaultSize
+	}
+	if req.ErrorCorrection == "" {
+		req.ErrorCorrection = qrDefaultLevel
+	}
+
+	var logo image.Image
+	if req.Logo && store.LogoURL != "" {
+		var err error
+		if logo, err = s.storeLogo(ctx, store); err != nil {
+			s.logger.WarnContext(ctx, "skipping store logo on QR code", "store_id", store.ID, "error", err)
+		}
+	}
+
+	code, err := newQRCode(flyer.URL, req.ErrorCorrection, logo != nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	switch req.Format {
+	case "svg":
+		var buf bytes.Buffer
+		if err := renderQRCodeSVG(&buf, code, req.Size, logo); err != nil {
+			return nil, fmt.Errorf("failed to write QR code: %w", err)
+		}
+		return &QRCode{ContentType: "image/svg+xml", Filename: name + ".svg", Body: buf.Bytes()}, nil
+	case "pdf":
+		qrPNG, err := renderQRCodePNG(code, qrFlyerImageSize, logo)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := writeQRFlyerPDF(&buf, flyer, qrPNG); err != nil {
+			return nil, fmt.Errorf("failed to write flyer: %w", err)
+		}
+		return &QRCode{ContentType: "application/pdf", Filename: name + ".pdf", Body: buf.Bytes()}, nil
+	default:
+		qrPNG, err := renderQRCodePNG(code, req.Size, logo)
+		if err != nil {
+			return nil, err
+		}
+		return &QRCode{ContentType: "image/png", Filename: name + ".png", Body: qrPNG}, nil
+	}
+}
+
+// storeLogo returns a store's logo scaled for QR codes, from the cache when
+// it was loaded recently. Logos are cached by URL, which changes when a new
+// logo is uploaded.
+func (s *QRCodeService) storeLogo(ctx context.Context, store *models.Store) (image.Image, error) {
+	key := store.ID + ":" + store.LogoURL
+	if data, ok, _ := s.logos.Get(ctx, key); ok {
+		if len(data) == 0 {
+			return nil, fmt.Errorf("logo %s failed to load recently", store.LogoURL)
+		}
+		return png.Decode(bytes.NewReader(data))
+	}
+
+	logo, err := s.fetchLogo(ctx, store.LogoURL)
+	if err != nil {
+		_ = s.logos.Set(ctx, key, nil, qrLogoFailureTTL)
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, logo); err == nil {
+		_ = s.logos.Set(ctx, key, buf.Bytes(), qrLogoCacheTTL)
+	}
+	return logo, nil
+}
+
+// fetchLogo downloads and decodes a store logo, scaled down to at most
+// qrLogoMaxSide pixels on its longer side. Logos over qrLogoMaxPixels are
+// rejected from their header, before being decoded.
+func (s *QRCodeService) fetchLogo(ctx context.Context, logoURL string) (image.Image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("logo request returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, qrLogoMaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download logo: %w", err)
+	}
+	if len(data) > qrLogoMaxBytes {
+		return nil, fmt.Errorf("logo is over %d bytes", qrLogoMaxBytes)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode logo: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Height > qrLogoMaxPixels/config.Width {
+		return nil, fmt.Errorf("logo is %dx%d pixels, over the limit of %d", config.Width, config.Height, qrLogoMaxPixels)
+	}
+
+	logo, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode logo: %w", err)
+	}
+
+	bounds := logo.Bounds()
+	if bounds.Dx() <= qrLogoMaxSide && bounds.Dy() <= qrLogoMaxSide {
+		return logo, nil
+	}
+	fit := fitRect(bounds, image.Rect(0, 0, qrLogoMaxSide, qrLogoMaxSide))
+	scaled := image.NewRGBA(image.Rect(0, 0, fit.Dx(), fit.Dy()))
+	xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), logo, bounds, xdraw.Src, nil)
+	return scaled, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGSize rewrites the dimensions in a PNG's header, as a crafted file
+// declaring a huge image would
+func withPNGSize(data []byte, width, height uint32) []byte {
+	data = bytes.Clone(data)
+	// The IHDR chunk follows the 8 byte signature: length, type, then
+	// width and height, with a CRC of the type and data after them
+	binary.BigEndian.PutUint32(data[16:], width)
+	binary.BigEndian.PutUint32(data[20:], height)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestQRCodeServiceStoreLogo(t *testing.T) {
+	logos := map[string][]byte{
+		"/wide.png":  encodeTestPNG(t, 600, 300),
+		"/small.png": encodeTestPNG(t, 64, 64),
+		"/bomb.png":  withPNGSize(encodeTestPNG(t, 1, 1), 100000, 100000),
+		"/text.png":  []byte("not an image"),
+	}
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		data, ok := logos[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	s := NewQRCodeService(nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		path     string
+		wantSize image.Point
+		wantErr  string
+	}{
+		{path: "/wide.png", wantSize: image.Pt(256, 128)},
+		{path: "/small.png", wantSize: image.Pt(64, 64)},
+		{path: "/bomb.png", wantErr: "100000x100000 pixels"},
+		{path: "/text.png", wantErr: "failed to decode logo"},
+		{path: "/missing.png", wantErr: "404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			store := &models.Store{ID: "s1", LogoURL: server.URL + tt.path}
+			for range 2 {
+				logo, err := s.storeLogo(context.Background(), store)
+				if tt.wantErr != "" {
+					if err == nil {
+						t.Fatalf("storeLogo() succeeded, want an error")
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("storeLogo() error = %v", err)
+				}
+				if got := logo.Bounds().Size(); got != tt.wantSize {
+					t.Errorf("logo size = %v, want %v", got, tt.wantSize)
+				}
+			}
+
+			if tt.wantErr != "" {
+				if _, err := s.fetchLogo(context.Background(), store.LogoURL); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("fetchLogo() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				requests[tt.path]--
+			}
+			// The second request, and failures, are served from the cache
+			if requests[tt.path] != 1 {
+				t.Errorf("logo downloaded %d times, want once", requests[tt.path])
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type ReviewRepository interface {
+	CreateReview(ctx context.Context, review *models.Review) (*models.Review, error)
+	GetByID(ctx context.Context, reviewID string) (*models.Review, error)
+	ListPublishedByProduct(ctx context.Context, productID string, limit, offset int) ([]*models.Review, error)
+	ListByStore(ctx context.Context, storeID string, limit, offset int) ([]*models.Review, error)
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]*models.Review, error)
+	UpdateReply(ctx context.Context, reviewID, reply string) (*models.Review, error)
+	UpdateStatus(ctx context.Context, reviewID, status, note string) (*models.Review, error)
+}
+
+// ProductLookup loads the product a review is left on
+type ProductLookup interface {
+	GetProductByID(ctx context.Context, productID string) (*models.Product, error)
+}
+
+type ReviewService struct {
+	reviewRepo ReviewRepository
+	products   ProductLookup
+	access     StoreAuthorizer
+}
+
+func NewReviewService(reviewRepo ReviewRepository, products ProductLookup, access StoreAuthorizer) *ReviewService {
+	return &ReviewService{
+		reviewRepo: reviewRepo,
+		products:   products,
+		access:     access,
+	}
+}
+
+// CreateReview publishes a buyer's review of an active product
+func (s *ReviewService) CreateReview(ctx context.Context, productID string, req dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	product, err := s.products.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsActive {
+		return nil, utils.ErrProductNotFound
+	}
+
+	review, err := s.reviewRepo.CreateReview(ctx, &models.Review{
+		ProductID:    product.ID,
+		StoreID:      product.StoreID,
+		ReviewerName: strings.TrimSpace(req.ReviewerName),
+		Rating:       req.Rating,
+		Comment:      strings.TrimSpace(req.Comment),
+		Status:       models.ReviewStatusPublished,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReviewToPublicResponse(review), nil
+}
+
+// ListProductReviews returns the published reviews of a product
+func (s *ReviewService) ListProductReviews(ctx context.Context, productID string, page, pageSize int) ([]*dto.ReviewResponse, error) {
+	page, pageSize = normalisePage(page, pageSize)
+
+	reviews, err := s.reviewRepo.ListPublishedByProduct(ctx, productID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = mapReviewToPublicResponse(review)
+	}
+	return responses, nil
+}
+
+// ListStoreReviews returns every review of a store's products, including
+// hidden ones, for the owner or staff allowed to manage the catalog.
+func (s *ReviewService) ListStoreReviews(ctx context.Context, userID, storeID string, page, pageSize int) ([]*dto.ReviewResponse, error) {
+	if storeID == "" {
+		defaultStoreID, err := s.access.DefaultStoreID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		storeID = defaultStoreID
+	}
+
+	if err := s.requireCatalogAccess(ctx, storeID, userID); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalisePage(page, pageSize)
+	reviews, err := s.reviewRepo.ListByStore(ctx, storeID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReviewsToResponse(reviews), nil
+}
+
+// ReplyToReview sets or, with an empty reply, removes the store's public
+// reply to a review.
+func (s *ReviewService) ReplyToReview(ctx context.Context, userID, reviewID string, req dto.ReviewReplyRequest) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requireCatalogAccess(ctx, review.StoreID, userID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.reviewRepo.UpdateReply(ctx, review.ID, strings.TrimSpace(req.Reply))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReviewToResponse(updated), nil
+}
+
+// ListReviewsForModeration returns reviews across all stores, optionally
+// filtered by status
+func (s *ReviewService) ListReviewsForModeration(ctx context.Context, status string, page, pageSize int) ([]*dto.ReviewResponse, error) {
+	if status != "" && status != models.ReviewStatusPublished && status != models.ReviewStatusHidden {
+		return nil, fmt.Errorf("%w: status must be published or hidden", utils.ErrInvalidOperation)
+	}
+
+	page, pageSize = normalisePage(page, pageSize)
+	reviews, err := s.reviewRepo.ListByStatus(ctx, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReviewsToResponse(reviews), nil
+}
+
+// HideReview removes a review from public listings and ratings
+func (s *ReviewService) HideReview(ctx context.Context, reviewID string, req dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	return s.setReviewStatus(ctx, reviewID, models.ReviewStatusHidden, req.Note)
+}
+
+// PublishReview restores a hidden review
+func (s *ReviewService) PublishReview(ctx context.Context, reviewID string, req dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	return s.setReviewStatus(ctx, reviewID, models.ReviewStatusPublished, req.Note)
+}
+
+func (s *ReviewService) setReviewStatus(ctx context.Context, reviewID, status, note string) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.UpdateStatus(ctx, reviewID, status, strings.TrimSpace(note))
+	if err != nil {
+		return nil, err
+	}
+	return mapReviewToResponse(review), nil
+}
+
+func (s *ReviewService) requireCatalogAccess(ctx context.Context, storeID, userID string) error {
+	allowed, err := s.access.CanAccessStore(ctx, storeID, userID, PermissionManageCatalog)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return utils.ErrForbidden
+	}
+	return nil
+}
+
+func normalisePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	return page, pageSize
+}
+
+func mapReviewToResponse(review *models.Review) *dto.ReviewResponse {
+	response := &dto.ReviewResponse{
+		ID:             review.ID,
+		ProductID:      review.ProductID,
+		StoreID:        review.StoreID,
+		ReviewerName:   review.ReviewerName,
+		Rating:         review.Rating,
+		Comment:        review.Comment,
+		Status:         review.Status,
+		ModerationNote: review.ModerationNote,
+		VendorReply:    review.VendorReply,
+		CreatedAt:      review.CreatedAt.Format(time.RFC3339),
+	}
+	if review.VendorRepliedAt != nil {
+		response.VendorRepliedAt = review.VendorRepliedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// mapReviewToPublicResponse omits moderation details shown only to the
+// store and admins
+func mapReviewToPublicResponse(review *models.Review) *dto.ReviewResponse {
+	response := mapReviewToResponse(review)
+	response.Status = ""
+	response.ModerationNote = ""
+	return response
+}
+
+func mapReviewsToResponse(reviews []*models.Review) []*dto.ReviewResponse {
+	responses := make([]*dto.ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = mapReviewToResponse(review)
+	}
+	return responses
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+const (
+	seoSiteName = "VendorHub"
+	// seoMaxDescription keeps descriptions within what previews show
+	seoMaxDescription = 200
+	// sitemapMaxURLs is the most URLs the sitemap protocol allows in one file
+	sitemapMaxURLs = 50000
+	// sitemapTTL is how long a generated sitemap is served
+	sitemapTTL = time.Hour
+)
+
+// SEOProducts looks up products for page metadata and the sitemap
+type SEOProducts interface {
+	GetProductBySlug(ctx context.Context, storeID, slug string) (*models.Product, error)
+	GetProductImages(ctx context.Context, productID string) ([]*models.ProductImage, error)
+	GetActiveProductsOfApprovedStores(ctx context.Context, limit int) ([]*models.Product, error)
+}
+
+// SEOService describes storefront pages for link previews and lists them
+// for search engines
+type SEOService struct {
+	products     SEOProducts
+	storeService *StoreService
+
+	mu                 sync.Mutex
+	sitemap            []byte
+	sitemapGeneratedAt time.Time
+}
+
+func NewSEOService(products SEOProducts, storeService *StoreService) *SEOService {
+	return &SEOService{products: products, storeService: storeService}
+}
+
+// GetStoreMeta returns the preview metadata of a store page. The cover
+// image is the store's banner, or its logo when it has none.
+func (s *SEOService) GetStoreMeta(ctx context.Context, storeSlug string) (*dto.PageMetaResponse, error) {
+	store, err := s.storeService.GetStoreBySlug(ctx, storeSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	description := store.Bio
+	if description == "" {
+		description = "Shop " + store.Name + " on " + seoSiteName
+	}
+	image := store.BannerURL
+	if image == "" {
+		image = store.LogoURL
+	}
+
+	return newPageMeta("website", store.Name, description, image, s.storeService.StoreURL(store), nil), nil
+}
+
+// GetProductMeta returns the preview metadata of an active product's page,
+// with the price a buyer pays now
+func (s *SEOService) GetProductMeta(ctx context.Context, storeSlug, productSlug string) (*dto.PageMetaResponse, error) {
+	store, err := s.storeService.GetStoreBySlug(ctx, storeSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.products.GetProductBySlug(ctx, store.ID, productSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsActive {
+		return nil, utils.ErrProductNotFound
+	}
+
+	images, err := s.products.GetProductImages(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	image := ""
+	if len(images) > 0 {
+		image = images[0].ImageURL
+	}
+
+	price := mapMoney(models.CurrencyOf(product.Currency), effectivePrice(product, time.Now()))
+	title := product.Name + " | " + store.Name
+	return newPageMeta("product", title, product.Description, image, s.storeService.ProductURL(store, product.Slug), &price), nil
+}
+
+// newPageMeta fills in a page's metadata and the Open Graph and Twitter
+// card tags that carry it
+func newPageMeta(pageType, title, description, image, url string, price *dto.Money) *dto.PageMetaResponse {
+	description = truncateRunes(description, seoMaxDescription)
+	meta := &dto.PageMetaResponse{
+		Type:        pageType,
+		Title:       title,
+		Description: description,
+		Image:       image,
+		URL:         url,
+		SiteName:    seoSiteName,
+		Price:       price,
+	}
+
+	card := "summary"
+	if image != "" {
+		card = "summary_large_image"
+	}
+	meta.Tags = []*dto.MetaTag{
+		{Property: "og:type", Content: pageType},
+		{Property: "og:site_name", Content: seoSiteName},
+		{Property: "og:title", Content: title},
+		{Property: "og:description", Content: description},
+		{Property: "og:url", Content: url},
+	}
+	if image != "" {
+		meta.Tags = append(meta.Tags, &dto.MetaTag{Property: "og:image", Content: image})
+	}
+	if price != nil {
+		meta.Tags = append(meta.Tags,
+			&dto.MetaTag{Property: "product:price:amount", Content: price.Value},
+			&dto.MetaTag{Property: "product:price:currency", Content: price.Currency},
+		)
+	}
+	meta.Tags = append(meta.Tags,
+		&dto.MetaTag{Name: "twitter:card", Content: card},
+		&dto.MetaTag{Name: "twitter:title", Content: title},
+		&dto.MetaTag{Name: "twitter:description", Content: description},
+	)
+	if image != "" {
+		meta.Tags = append(meta.Tags, &dto.MetaTag{Name: "twitter:image", Content: image})
+	}
+	return meta
+}
+
+// Sitemap returns sitemap.xml listing the stores of active vendors and
+// their active products, generating it at most once per sitemapTTL
+func (s *SEOService) Sitemap(ctx context.Context) ([]byte, time.Time, error) {
+	s.mu.Lock()
+	sitemap, generatedAt := s.sitemap, s.sitemapGeneratedAt
+	s.mu.Unlock()
+	if sitemap != nil && time.Since(generatedAt) < sitemapTTL {
+		return sitemap, generatedAt, nil
+	}
+
+	stores, err := s.storeService.ListApprovedStores(ctx, sitemapMaxURLs)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	products, err := s.products.GetActiveProductsOfApprovedStores(ctx, sitemapMaxURLs-len(stores))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := s.writeSitemap(&buf, stores, products); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to write sitemap: %w", err)
+	}
+
+	generatedAt = time.Now()
+	s.mu.Lock()
+	s.sitemap, s.sitemapGeneratedAt = buf.Bytes(), generatedAt
+	s.mu.Unlock()
+
+	return buf.Bytes(), generatedAt, nil
+}
+
+type sitemapURLSet struct {
+	XMLName   xml.Name     `xml:"urlset"`
+	Namespace string       `xml:"xmlns,attr"`
+	URLs      []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// writeSitemap lists each store followed by its products. Products of
+// stores not in stores are left out.
+func (s *SEOService) writeSitemap(w io.Writer, stores []*models.Store, products []*models.Product) error {
+	storeProducts := make(map[string][]*models.Product, len(stores))
+	for _, product := range products {
+		storeProducts[product.StoreID] = append(storeProducts[product.StoreID], product)
+	}
+
+	urlSet := sitemapURLSet{
+		Namespace: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:      make([]sitemapURL, 0, len(stores)+len(products)),
+	}
+	for _, store := range stores {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:     s.storeService.StoreURL(store),
+			LastMod: store.UpdatedAt.UTC().Format(time.DateOnly),
+		})
+		for _, product := range storeProducts[store.ID] {
+			urlSet.URLs = append(urlSet.URLs, sitemapURL{
+				Loc:     s.storeService.ProductURL(store, product.Slug),
+				LastMod: product.UpdatedAt.UTC().Format(time.DateOnly),
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(urlSet); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
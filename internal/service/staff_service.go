@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+const (
+	PermissionManageCatalog = "catalog:write"
+	PermissionManageOrders  = "orders:write"
+
+	// StoreRoleOwner is reported for stores a user owns rather than staffs
+	StoreRoleOwner = "owner"
+)
+
+// staffRolePermissions lists what each staff role may do inside a store.
+// Store owners implicitly hold every permission.
+var staffRolePermissions = map[string][]string{
+	models.StaffRoleCatalogEditor: {PermissionManageCatalog},
+	models.StaffRoleOrderManager:  {PermissionManageOrders},
+}
+
+func isValidStaffRole(role string) bool {
+	_, ok := staffRolePermissions[role]
+	return ok
+}
+
+func staffRoleAllows(role, permission string) bool {
+	for _, p := range staffRolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type StoreMemberRepository interface {
+	CreateInvite(ctx context.Context, member *models.StoreMember) (*models.StoreMember, error)
+	GetByID(ctx context.Context, memberID string) (*models.StoreMember, error)
+	GetByInviteTokenHash(ctx context.Context, tokenHash string) (*models.StoreMember, error)
+	GetActiveMembership(ctx context.Context, storeID, userID string) (*models.StoreMember, error)
+	ListByStore(ctx context.Context, storeID string) ([]*models.StoreMember, error)
+	ListByUser(ctx context.Context, userID string) ([]*models.StoreMember, error)
+	AcceptInvite(ctx context.Context, memberID, userID string) (*models.StoreMember, error)
+	UpdateRole(ctx context.Context, memberID, role string) (*models.StoreMember, error)
+	Delete(ctx context.Context, memberID string) error
+}
+
+type StaffService struct {
+	memberRepo   StoreMemberRepository
+	userRepo     UserRepository
+	storeService *StoreService
+	jwtSecret    string
+}
+
+func NewStaffService(memberRepo StoreMemberRepository, userRepo UserRepository, storeService *StoreService, jwtSecret string) *StaffService {
+	return &StaffService{memberRepo: memberRepo, userRepo: userRepo, storeService: storeService, jwtSecret: jwtSecret}
+}
+
+// CanAccessStore reports whether userID may perform an action requiring
+// permission on storeID, either as the store owner or as an active member.
+// Requests made with an API key are further limited to the key's store and
+// scopes.
+func (s *StaffService) CanAccessStore(ctx context.Context, storeID, userID, permission string) (bool, error) {
+	if storeID == "" || userID == "" {
+		return false, nil
+	}
+	if key, ok := utils.GetAPIKeyFromContext(ctx); ok && !key.Allows(storeID, permission) {
+		return false, nil
+	}
+
+	store, err := s.storeService.GetStoreByID(ctx, storeID)
+	if err != nil {
+		if errors.Is(err, utils.ErrStoreNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if store.OwnerID == userID {
+		return true, nil
+	}
+
+	member, err := s.memberRepo.GetActiveMembership(ctx, storeID, userID)
+	if err != nil {
+		if errors.Is(err, utils.ErrStoreMemberNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return staffRoleAllows(member.Role, permission), nil
+}
+
+// DefaultStoreID returns the store a user acts on when no store is given,
+// which is the primary store they own, or the key's store for requests made
+// with an API key.
+func (s *StaffService) DefaultStoreID(ctx context.Context, userID string) (string, error) {
+	if key, ok := utils.GetAPIKeyFromContext(ctx); ok {
+		return key.StoreID, nil
+	}
+	store, err := s.storeService.GetPrimaryStore(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return store.ID, nil
+}
+
+func (s *StaffService) InviteStaff(ctx context.Context, ownerID, storeID string, req dto.InviteStaffRequest) (*dto.InviteStaffResponse, error) {
+	if !isValidStaffRole(req.Role) {
+		return nil, fmt.Errorf("%w: unknown staff role %q", utils.ErrInvalidOperation, req.Role)
+	}
+
+	owner, err := s.userRepo.GetByID(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Role != "vendor" {
+		return nil, utils.ErrForbidden
+	}
+
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == strings.ToLower(owner.Email) {
+		return nil, fmt.Errorf("%w: cannot invite the store owner", utils.ErrInvalidOperation)
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite token: %w", err)
+	}
+
+	member, err := s.memberRepo.CreateInvite(ctx, &models.StoreMember{
+		StoreID:         store.ID,
+		Email:           email,
+		Role:            req.Role,
+		InviteTokenHash: utils.HashToken(token),
+		InvitedBy:       ownerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.InviteStaffResponse{
+		Member:      mapStoreMemberToResponse(member, store),
+		InviteToken: token,
+	}, nil
+}
+
+func (s *StaffService) ListStaff(ctx context.Context, ownerID, storeID string) ([]*dto.StoreMemberResponse, error) {
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.ListByStore(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.StoreMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = mapStoreMemberToResponse(member, store)
+	}
+	return responses, nil
+}
+
+func (s *StaffService) UpdateStaffRole(ctx context.Context, ownerID, memberID, role string) (*dto.StoreMemberResponse, error) {
+	if !isValidStaffRole(role) {
+		return nil, fmt.Errorf("%w: unknown staff role %q", utils.ErrInvalidOperation, role)
+	}
+
+	member, err := s.memberRepo.GetByID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, member.StoreID)
+	if err != nil {
+		return nil, utils.ErrStoreMemberNotFound
+	}
+
+	updated, err := s.memberRepo.UpdateRole(ctx, memberID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStoreMemberToResponse(updated, store), nil
+}
+
+func (s *StaffService) RemoveStaff(ctx context.Context, ownerID, memberID string) error {
+	member, err := s.memberRepo.GetByID(ctx, memberID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.storeService.GetOwnedStore(ctx, ownerID, member.StoreID); err != nil {
+		return utils.ErrStoreMemberNotFound
+	}
+
+	return s.memberRepo.Delete(ctx, memberID)
+}
+
+// AcceptInvite redeems an invite token. If the invited email already has an
+// account the password must match it; otherwise a staff account is created.
+func (s *StaffService) AcceptInvite(ctx context.Context, req dto.AcceptInviteRequest) (*dto.AuthResponse, error) {
+	member, err := s.memberRepo.GetByInviteTokenHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(member.Email)
+	if err == nil {
+		if !utils.ComparePassword(user.PasswordHash, req.Password) {
+			return nil, utils.ErrInvalidCredentials
+		}
+	} else if !errors.Is(err, utils.ErrUserNotFound) {
+		return nil, err
+	} else {
+		if req.Name == "" || req.Username == "" {
+			return nil, fmt.Errorf("%w: name and username are required", utils.ErrInvalidOperation)
+		}
+
+		hash, err := utils.HashPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err = s.userRepo.CreateUser(&models.User{
+			Name:         req.Name,
+			Email:        member.Email,
+			PasswordHash: hash,
+			Username:     req.Username,
+			Role:         "staff",
+			IsActive:     true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.memberRepo.AcceptInvite(ctx, member.ID, user.ID); err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateJwt(user, s.jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthResponse{
+		Token: token,
+		User: dto.AuthUser{
+			ID:       user.ID,
+			Name:     user.Name,
+			Email:    user.Email,
+			Username: user.Username,
+			Role:     user.Role,
+		},
+	}, nil
+}
+
+// ListMyStores returns the stores a user owns, with role "owner", followed
+// by the stores they have joined as staff.
+func (s *StaffService) ListMyStores(ctx context.Context, userID string) ([]*dto.StoreMemberResponse, error) {
+	owned, err := s.storeService.ListOwnedStores(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.StoreMemberResponse, 0, len(owned)+len(members))
+	for _, store := range owned {
+		responses = append(responses, &dto.StoreMemberResponse{
+			StoreID:   store.ID,
+			StoreName: store.Name,
+			StoreSlug: store.Slug,
+			UserID:    userID,
+			Email:     store.OwnerEmail,
+			Role:      StoreRoleOwner,
+			Status:    models.MemberStatusActive,
+			CreatedAt: store.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	for _, member := range members {
+		store, err := s.storeService.GetStoreByID(ctx, member.StoreID)
+		if err != nil {
+			continue
+		}
+		responses = append(responses, mapStoreMemberToResponse(member, store))
+	}
+	return responses, nil
+}
+
+func mapStoreMemberToResponse(member *models.StoreMember, store *models.Store) *dto.StoreMemberResponse {
+	response := &dto.StoreMemberResponse{
+		ID:        member.ID,
+		StoreID:   member.StoreID,
+		UserID:    member.UserID,
+		Email:     member.Email,
+		Role:      member.Role,
+		Status:    member.Status,
+		CreatedAt: member.CreatedAt.Format(time.RFC3339),
+	}
+	if member.AcceptedAt != nil {
+		response.AcceptedAt = member.AcceptedAt.Format(time.RFC3339)
+	}
+	if store != nil {
+		response.StoreName = store.Name
+		response.StoreSlug = store.Slug
+	}
+	return response
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// parseClock converts "HH:MM" into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseBusinessHours validates the requested weekly schedule and normalises
+// day names to lowercase.
+func parseBusinessHours(entries []dto.BusinessHours) ([]models.BusinessHours, error) {
+	hours := make([]models.BusinessHours, 0, len(entries))
+	for _, entry := range entries {
+		day := strings.ToLower(strings.TrimSpace(entry.Day))
+		if _, ok := weekdays[day]; !ok {
+			return nil, fmt.Errorf("invalid day %q", entry.Day)
+		}
+		opens, err := parseClock(entry.Opens)
+		if err != nil {
+			return nil, err
+		}
+		closes, err := parseClock(entry.Closes)
+		if err != nil {
+			return nil, err
+		}
+		if opens == closes {
+			return nil, fmt.Errorf("opening and closing times on %s must differ", day)
+		}
+		hours = append(hours, models.BusinessHours{Day: day, Opens: entry.Opens, Closes: entry.Closes})
+	}
+	return hours, nil
+}
+
+// storeLocation returns the store's timezone, falling back to the default
+// when it is unset or unknown.
+func storeLocation(store *models.Store) *time.Location {
+	if store.Timezone != "" {
+		if loc, err := time.LoadLocation(store.Timezone); err == nil {
+			return loc
+		}
+	}
+	loc, err := time.LoadLocation(models.DefaultStoreTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// isOnVacation reports whether vacation mode covers now. A missing start or
+// end leaves that side of the window open.
+func isOnVacation(store *models.Store, now time.Time) bool {
+	if !store.VacationMode {
+		return false
+	}
+	if store.VacationStartsAt != nil && now.Before(*store.VacationStartsAt) {
+		return false
+	}
+	if store.VacationEndsAt != nil && !now.Before(*store.VacationEndsAt) {
+		return false
+	}
+	return true
+}
+
+// isOpenNow reports whether the store is open at now. Stores without a
+// weekly schedule are treated as always open unless on vacation.
+func isOpenNow(store *models.Store, now time.Time) bool {
+	if isOnVacation(store, now) {
+		return false
+	}
+	if len(store.BusinessHours) == 0 {
+		return true
+	}
+
+	local := now.In(storeLocation(store))
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+
+	for _, interval := range store.BusinessHours {
+		day, ok := weekdays[interval.Day]
+		if !ok {
+			continue
+		}
+		opens, err := parseClock(interval.Opens)
+		if err != nil {
+			continue
+		}
+		closes, err := parseClock(interval.Closes)
+		if err != nil {
+			continue
+		}
+
+		if opens < closes {
+			if day == today && minute >= opens && minute < closes {
+				return true
+			}
+			continue
+		}
+
+		// Overnight interval: open from opens until midnight, then until
+		// closes on the following day.
+		if day == today && minute >= opens {
+			return true
+		}
+		if day == yesterday && minute < closes {
+			return true
+		}
+	}
+
+	return false
+}
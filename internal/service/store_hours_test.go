@@ -0,0 +1,123 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // as in the server, so the tests don't need zoneinfo
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+func TestParseBusinessHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []dto.BusinessHours
+		want    []models.BusinessHours
+		wantErr string
+	}{
+		{
+			name:    "normalises day names",
+			entries: []dto.BusinessHours{{Day: " Monday ", Opens: "09:00", Closes: "17:30"}},
+			want:    []models.BusinessHours{{Day: "monday", Opens: "09:00", Closes: "17:30"}},
+		},
+		{
+			name:    "overnight",
+			entries: []dto.BusinessHours{{Day: "friday", Opens: "22:00", Closes: "02:00"}},
+			want:    []models.BusinessHours{{Day: "friday", Opens: "22:00", Closes: "02:00"}},
+		},
+		{
+			name:    "unknown day",
+			entries: []dto.BusinessHours{{Day: "funday", Opens: "09:00", Closes: "17:00"}},
+			wantErr: `invalid day "funday"`,
+		},
+		{
+			name:    "bad time",
+			entries: []dto.BusinessHours{{Day: "monday", Opens: "9am", Closes: "17:00"}},
+			wantErr: `invalid time "9am", expected HH:MM`,
+		},
+		{
+			name:    "out of range time",
+			entries: []dto.BusinessHours{{Day: "monday", Opens: "09:00", Closes: "24:00"}},
+			wantErr: `invalid time "24:00"`,
+		},
+		{
+			name:    "zero length",
+			entries: []dto.BusinessHours{{Day: "monday", Opens: "09:00", Closes: "09:00"}},
+			wantErr: "opening and closing times on monday must differ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBusinessHours(tt.entries)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseBusinessHours() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || len(got) != len(tt.want) || got[0] != tt.want[0] {
+				t.Errorf("parseBusinessHours() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOpenNow(t *testing.T) {
+	weekdays := []models.BusinessHours{
+		{Day: "monday", Opens: "09:00", Closes: "17:00"},
+		{Day: "friday", Opens: "22:00", Closes: "02:00"},
+	}
+	// 2026-01-05 is a Monday; Lagos is UTC+1 and Nairobi UTC+3
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	vacationStart, vacationEnd := at(5, 0, 0), at(6, 0, 0)
+
+	tests := []struct {
+		name  string
+		store models.Store
+		now   time.Time
+		want  bool
+	}{
+		{name: "no schedule", store: models.Store{}, now: at(4, 3, 0), want: true},
+		{name: "before opening in Lagos", store: models.Store{BusinessHours: weekdays}, now: at(5, 7, 59), want: false},
+		{name: "at opening in Lagos", store: models.Store{BusinessHours: weekdays}, now: at(5, 8, 0), want: true},
+		{name: "at closing in Lagos", store: models.Store{BusinessHours: weekdays}, now: at(5, 16, 0), want: false},
+		{name: "store timezone", store: models.Store{BusinessHours: weekdays, Timezone: "Africa/Nairobi"}, now: at(5, 6, 0), want: true},
+		{name: "unknown timezone falls back to Lagos", store: models.Store{BusinessHours: weekdays, Timezone: "Mars/Olympus"}, now: at(5, 7, 30), want: false},
+		{name: "other day", store: models.Store{BusinessHours: weekdays}, now: at(6, 10, 0), want: false},
+		{name: "overnight before midnight", store: models.Store{BusinessHours: weekdays}, now: at(9, 21, 30), want: true},
+		{name: "overnight after midnight", store: models.Store{BusinessHours: weekdays}, now: at(10, 0, 30), want: true},
+		{name: "overnight after closing", store: models.Store{BusinessHours: weekdays}, now: at(10, 1, 0), want: false},
+		{name: "vacation", store: models.Store{VacationMode: true}, now: at(4, 12, 0), want: false},
+		{
+			name:  "during a vacation window",
+			store: models.Store{BusinessHours: weekdays, VacationMode: true, VacationStartsAt: &vacationStart, VacationEndsAt: &vacationEnd},
+			now:   at(5, 10, 0),
+			want:  false,
+		},
+		{
+			name:  "before a vacation window",
+			store: models.Store{VacationMode: true, VacationStartsAt: &vacationStart},
+			now:   at(4, 23, 0),
+			want:  true,
+		},
+		{
+			name:  "after a vacation window",
+			store: models.Store{VacationMode: true, VacationEndsAt: &vacationEnd},
+			now:   vacationEnd,
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOpenNow(&tt.store, tt.now); got != tt.want {
+				t.Errorf("isOpenNow(%v) = %t, want %t", tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/storage"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+type StoreRepository interface {
+	CreateStore(ctx context.Context, store *models.Store) (*models.Store, error)
+	GetByID(ctx context.Context, storeID string) (*models.Store, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Store, error)
+	GetByPreviousSlug(ctx context.Context, slug string) (*models.Store, error)
+	SlugInUse(ctx context.Context, slug, exceptStoreID string) (bool, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]*models.Store, error)
+	ListApproved(ctx context.Context, limit, offset int) ([]*models.Store, error)
+	SearchApproved(ctx context.Context, searchTerm string) ([]*models.Store, error)
+	UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error)
+	DeleteStore(ctx context.Context, storeID string) error
+}
+
+type StoreService struct {
+	storeRepo     StoreRepository
+	userRepo      UserRepository
+	storage       storage.Storage
+	storefrontURL string
+	catalogCache  *CatalogCache
+	logger        *slog.Logger
+}
+
+// NewStoreService returns a store service whose store links point at the
+// web storefront at storefrontURL
+func NewStoreService(storeRepo StoreRepository, userRepo UserRepository, storage storage.Storage, storefrontURL string, catalogCache *CatalogCache, logger *slog.Logger) *StoreService {
+	return &StoreService{storeRepo: storeRepo, userRepo: userRepo, storage: storage, storefrontURL: storefrontURL, catalogCache: catalogCache, logger: logger}
+}
+
+// StoreURL returns the storefront link of a store
+func (s *StoreService) StoreURL(store *models.Store) string {
+	return s.storefrontURL + "/stores/" + store.Slug
+}
+
+// ProductURL returns the storefront link of one of a store's products
+func (s *StoreService) ProductURL(store *models.Store, productSlug string) string {
+	return s.StoreURL(store) + "/products/" + productSlug
+}
+
+// CreateStoreForOwner creates a store for an existing user, using the
+// requested custom slug or one derived from the store name.
+func (s *StoreService) CreateStoreForOwner(ctx context.Context, ownerID string, req dto.CreateStoreRequest) (*models.Store, error) {
+	currency, err := lookupCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var slug string
+	if req.Slug != "" {
+		slug, err = s.claimSlug(ctx, req.Slug, "")
+	} else {
+		slug, err = s.allocateSlug(ctx, req.StoreName, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storeRepo.CreateStore(ctx, &models.Store{
+		OwnerID:        ownerID,
+		Name:           req.StoreName,
+		Slug:           slug,
+		Bio:            req.Bio,
+		WhatsappNumber: req.WhatsappNumber,
+		Currency:       currency.Code,
+	})
+}
+
+// CreateStore opens an additional store for an existing vendor
+func (s *StoreService) CreateStore(ctx context.Context, ownerID string, req dto.CreateStoreRequest) (*dto.StoreResponse, error) {
+	owner, err := s.userRepo.GetByID(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Role != "vendor" {
+		return nil, utils.ErrForbidden
+	}
+
+	if req.WhatsappNumber == "" {
+		req.WhatsappNumber = owner.WhatsappNumber
+	}
+
+	store, err := s.CreateStoreForOwner(ctx, ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return MapStoreToResponse(store), nil
+}
+
+// CheckSlugAvailable validates a custom slug and reports ErrSlugTaken if a
+// store uses or used to use it.
+func (s *StoreService) CheckSlugAvailable(ctx context.Context, slug string) error {
+	_, err := s.claimSlug(ctx, slug, "")
+	return err
+}
+
+// allocateSlug generates a slug from name that no store other than storeID
+// uses or used to use, skipping reserved words.
+func (s *StoreService) allocateSlug(ctx context.Context, name, storeID string) (string, error) {
+	baseSlug := utils.GenerateSlug(name)
+	if len(baseSlug) > utils.MaxSlugLength-4 {
+		baseSlug = strings.TrimRight(baseSlug[:utils.MaxSlugLength-4], "-")
+	}
+	if baseSlug == "" {
+		baseSlug = "store"
+	}
+
+	slug := baseSlug
+	for i := 2; ; i++ {
+		if !utils.IsReservedSlug(slug) {
+			inUse, err := s.storeRepo.SlugInUse(ctx, slug, storeID)
+			if err != nil {
+				return "", err
+			}
+			if !inUse {
+				return slug, nil
+			}
+		}
+		slug = baseSlug + "-" + strconv.Itoa(i)
+	}
+}
+
+// claimSlug normalises and validates a vendor-chosen slug for storeID
+func (s *StoreService) claimSlug(ctx context.Context, slug, storeID string) (string, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if err := utils.ValidateSlug(slug); err != nil {
+		return "", fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	inUse, err := s.storeRepo.SlugInUse(ctx, slug, storeID)
+	if err != nil {
+		return "", err
+	}
+	if inUse {
+		return "", utils.ErrSlugTaken
+	}
+	return slug, nil
+}
+
+// GetStoreBySlug looks a store up by its current slug, falling back to the
+// slugs it had before being renamed. Callers compare the returned store's
+// Slug with the requested one to detect a redirect.
+func (s *StoreService) GetStoreBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	store, err := s.storeRepo.GetBySlug(ctx, slug)
+	if errors.Is(err, utils.ErrStoreNotFound) {
+		return s.storeRepo.GetByPreviousSlug(ctx, slug)
+	}
+	return store, err
+}
+
+func (s *StoreService) GetStoreByID(ctx context.Context, storeID string) (*models.Store, error) {
+	return s.storeRepo.GetByID(ctx, storeID)
+}
+
+// GetPrimaryStore returns the oldest store owned by ownerID
+func (s *StoreService) GetPrimaryStore(ctx context.Context, ownerID string) (*models.Store, error) {
+	stores, err := s.storeRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stores) == 0 {
+		return nil, utils.ErrStoreNotFound
+	}
+	return stores[0], nil
+}
+
+// GetOwnedStore returns storeID if ownerID owns it, or the owner's primary
+// store when storeID is empty. Stores owned by someone else are reported as
+// not found.
+func (s *StoreService) GetOwnedStore(ctx context.Context, ownerID, storeID string) (*models.Store, error) {
+	if storeID == "" {
+		return s.GetPrimaryStore(ctx, ownerID)
+	}
+
+	store, err := s.storeRepo.GetByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if store.OwnerID != ownerID {
+		return nil, utils.ErrStoreNotFound
+	}
+	return store, nil
+}
+
+func (s *StoreService) ListOwnedStores(ctx context.Context, ownerID string) ([]*models.Store, error) {
+	return s.storeRepo.ListByOwner(ctx, ownerID)
+}
+
+func (s *StoreService) UpdateStore(ctx context.Context, ownerID, storeID string, req dto.UpdateStoreRequest) (*dto.StoreResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	store, err := s.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	nameChanged := false
+	if req.StoreName != nil {
+		if strings.TrimSpace(*req.StoreName) == "" {
+			return nil, fmt.Errorf("%w: store_name cannot be empty", utils.ErrInvalidOperation)
+		}
+		nameChanged = *req.StoreName != store.Name
+		store.Name = *req.StoreName
+	}
+	if req.Bio != nil {
+		store.Bio = *req.Bio
+	}
+	if req.WhatsappNumber != nil {
+		store.WhatsappNumber = *req.WhatsappNumber
+	}
+	if req.Currency != nil {
+		currency, err := lookupCurrency(*req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		store.Currency = currency.Code
+	}
+	if req.PrimaryColor != nil {
+		store.PrimaryColor = strings.ToUpper(*req.PrimaryColor)
+	}
+	if req.AccentColor != nil {
+		store.AccentColor = strings.ToUpper(*req.AccentColor)
+	}
+	if req.InstagramURL != nil {
+		store.InstagramURL = *req.InstagramURL
+	}
+	if req.TiktokURL != nil {
+		store.TiktokURL = *req.TiktokURL
+	}
+	if req.FacebookURL != nil {
+		store.FacebookURL = *req.FacebookURL
+	}
+	if req.Location != nil {
+		store.Location = strings.TrimSpace(*req.Location)
+	}
+	if req.OpeningHours != nil {
+		store.OpeningHours = strings.TrimSpace(*req.OpeningHours)
+	}
+	if req.DeliveryNotes != nil {
+		store.DeliveryNotes = strings.TrimSpace(*req.DeliveryNotes)
+	}
+	if req.Timezone != nil {
+		store.Timezone = *req.Timezone
+	}
+	if req.BusinessHours != nil {
+		hours, err := parseBusinessHours(*req.BusinessHours)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+		}
+		store.BusinessHours = hours
+	}
+	if req.VacationMode != nil {
+		store.VacationMode = *req.VacationMode
+	}
+	if req.VacationStartsAt != nil {
+		store.VacationStartsAt = parseOptionalTime(*req.VacationStartsAt)
+	}
+	if req.VacationEndsAt != nil {
+		store.VacationEndsAt = parseOptionalTime(*req.VacationEndsAt)
+	}
+	if req.VacationMessage != nil {
+		store.VacationMessage = strings.TrimSpace(*req.VacationMessage)
+	}
+	if req.BlockOrdersWhenClosed != nil {
+		store.BlockOrdersWhenClosed = *req.BlockOrdersWhenClosed
+	}
+	if store.VacationStartsAt != nil && store.VacationEndsAt != nil && !store.VacationEndsAt.After(*store.VacationStartsAt) {
+		return nil, fmt.Errorf("%w: vacation_ends_at must be after vacation_starts_at", utils.ErrInvalidOperation)
+	}
+
+	// A custom slug wins; otherwise renaming the store moves it to a slug
+	// derived from the new name. The old slug is kept as a redirect.
+	switch {
+	case req.Slug != nil:
+		slug, err := s.claimSlug(ctx, *req.Slug, store.ID)
+		if err != nil {
+			return nil, err
+		}
+		store.Slug = slug
+	case nameChanged:
+		slug, err := s.allocateSlug(ctx, store.Name, store.ID)
+		if err != nil {
+			return nil, err
+		}
+		store.Slug = slug
+	}
+
+	updated, err := s.storeRepo.UpdateStore(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	// Cached product lists are priced in the store's currency
+	s.catalogCache.InvalidateStore(ctx, store.ID)
+
+	return MapStoreToResponse(updated), nil
+}
+
+// SetStoreImage replaces a store's logo or banner with imageURL, which must
+// already be saved in storage. An empty imageURL removes the image. The file
+// previously in use is deleted from storage on a best-effort basis.
+func (s *StoreService) SetStoreImage(ctx context.Context, ownerID, storeID, kind, imageURL string) (*dto.StoreResponse, error) {
+	store, err := s.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousURL string
+	switch kind {
+	case models.StoreImageLogo:
+		previousURL, store.LogoURL = store.LogoURL, imageURL
+	case models.StoreImageBanner:
+		previousURL, store.BannerURL = store.BannerURL, imageURL
+	default:
+		return nil, fmt.Errorf("%w: unknown store image %q", utils.ErrInvalidOperation, kind)
+	}
+
+	updated, err := s.storeRepo.UpdateStore(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	s.catalogCache.InvalidateStore(ctx, store.ID)
+
+	if previousURL != "" && previousURL != imageURL {
+		if err := s.storage.DeleteFile(ctx, previousURL); err != nil {
+			s.logger.WarnContext(ctx, "failed to delete previous store image", "kind", kind, "url", previousURL, "error", err)
+		}
+	}
+
+	return MapStoreToResponse(updated), nil
+}
+
+func (s *StoreService) DeleteStore(ctx context.Context, ownerID, storeID string) error {
+	store, err := s.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return err
+	}
+	if err := s.storeRepo.DeleteStore(ctx, store.ID); err != nil {
+		return err
+	}
+	// The store's products went with it
+	s.catalogCache.InvalidateStore(ctx, store.ID)
+	return nil
+}
+
+// InvalidateOwnerCatalog drops the cached product lists of every store
+// ownerID owns, after a change to the owner's account such as approval
+func (s *StoreService) InvalidateOwnerCatalog(ctx context.Context, ownerID string) {
+	stores, err := s.storeRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to list stores to invalidate catalog cache", "owner_id", ownerID, "error", err)
+		return
+	}
+	for _, store := range stores {
+		s.catalogCache.InvalidateStore(ctx, store.ID)
+	}
+}
+
+func (s *StoreService) ListStores(ctx context.Context, page, pageSize int) ([]*dto.StoreResponse, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	stores, err := s.storeRepo.ListApproved(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return MapStoresToResponse(stores), nil
+}
+
+// ListApprovedStores returns up to limit stores of active vendors, oldest
+// first
+func (s *StoreService) ListApprovedStores(ctx context.Context, limit int) ([]*models.Store, error) {
+	return s.storeRepo.ListApproved(ctx, limit, 0)
+}
+
+func (s *StoreService) SearchStores(ctx context.Context, searchTerm string) ([]*dto.StoreResponse, error) {
+	if strings.TrimSpace(searchTerm) == "" {
+		return []*dto.StoreResponse{}, nil
+	}
+
+	stores, err := s.storeRepo.SearchApproved(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	return MapStoresToResponse(stores), nil
+}
+
+func MapStoreToResponse(store *models.Store) *dto.StoreResponse {
+	now := time.Now()
+	openNow := isOpenNow(store, now)
+
+	hours := make([]dto.BusinessHours, len(store.BusinessHours))
+	for i, interval := range store.BusinessHours {
+		hours[i] = dto.BusinessHours{Day: interval.Day, Opens: interval.Opens, Closes: interval.Closes}
+	}
+
+	response := &dto.StoreResponse{
+		ID:             store.ID,
+		OwnerID:        store.OwnerID,
+		Name:           store.Name,
+		Slug:           store.Slug,
+		Username:       store.OwnerUsername,
+		Bio:            store.Bio,
+		WhatsappNumber: store.WhatsappNumber,
+		Currency:       store.Currency,
+		Email:          store.OwnerEmail,
+		LogoURL:        store.LogoURL,
+		BannerURL:      store.BannerURL,
+		PrimaryColor:   store.PrimaryColor,
+		AccentColor:    store.AccentColor,
+		InstagramURL:   store.InstagramURL,
+		TiktokURL:      store.TiktokURL,
+		FacebookURL:    store.FacebookURL,
+		Location:       store.Location,
+		OpeningHours:   store.OpeningHours,
+		DeliveryNotes:  store.DeliveryNotes,
+
+		Timezone:              store.Timezone,
+		BusinessHours:         hours,
+		VacationMode:          store.VacationMode,
+		VacationMessage:       store.VacationMessage,
+		BlockOrdersWhenClosed: store.BlockOrdersWhenClosed,
+		OnVacation:            isOnVacation(store, now),
+		IsOpenNow:             openNow,
+		AcceptingOrders:       openNow || !store.BlockOrdersWhenClosed,
+		RatingAverage:         store.RatingAverage,
+		RatingCount:           store.RatingCount,
+
+		CreatedAt: store.CreatedAt.Format(time.RFC3339),
+	}
+	if store.VacationStartsAt != nil {
+		response.VacationStartsAt = store.VacationStartsAt.Format(time.RFC3339)
+	}
+	if store.VacationEndsAt != nil {
+		response.VacationEndsAt = store.VacationEndsAt.Format(time.RFC3339)
+	}
+
+	return response
+}
+
+// parseOptionalTime parses an already validated RFC 3339 timestamp, with an
+// empty value clearing it.
+func parseOptionalTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func MapStoresToResponse(stores []*models.Store) []*dto.StoreResponse {
+	responses := make([]*dto.StoreResponse, len(stores))
+	for i, store := range stores {
+		responses[i] = MapStoreToResponse(store)
+	}
+	return responses
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// fakeStoreRepo keeps stores by slug and by the slugs they had before.
+// Methods the tests don't use panic through the nil embedded interface.
+type fakeStoreRepo struct {
+	StoreRepository
+	bySlug         map[string]*models.Store
+	byPreviousSlug map[string]*models.Store
+}
+
+func (r *fakeStoreRepo) GetBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	if store, ok := r.bySlug[slug]; ok {
+		return store, nil
+	}
+	return nil, utils.ErrStoreNotFound
+}
+
+func (r *fakeStoreRepo) GetByPreviousSlug(ctx context.Context, slug string) (*models.Store, error) {
+	if store, ok := r.byPreviousSlug[slug]; ok {
+		return store, nil
+	}
+	return nil, utils.ErrStoreNotFound
+}
+
+func (r *fakeStoreRepo) SlugInUse(ctx context.Context, slug, exceptStoreID string) (bool, error) {
+	store, ok := r.bySlug[slug]
+	return ok && store.ID != exceptStoreID, nil
+}
+
+func TestStoreServiceAllocateSlug(t *testing.T) {
+	repo := &fakeStoreRepo{bySlug: map[string]*models.Store{
+		"pizza-hut":   {ID: "s1"},
+		"pizza-hut-2": {ID: "s2"},
+		"ada-co":      {ID: "s3"},
+	}}
+	service := NewStoreService(repo, nil, nil, "", nil, nil)
+
+	tests := []struct {
+		name    string
+		storeID string
+		want    string
+	}{
+		{name: "Mama's Kitchen", want: "mama-s-kitchen"},
+		{name: "Pizza Hut", want: "pizza-hut-3"},
+		{name: "Pizza Hut", storeID: "s1", want: "pizza-hut"},
+		{name: "Ada & Co", storeID: "s3", want: "ada-co"},
+		{name: "Admin", want: "admin-2"},
+		// "store" itself is reserved
+		{name: "!!!", want: "store-2"},
+		{name: strings.Repeat("long name ", 10), want: strings.TrimRight(strings.Repeat("long-name-", 6)[:utils.MaxSlugLength-4], "-")},
+	}
+
+	for _, tt := range tests {
+		got, err := service.allocateSlug(context.Background(), tt.name, tt.storeID)
+		if err != nil || got != tt.want {
+			t.Errorf("allocateSlug(%q, %q) = %q, %v, want %q", tt.name, tt.storeID, got, err, tt.want)
+		}
+	}
+}
+
+func TestStoreServiceClaimSlug(t *testing.T) {
+	repo := &fakeStoreRepo{bySlug: map[string]*models.Store{"pizza-hut": {ID: "s1"}}}
+	service := NewStoreService(repo, nil, nil, "", nil, nil)
+
+	tests := []struct {
+		slug    string
+		storeID string
+		want    string
+		wantErr error
+	}{
+		{slug: " Mama-Kitchen ", want: "mama-kitchen"},
+		{slug: "pizza-hut", storeID: "s1", want: "pizza-hut"},
+		{slug: "pizza-hut", storeID: "s2", wantErr: utils.ErrSlugTaken},
+		{slug: "admin", wantErr: utils.ErrInvalidOperation},
+		{slug: "mama kitchen", wantErr: utils.ErrInvalidOperation},
+	}
+
+	for _, tt := range tests {
+		got, err := service.claimSlug(context.Background(), tt.slug, tt.storeID)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("claimSlug(%q, %q) = %q, %v, want %q, %v", tt.slug, tt.storeID, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestStoreServiceGetStoreBySlug(t *testing.T) {
+	store := &models.Store{ID: "s1", Slug: "pizza-hut-lagos"}
+	repo := &fakeStoreRepo{
+		bySlug:         map[string]*models.Store{"pizza-hut-lagos": store},
+		byPreviousSlug: map[string]*models.Store{"pizza-hut": store},
+	}
+	service := NewStoreService(repo, nil, nil, "", nil, nil)
+
+	tests := []struct {
+		slug    string
+		wantErr error
+	}{
+		{slug: "pizza-hut-lagos"},
+		{slug: "pizza-hut"},
+		{slug: "unknown", wantErr: utils.ErrStoreNotFound},
+	}
+
+	for _, tt := range tests {
+		got, err := service.GetStoreBySlug(context.Background(), tt.slug)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("GetStoreBySlug(%q) error = %v, want %v", tt.slug, err, tt.wantErr)
+		}
+		if err == nil && got != store {
+			t.Errorf("GetStoreBySlug(%q) = %+v, want the renamed store", tt.slug, got)
+		}
+	}
+}
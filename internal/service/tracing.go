@@ -0,0 +1,7 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer records spans for service calls, so traces show the time spent
+// in each between the request and its queries
+var tracer = otel.Tracer("github.com/falasefemi2/vendorhub/internal/service")
@@ -3,9 +3,6 @@ package service
 import (
 	"context"
 	"errors"
-	"strconv"
-	"strings"
-	"time"
 
 	"github.com/falasefemi2/vendorhub/internal/dto"
 	"github.com/falasefemi2/vendorhub/internal/models"
@@ -17,44 +14,45 @@ type UserRepository interface {
 	GetByEmail(email string) (*models.User, error)
 	GetByID(id string) (*models.User, error)
 	ApproveVendor(id string) error
-	GetByStoreSlug(slug string) (*models.User, error)
-	UpdateStoreSettings(userID, storeName, storeSlug, bio, whatsapp string) error
-	GetApprovedVendors() ([]models.User, error)
 }
 
 type AuthService struct {
-	userRepo  UserRepository
-	jwtSecret string
+	userRepo     UserRepository
+	storeService *StoreService
+	jwtSecret    string
 }
 
-func NewAuthService(userRepo UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo UserRepository, storeService *StoreService, jwtSecret string) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:     userRepo,
+		storeService: storeService,
+		jwtSecret:    jwtSecret,
 	}
 }
 
 func (s *AuthService) SignUp(req dto.SignUpRequest) (*dto.AuthResponse, error) {
 	_, err := s.userRepo.GetByEmail(req.Email)
 	if err == nil {
-		return nil, errors.New("email already exists")
+		return nil, utils.ErrEmailTaken
 	}
-
-	hash, err := utils.HashPassword(req.Password)
-	if err != nil {
+	if !errors.Is(err, utils.ErrUserNotFound) {
 		return nil, err
 	}
 
-	// generate slug and ensure uniqueness
-	baseSlug := utils.GenerateSlug(req.StoreName)
-	slug := baseSlug
-	i := 1
-	for {
-		if existing, _ := s.userRepo.GetByStoreSlug(slug); existing == nil {
-			break
+	// Check a custom slug and the currency up front so a bad one doesn't
+	// leave a vendor account without a store.
+	if req.StoreSlug != "" {
+		if err := s.storeService.CheckSlugAvailable(context.Background(), req.StoreSlug); err != nil {
+			return nil, err
 		}
-		i++
-		slug = baseSlug + "-" + strconv.Itoa(i)
+	}
+	if _, err := lookupCurrency(req.StoreCurrency); err != nil {
+		return nil, err
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
 	}
 
 	user := &models.User{
@@ -64,8 +62,6 @@ func (s *AuthService) SignUp(req dto.SignUpRequest) (*dto.AuthResponse, error) {
 		WhatsappNumber: req.WhatsappNumber,
 		Username:       req.Username,
 		Bio:            req.Bio,
-		StoreName:      req.StoreName,
-		StoreSlug:      slug,
 		Role:           "vendor",
 		IsActive:       false,
 	}
@@ -75,14 +71,25 @@ func (s *AuthService) SignUp(req dto.SignUpRequest) (*dto.AuthResponse, error) {
 		return nil, err
 	}
 
+	store, err := s.storeService.CreateStoreForOwner(context.Background(), createdUser.ID, dto.CreateStoreRequest{
+		StoreName:      req.StoreName,
+		Slug:           req.StoreSlug,
+		Currency:       req.StoreCurrency,
+		Bio:            req.Bio,
+		WhatsappNumber: req.WhatsappNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	authUser := dto.AuthUser{
 		ID:             createdUser.ID,
 		Name:           createdUser.Name,
 		Email:          createdUser.Email,
 		Username:       createdUser.Username,
 		Role:           createdUser.Role,
-		StoreName:      createdUser.StoreName,
-		StoreSlug:      createdUser.StoreSlug,
+		StoreName:      store.Name,
+		StoreSlug:      store.Slug,
 		WhatsappNumber: createdUser.WhatsappNumber,
 		Bio:            createdUser.Bio,
 	}
@@ -109,26 +116,14 @@ func (s *AuthService) Login(req dto.LoginRequest) (*dto.AuthResponse, error) {
 		return nil, utils.ErrInvalidCredentials
 	}
 
-	token, err := utils.GenerateJwt(user)
+	token, err := utils.GenerateJwt(user, s.jwtSecret)
 	if err != nil {
 		return nil, err
 	}
 
-	authUser := dto.AuthUser{
-		ID:             user.ID,
-		Name:           user.Name,
-		Email:          user.Email,
-		Username:       user.Username,
-		StoreName:      user.StoreName,
-		StoreSlug:      user.StoreSlug,
-		Role:           user.Role,
-		WhatsappNumber: user.WhatsappNumber,
-		Bio:            user.Bio,
-	}
-
 	return &dto.AuthResponse{
 		Token: token,
-		User:  authUser,
+		User:  *s.mapAuthUser(user),
 	}, nil
 }
 
@@ -141,134 +136,30 @@ func (s *AuthService) GetMyProfile(id string) (*dto.AuthUser, error) {
 		return nil, err
 	}
 
+	return s.mapAuthUser(user), nil
+}
+
+// mapAuthUser builds the auth payload for user, filling the store fields
+// from the user's primary store when they own one.
+func (s *AuthService) mapAuthUser(user *models.User) *dto.AuthUser {
 	authUser := &dto.AuthUser{
 		ID:             user.ID,
 		Name:           user.Name,
 		Email:          user.Email,
 		Username:       user.Username,
-		StoreName:      user.StoreName,
-		StoreSlug:      user.StoreSlug,
 		Role:           user.Role,
 		WhatsappNumber: user.WhatsappNumber,
 		Bio:            user.Bio,
 	}
 
-	return authUser, nil
-}
-
-func (s *AuthService) GetUserByID(id string) (*models.User, error) {
-	return s.userRepo.GetByID(id)
-}
-
-func (s *AuthService) GetVendorBySlug(slug string) (*models.User, error) {
-	return s.userRepo.GetByStoreSlug(slug)
-}
-
-func (s *AuthService) UpdateVendorStore(ctx context.Context, userID string, req dto.UpdateStoreRequest) (*dto.StoreResponse, error) {
-	user, err := s.userRepo.GetByID(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	storeName := user.StoreName
-	if req.StoreName != nil {
-		storeName = *req.StoreName
+	if store, err := s.storeService.GetPrimaryStore(context.Background(), user.ID); err == nil {
+		authUser.StoreName = store.Name
+		authUser.StoreSlug = store.Slug
 	}
 
-	username := user.Username
-	if req.Username != nil {
-		username = *req.Username
-	}
-
-	bio := user.Bio
-	if req.Bio != nil {
-		bio = *req.Bio
-	}
-
-	whatsapp := user.WhatsappNumber
-	if req.WhatsappNumber != nil {
-		whatsapp = *req.WhatsappNumber
-	}
-
-	email := user.Email
-	if req.Email != nil {
-		email = *req.Email
-	}
-
-	storeSlug := utils.GenerateSlug(storeName)
-	if storeSlug == "" {
-		storeSlug = user.StoreSlug
-	}
-
-	err = s.userRepo.UpdateStoreSettings(userID, storeName, storeSlug, bio, whatsapp)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.StoreResponse{
-		ID:             user.ID,
-		Name:           storeName,
-		Slug:           storeSlug,
-		Username:       username,
-		Bio:            bio,
-		WhatsappNumber: whatsapp,
-		Email:          email,
-		CreatedAt:      user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return authUser
 }
 
-func (s *AuthService) GetAllActiveVendors(page, pageSize int) ([]*models.User, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 {
-		pageSize = 20
-	}
-
-	vendors, err := s.userRepo.GetApprovedVendors()
-	if err != nil {
-		return nil, err
-	}
-
-	// convert to []*models.User
-	vendorPtrs := make([]*models.User, len(vendors))
-	for i := range vendors {
-		vendorPtrs[i] = &vendors[i]
-	}
-
-	// pagination
-	start := (page - 1) * pageSize
-	if start >= len(vendorPtrs) {
-		return []*models.User{}, nil
-	}
-	end := start + pageSize
-	if end > len(vendorPtrs) {
-		end = len(vendorPtrs)
-	}
-
-	return vendorPtrs[start:end], nil
-}
-
-func (s *AuthService) SearchVendors(searchTerm string) ([]*models.User, error) {
-	if strings.TrimSpace(searchTerm) == "" {
-		return []*models.User{}, nil
-	}
-
-	vendors, err := s.userRepo.GetApprovedVendors()
-	if err != nil {
-		return nil, err
-	}
-
-	lower := strings.ToLower(searchTerm)
-	var results []*models.User
-	for i := range vendors {
-		v := &vendors[i]
-		if strings.Contains(strings.ToLower(v.StoreName), lower) ||
-			strings.Contains(strings.ToLower(v.Username), lower) ||
-			strings.Contains(strings.ToLower(v.Name), lower) {
-			results = append(results, v)
-		}
-	}
-
-	return results, nil
+func (s *AuthService) GetUserByID(id string) (*models.User, error) {
+	return s.userRepo.GetByID(id)
 }
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+const (
+	// webhookMaxAttempts is how many times a delivery is tried before it
+	// is marked failed; with the backoff below that spans about 8 hours
+	webhookMaxAttempts = 10
+	webhookBaseDelay   = time.Minute
+	webhookMaxDelay    = 6 * time.Hour
+	// webhookBatchSize deliveries are claimed per run and held for
+	// webhookLease, longer than sending a batch can take at webhookTimeout
+	// each, so no other run picks them up meanwhile
+	webhookBatchSize = 20
+	webhookTimeout   = 10 * time.Second
+	webhookLease     = 5 * time.Minute
+	// webhookRetention is how long finished deliveries stay in the log
+	webhookRetention = 30 * 24 * time.Hour
+)
+
+// webhookBackoff returns the wait after the given number of failed
+// attempts: 1m, 2m, 4m and so on, capped at webhookMaxDelay
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookBaseDelay
+	for i := 1; i < attempts && delay < webhookMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxDelay)
+}
+
+// signWebhook returns the X-VendorHub-Signature header for body sent at
+// timestamp: "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">"
+func signWebhook(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// nonPublicPrefixes are the special-purpose address ranges webhooks aren't
+// delivered to, from the IANA registries. IPv6 ranges that embed an IPv4
+// address, such as NAT64 and 6to4, are refused whole, as the embedded
+// address may be a private one.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT, also used by cloud VPCs
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local, including cloud metadata
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, and broadcast
+	netip.MustParsePrefix("::/96"),           // unspecified, loopback and IPv4-compatible
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local NAT64
+	netip.MustParsePrefix("100::/64"),        // discard
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, including Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("fc00::/7"),        // unique local
+	netip.MustParsePrefix("fe80::/10"),       // link-local
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+// isPublicAddress reports whether addr is outside nonPublicPrefixes. IPv4
+// addresses written in their IPv4-mapped IPv6 form are checked as IPv4.
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return addr.IsValid()
+}
+
+// newWebhookClient returns the HTTP client deliveries are sent with. It
+// doesn't follow redirects, and unless allowPrivateNetworks is set it
+// refuses to connect to addresses that aren't public, checked after DNS
+// resolution so a hostname can't point it at an internal service.
+func newWebhookClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("webhook address %s is not public", addrPort.Addr())
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// DeliverDue sends the deliveries that are due and records the outcome of
+// each, then prunes old finished deliveries
+func (s *WebhookService) DeliverDue(ctx context.Context) error {
+	deliveries, err := s.repo.ClaimDue(ctx, webhookBatchSize, webhookLease)
+	if err != nil {
+		return err
+	}
+
+	endpoints := make(map[string]*models.WebhookEndpoint)
+	for _, delivery := range deliveries {
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			if endpoint, err = s.repo.GetEndpoint(ctx, delivery.EndpointID); err != nil {
+				s.logger.ErrorContext(ctx, "failed to load webhook endpoint", "endpoint_id", delivery.EndpointID, "error", err)
+				continue
+			}
+			endpoints[endpoint.ID] = endpoint
+		}
+
+		s.attempt(ctx, endpoint, delivery)
+		if err := s.repo.RecordAttempt(ctx, delivery); err != nil {
+			s.logger.ErrorContext(ctx, "failed to record webhook attempt", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+
+	_, err = s.repo.PruneDeliveriesBefore(ctx, time.Now().Add(-webhookRetention))
+	return err
+}
+
+// attempt sends a delivery once and updates it with the outcome and, on
+// failure, when to retry
+func (s *WebhookService) attempt(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = nil
+	delivery.LastError = ""
+
+	if !endpoint.IsActive {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = "endpoint is disabled"
+		return
+	}
+
+	statusCode, err := s.send(ctx, endpoint, delivery, now)
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+}
+
+// send POSTs a delivery's payload to the endpoint and returns the response
+// status. Any 2xx status counts as delivered.
+func (s *WebhookService) send(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "VendorHub-Webhooks/1.0")
+	req.Header.Set("X-VendorHub-Event", delivery.EventType)
+	req.Header.Set("X-VendorHub-Delivery", delivery.ID)
+	req.Header.Set("X-VendorHub-Signature", signWebhook(endpoint.Secret, now, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The log belongs to the endpoint, so drop the URL the error repeats
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+	message := fmt.Sprintf("endpoint responded with HTTP %d", resp.StatusCode)
+	if text := strings.TrimSpace(string(snippet)); text != "" {
+		message += ": " + text
+	}
+	return resp.StatusCode, errors.New(message)
+}
+
+// StartDeliveryJob runs DeliverDue every interval until ctx is cancelled
+func (s *WebhookService) StartDeliveryJob(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+				s.logger.ErrorContext(ctx, "webhook delivery failed", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
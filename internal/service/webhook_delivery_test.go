@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+// verifyWebhookSignature checks a signature header the way the README
+// tells receivers to
+func verifyWebhookSignature(header, secret string, body []byte) bool {
+	t, v1, ok := strings.Cut(header, ",v1=")
+	t, ok2 := strings.CutPrefix(t, "t=")
+	if !ok || !ok2 {
+		return false
+	}
+	expected, err := hex.DecodeString(v1)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "." + string(body)))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"type":"order.created","data":{"id":"o1"}}`)
+	timestamp := time.Unix(1767607200, 0)
+	header := signWebhook("whsec_test", timestamp, body)
+
+	// Computed independently, so receivers' code can be checked against it
+	const want = "t=1767607200,v1=bacea479cdb8bfe84ef4699823cda05c821257383f4031f0a683bcf5d68ade59"
+	if header != want {
+		t.Fatalf("signWebhook() = %q, want %q", header, want)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		secret string
+		body   []byte
+		want   bool
+	}{
+		{name: "valid", header: header, secret: "whsec_test", body: body, want: true},
+		{name: "other secret", header: header, secret: "whsec_other", body: body},
+		{name: "tampered body", header: header, secret: "whsec_test", body: []byte(`{"type":"order.created","data":{"id":"o2"}}`)},
+		{name: "replayed with another timestamp", header: strings.Replace(header, "t=1767607200", "t=1767607260", 1), secret: "whsec_test", body: body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyWebhookSignature(tt.header, tt.secret, tt.body); got != tt.want {
+				t.Errorf("verify = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 3, want: 4 * time.Minute},
+		{attempts: 9, want: 256 * time.Minute},
+		{attempts: 10, want: webhookMaxDelay},
+		{attempts: 50, want: webhookMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := webhookBackoff(tt.attempts); got != tt.want {
+			t.Errorf("webhookBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookAttempt(t *testing.T) {
+	var received http.Header
+	var receivedBody []byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		io.WriteString(w, "  try later  ")
+	}))
+	defer server.Close()
+
+	service := NewWebhookService(nil, nil, true, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	endpoint := &models.WebhookEndpoint{URL: server.URL, Secret: "whsec_test", IsActive: true}
+	newDelivery := func(attempts int) *models.WebhookDelivery {
+		return &models.WebhookDelivery{ID: "d1", EventType: "order.created", Payload: []byte(`{"id":"o1"}`), Attempts: attempts}
+	}
+
+	t.Run("delivered", func(t *testing.T) {
+		status = http.StatusNoContent
+		delivery := newDelivery(0)
+		service.attempt(context.Background(), endpoint, delivery)
+
+		if delivery.Status != models.WebhookDeliverySucceeded || delivery.DeliveredAt == nil || delivery.Attempts != 1 {
+			t.Fatalf("delivery = %+v, want succeeded", delivery)
+		}
+		if received.Get("X-VendorHub-Event") != "order.created" || received.Get("X-VendorHub-Delivery") != "d1" {
+			t.Errorf("headers = %v", received)
+		}
+		if !verifyWebhookSignature(received.Get("X-VendorHub-Signature"), "whsec_test", receivedBody) {
+			t.Errorf("signature %q doesn't verify", received.Get("X-VendorHub-Signature"))
+		}
+	})
+
+	t.Run("retried", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		delivery := newDelivery(2)
+		before := time.Now()
+		service.attempt(context.Background(), endpoint, delivery)
+
+		if delivery.Status == models.WebhookDeliverySucceeded || delivery.Status == models.WebhookDeliveryFailed {
+			t.Fatalf("Status = %q, want it left to retry", delivery.Status)
+		}
+		if delivery.LastStatusCode == nil || *delivery.LastStatusCode != http.StatusServiceUnavailable ||
+			delivery.LastError != "endpoint responded with HTTP 503: try later" {
+			t.Errorf("delivery = %+v", delivery)
+		}
+		if wait := delivery.NextAttemptAt.Sub(before); wait < 4*time.Minute || wait > 4*time.Minute+time.Second {
+			t.Errorf("next attempt in %v, want 4m", wait)
+		}
+	})
+
+	t.Run("out of attempts", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		delivery := newDelivery(webhookMaxAttempts - 1)
+		service.attempt(context.Background(), endpoint, delivery)
+
+		if delivery.Status != models.WebhookDeliveryFailed {
+			t.Errorf("Status = %q, want failed", delivery.Status)
+		}
+	})
+
+	t.Run("disabled endpoint", func(t *testing.T) {
+		received = nil
+		delivery := newDelivery(0)
+		service.attempt(context.Background(), &models.WebhookEndpoint{URL: server.URL, IsActive: false}, delivery)
+
+		if delivery.Status != models.WebhookDeliveryFailed || received != nil {
+			t.Errorf("delivery = %+v, sent %t, want failed without sending", delivery, received != nil)
+		}
+	})
+}
+
+func TestWebhookClientRefusesNonPublicAddresses(t *testing.T) {
+	client := newWebhookClient(false)
+
+	addresses := []string{
+		"0.0.0.1",
+		"10.1.2.3",
+		"100.64.0.1",
+		"100.127.255.254",
+		"127.0.0.1",
+		"169.254.169.254",
+		"172.16.0.1",
+		"192.0.0.8",
+		"192.168.1.1",
+		"198.18.0.1",
+		"198.19.255.255",
+		"240.0.0.1",
+		"255.255.255.255",
+		"[::1]",
+		"[::]",
+		"[::ffff:100.64.0.1]",
+		"[::ffff:169.254.169.254]",
+		"[::ffff:127.0.0.1]",
+		"[64:ff9b::a9fe:a9fe]",
+		"[64:ff9b:1::a00:1]",
+		"[2002:a9fe:a9fe::1]",
+		"[2001::a9fe:a9fe]",
+		"[fd00::1]",
+		"[fe80::1]",
+	}
+
+	for _, address := range addresses {
+		t.Run(address, func(t *testing.T) {
+			resp, err := client.Get("http://" + address + ":8080/hook")
+			if err == nil {
+				resp.Body.Close()
+				t.Fatal("delivery succeeded, want it refused")
+			}
+			if !strings.Contains(err.Error(), "is not public") {
+				t.Errorf("error = %v, want the address refused", err)
+			}
+		})
+	}
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	for _, address := range []string{"8.8.8.8", "100.128.0.1", "198.20.0.1", "2606:4700:4700::1111", "::ffff:8.8.8.8"} {
+		if !isPublicAddress(netip.MustParseAddr(address)) {
+			t.Errorf("isPublicAddress(%s) = false, want true", address)
+		}
+	}
+	if isPublicAddress(netip.MustParseAddr("fe80::1%eth0")) {
+		t.Errorf("isPublicAddress(%s) = true, want zoned link-local addresses refused", "fe80::1%eth0")
+	}
+}
+
+func TestWebhookClientRefusesPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := newWebhookClient(false).Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err == nil {
+		t.Fatal("delivery to a loopback address succeeded")
+	}
+
+	resp, err := newWebhookClient(true).Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("delivery with private networks allowed failed: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/falasefemi2/vendorhub/internal/dto"
+	"github.com/falasefemi2/vendorhub/internal/models"
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+// EventPublisher notifies a store's integrations that something happened
+// in it. Publishing never fails the operation that triggered it.
+type EventPublisher interface {
+	Publish(ctx context.Context, storeID, eventType string, data any)
+}
+
+// EventPublishers passes each event to every publisher in turn
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(ctx context.Context, storeID, eventType string, data any) {
+	for _, publisher := range p {
+		publisher.Publish(ctx, storeID, eventType, data)
+	}
+}
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) (*models.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, endpointID string) (*models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, storeID string) ([]*models.WebhookEndpoint, error)
+	ListSubscribed(ctx context.Context, storeID, eventType string) ([]*models.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) (*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, endpointID string) error
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, endpointID, status string, limit, offset int) ([]*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error)
+	PruneDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type WebhookService struct {
+	repo         WebhookRepository
+	storeService *StoreService
+	client       *http.Client
+	logger       *slog.Logger
+}
+
+// NewWebhookService creates the webhook service. Unless allowPrivateNetworks
+// is set, deliveries to addresses that aren't public are refused so
+// vendors can't point webhooks at internal services.
+func NewWebhookService(repo WebhookRepository, storeService *StoreService, allowPrivateNetworks bool, logger *slog.Logger) *WebhookService {
+	return &WebhookService{
+		repo:         repo,
+		storeService: storeService,
+		client:       newWebhookClient(allowPrivateNetworks),
+		logger:       logger,
+	}
+}
+
+// CreateEndpoint registers a webhook endpoint for a store owned by ownerID.
+// The response carries the signing secret, which isn't shown again.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, ownerID string, req dto.CreateWebhookRequest) (*dto.WebhookEndpointResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	events, err := normaliseWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := &models.WebhookEndpoint{
+		StoreID:     store.ID,
+		URL:         req.URL,
+		Secret:      secret,
+		Events:      events,
+		Description: strings.TrimSpace(req.Description),
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+
+	created, err := s.repo.CreateEndpoint(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapWebhookEndpointToResponse(created)
+	response.Secret = created.Secret
+	return response, nil
+}
+
+// ListEndpoints returns the webhook endpoints of a store owned by ownerID
+func (s *WebhookService) ListEndpoints(ctx context.Context, ownerID, storeID string) ([]*dto.WebhookEndpointResponse, error) {
+	store, err := s.storeService.GetOwnedStore(ctx, ownerID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.repo.ListEndpoints(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.WebhookEndpointResponse, len(endpoints))
+	for i, endpoint := range endpoints {
+		responses[i] = mapWebhookEndpointToResponse(endpoint)
+	}
+	return responses, nil
+}
+
+// UpdateEndpoint changes a webhook endpoint of a store owned by ownerID
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, ownerID, endpointID string, req dto.UpdateWebhookRequest) (*dto.WebhookEndpointResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrInvalidOperation, err)
+	}
+
+	endpoint, err := s.getOwnedEndpoint(ctx, ownerID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		endpoint.URL = *req.URL
+	}
+	if req.Events != nil {
+		if endpoint.Events, err = normaliseWebhookEvents(req.Events); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		endpoint.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+	if req.RotateSecret {
+		if endpoint.Secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.repo.UpdateEndpoint(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapWebhookEndpointToResponse(updated)
+	if req.RotateSecret {
+		response.Secret = updated.Secret
+	}
+	return response, nil
+}
+
+// DeleteEndpoint removes a webhook endpoint of a store owned by ownerID
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, ownerID, endpointID string) error {
+	endpoint, err := s.getOwnedEndpoint(ctx, ownerID, endpointID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteEndpoint(ctx, endpoint.ID)
+}
+
+// ListDeliveries returns a page of an endpoint's delivery log, optionally
+// only deliveries with the given status
+func (s *WebhookService) ListDeliveries(ctx context.Context, ownerID, endpointID, status string, page, pageSize int) ([]*dto.WebhookDeliveryResponse, error) {
+	switch status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliverySucceeded, models.WebhookDeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: status must be pending, succeeded or failed", utils.ErrInvalidOperation)
+	}
+
+	endpoint, err := s.getOwnedEndpoint(ctx, ownerID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalisePage(page, pageSize)
+	deliveries, err := s.repo.ListDeliveries(ctx, endpoint.ID, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = mapWebhookDeliveryToResponse(delivery)
+	}
+	return responses, nil
+}
+
+// Redeliver queues a delivery of an endpoint owned by ownerID to be sent
+// again right away, whatever its status
+func (s *WebhookService) Redeliver(ctx context.Context, ownerID, endpointID, deliveryID string) (*dto.WebhookDeliveryResponse, error) {
+	endpoint, err := s.getOwnedEndpoint(ctx, ownerID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.EndpointID != endpoint.ID {
+		return nil, utils.ErrDeliveryNotFound
+	}
+
+	queued, err := s.repo.Redeliver(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapWebhookDeliveryToResponse(queued), nil
+}
+
+// Publish queues an event for every active endpoint of the store that
+// subscribes to eventType. Failures are logged rather than returned so a
+// broken integration never blocks the vendor's own work.
+func (s *WebhookService) Publish(ctx context.Context, storeID, eventType string, data any) {
+	endpoints, err := s.repo.ListSubscribed(ctx, storeID, eventType)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to publish webhook event", "event", eventType, "store_id", storeID, "error", err)
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	event := dto.WebhookEvent{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		StoreID:   storeID,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Data:      data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to encode webhook event", "event", eventType, "store_id", storeID, "error", err)
+		return
+	}
+
+	deliveries := make([]*models.WebhookDelivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = &models.WebhookDelivery{
+			EndpointID: endpoint.ID,
+			EventID:    event.ID,
+			EventType:  eventType,
+			Payload:    payload,
+		}
+	}
+
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		s.logger.ErrorContext(ctx, "failed to queue webhook deliveries", "event", eventType, "store_id", storeID, "error", err)
+	}
+}
+
+// getOwnedEndpoint loads an endpoint, reporting endpoints of other owners'
+// stores as not found
+func (s *WebhookService) getOwnedEndpoint(ctx context.Context, ownerID, endpointID string) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.storeService.GetOwnedStore(ctx, ownerID, endpoint.StoreID); err != nil {
+		if errors.Is(err, utils.ErrStoreNotFound) {
+			return nil, utils.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// normaliseWebhookEvents checks event types against the supported ones and
+// drops duplicates
+func normaliseWebhookEvents(events []string) ([]string, error) {
+	normalised := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(models.WebhookEventTypes, event) {
+			return nil, fmt.Errorf("%w: unknown event type %q, expected one of %s",
+				utils.ErrInvalidOperation, event, strings.Join(models.WebhookEventTypes, ", "))
+		}
+		if !slices.Contains(normalised, event) {
+			normalised = append(normalised, event)
+		}
+	}
+	return normalised, nil
+}
+
+func newWebhookSecret() (string, error) {
+	token, err := utils.GenerateToken(24)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + token, nil
+}
+
+func mapWebhookEndpointToResponse(endpoint *models.WebhookEndpoint) *dto.WebhookEndpointResponse {
+	return &dto.WebhookEndpointResponse{
+		ID:          endpoint.ID,
+		StoreID:     endpoint.StoreID,
+		URL:         endpoint.URL,
+		Events:      endpoint.Events,
+		Description: endpoint.Description,
+		IsActive:    endpoint.IsActive,
+		CreatedAt:   endpoint.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   endpoint.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func mapWebhookDeliveryToResponse(delivery *models.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	response := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		response.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.LastAttemptAt != nil {
+		response.LastAttemptAt = delivery.LastAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		response.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	return response
+}
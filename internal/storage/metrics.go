@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// instrumentedStorage times the uploads and deletes of another Storage and
+// counts those that fail. Files rejected for their size or type never reach
+// storage and aren't counted.
+type instrumentedStorage struct {
+	Storage
+	duration *prometheus.HistogramVec
+	failures *prometheus.CounterVec
+}
+
+// WithMetrics wraps storage so its operations are reported to registerer
+// as storage_operation_duration_seconds and
+// storage_operation_failures_total, labelled by operation
+func WithMetrics(storage Storage, registerer prometheus.Registerer) Storage {
+	s := &instrumentedStorage{
+		Storage: storage,
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "storage_operation_duration_seconds",
+			Help:    "Duration of file storage operations.",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"operation"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "storage_operation_failures_total",
+			Help: "File storage operations that failed.",
+		}, []string{"operation"}),
+	}
+	registerer.MustRegister(s.duration, s.failures)
+	return s
+}
+
+func (s *instrumentedStorage) SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	start := time.Now()
+	url, err := s.Storage.SaveFile(ctx, file)
+	s.observe("upload", start, err)
+	return url, err
+}
+
+func (s *instrumentedStorage) DeleteFile(ctx context.Context, filename string) error {
+	start := time.Now()
+	err := s.Storage.DeleteFile(ctx, filename)
+	s.observe("delete", start, err)
+	return err
+}
+
+func (s *instrumentedStorage) observe(operation string, start time.Time, err error) {
+	var invalid *InvalidFileError
+	if errors.As(err, &invalid) {
+		return
+	}
+	s.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		s.failures.WithLabelValues(operation).Inc()
+	}
+}
@@ -2,15 +2,20 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"mime/multipart"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	storage_go "github.com/supabase-community/storage-go"
 	"github.com/supabase-community/supabase-go"
+
+	"github.com/falasefemi2/vendorhub/internal/utils"
 )
 
 // Storage defines the interface for file storage operations
@@ -23,32 +28,44 @@ type Storage interface {
 	GetURL(filename string) string
 }
 
+// InvalidFileError reports an upload rejected for its size or type before
+// reaching storage. It wraps utils.ErrInvalidFile, so clients get a 400.
+type InvalidFileError struct {
+	Reason string
+}
+
+func (e *InvalidFileError) Error() string {
+	return e.Reason
+}
+
+func (e *InvalidFileError) Unwrap() error {
+	return utils.ErrInvalidFile
+}
+
 // SupabaseStorage implements Storage interface using Supabase Storage
 type SupabaseStorage struct {
 	client      *supabase.Client
 	bucket      string // Supabase bucket name
 	supabaseURL string // Supabase project URL
 	maxFileSize int64  // Max file size in bytes (default: 10MB)
+	logger      *slog.Logger
 }
 
 // NewSupabaseStorage creates a new Supabase storage instance
-func NewSupabaseStorage(url, key, bucket string) (*SupabaseStorage, error) {
-	fmt.Printf("[DEBUG] Initializing Supabase storage client with URL=%s, bucket=%s\n", url, bucket)
-
+func NewSupabaseStorage(url, key, bucket string, logger *slog.Logger) (*SupabaseStorage, error) {
 	client, err := supabase.NewClient(url, key, nil)
 	if err != nil {
-		fmt.Printf("[ERROR] Failed to initialize Supabase client: %v\n", err)
 		return nil, fmt.Errorf("failed to initialize Supabase client: %w", err)
 	}
 
-	fmt.Printf("[DEBUG] Supabase client initialized successfully\n")
-	fmt.Printf("[DEBUG] Client Storage interface: %T\n", client.Storage)
+	logger.Debug("supabase storage initialized", "url", url, "bucket", bucket)
 
 	return &SupabaseStorage{
 		client:      client,
 		bucket:      bucket,
 		supabaseURL: strings.TrimSuffix(url, "/"),
 		maxFileSize: 10 * 1024 * 1024, // 10MB default
+		logger:      logger,
 	}, nil
 }
 
@@ -60,7 +77,7 @@ func (ss *SupabaseStorage) SaveFile(ctx context.Context, file *multipart.FileHea
 
 	// Validate file size
 	if file.Size > ss.maxFileSize {
-		return "", fmt.Errorf("file size exceeds maximum allowed size of %d bytes", ss.maxFileSize)
+		return "", &InvalidFileError{Reason: fmt.Sprintf("file size exceeds maximum allowed size of %d bytes", ss.maxFileSize)}
 	}
 
 	// Validate file extension
@@ -74,7 +91,7 @@ func (ss *SupabaseStorage) SaveFile(ctx context.Context, file *multipart.FileHea
 	}
 
 	if !allowedExts[ext] {
-		return "", fmt.Errorf("file type %s not allowed. Allowed types: jpg, jpeg, png, gif, webp", ext)
+		return "", &InvalidFileError{Reason: fmt.Sprintf("file type %s not allowed. Allowed types: jpg, jpeg, png, gif, webp", ext)}
 	}
 
 	// Open the uploaded file
@@ -86,28 +103,20 @@ func (ss *SupabaseStorage) SaveFile(ctx context.Context, file *multipart.FileHea
 
 	// Generate unique filename
 	filename := generateUniqueFilename(ext)
-	log.Printf("[DEBUG] Generated filename: %s", filename)
-	log.Printf("[DEBUG] Supabase URL: %s, Bucket: %s", ss.supabaseURL, ss.bucket)
 
 	// Upload to Supabase Storage
-	log.Printf("[DEBUG] Starting Supabase upload to bucket=%s with filename=%s", ss.bucket, filename)
 	uploadResult, err := ss.client.Storage.UploadFile(ss.bucket, filename, src)
 	if err != nil {
-		log.Printf("[ERROR] Supabase upload failed: bucket=%s filename=%s error=%v error_type=%T", ss.bucket, filename, err, err)
-		log.Printf("[ERROR] Full error details: %+v", err)
-		return "", fmt.Errorf("failed to upload file to Supabase: %w", err)
+		return "", fmt.Errorf("failed to upload %s to Supabase bucket %s: %w", filename, ss.bucket, err)
 	}
 
-	log.Printf("[DEBUG] Supabase upload response: %+v", uploadResult)
-	log.Printf("[DEBUG] Response Key field: %v", uploadResult.Key)
-
 	// Return the FULL PUBLIC URL (not just the filename)
 	publicURL := ss.GetURL(filename)
-	log.Printf("[INFO] Constructed public URL: %s", publicURL)
+	ss.logger.DebugContext(ctx, "uploaded file", "bucket", ss.bucket, "key", uploadResult.Key, "url", publicURL, "size", file.Size)
 
 	// Verify URL format
 	if !strings.HasPrefix(publicURL, "https://") {
-		log.Printf("[WARN] Public URL doesn't start with https://: %s", publicURL)
+		ss.logger.WarnContext(ctx, "public URL is not https", "url", publicURL)
 	}
 
 	return publicURL, nil
@@ -127,18 +136,43 @@ func (ss *SupabaseStorage) DeleteFile(ctx context.Context, filename string) erro
 	}
 
 	// Delete from Supabase (RemoveFile accepts a slice of filenames)
-	_, err := ss.client.Storage.RemoveFile(ss.bucket, []string{filename})
+	removed, err := ss.client.Storage.RemoveFile(ss.bucket, []string{filename})
 	if err != nil {
-		// Log error but don't fail if file doesn't exist
-		if strings.Contains(err.Error(), "not found") {
-			return fmt.Errorf("file not found")
+		var storageErr *storage_go.StorageError
+		if errors.As(err, &storageErr) && storageErr.Status == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", utils.ErrFileNotFound, filename)
 		}
 		return fmt.Errorf("failed to delete file from Supabase: %w", err)
 	}
+	// Supabase lists the objects it removed, answering with none rather
+	// than an error when nothing had the name
+	if len(removed) == 0 {
+		return fmt.Errorf("%w: %s", utils.ErrFileNotFound, filename)
+	}
 
 	return nil
 }
 
+// Ping checks that the bucket can be reached with the configured key. The
+// client doesn't take a context, so ctx only bounds the wait.
+func (ss *SupabaseStorage) Ping(ctx context.Context) error {
+	result := make(chan error, 1)
+	go func() {
+		_, err := ss.client.Storage.GetBucket(ss.bucket)
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return fmt.Errorf("failed to get Supabase bucket %s: %w", ss.bucket, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // GetURL returns the public URL for accessing the file
 func (ss *SupabaseStorage) GetURL(filename string) string {
 	// Extract just the filename if full URL is passed
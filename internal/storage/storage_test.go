@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/utils"
+)
+
+func TestSupabaseDeleteFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "removed", status: http.StatusOK, body: `[{"name":"a.png"}]`},
+		{name: "nothing removed", status: http.StatusOK, body: `[]`, wantErr: true, wantNotFound: true},
+		{name: "not found", status: http.StatusNotFound, body: `{"status":404,"message":"Object not found"}`, wantErr: true, wantNotFound: true},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"status":500,"message":"internal"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/storage/v1/object/images" {
+					t.Errorf("request = %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			ss, err := NewSupabaseStorage(server.URL, "key", "images", slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ss.DeleteFile(context.Background(), server.URL+"/storage/v1/object/public/images/a.png")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteFile() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, utils.ErrFileNotFound); got != tt.wantNotFound {
+				t.Errorf("DeleteFile() error = %v, not found %v, want %v", err, got, tt.wantNotFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"mime/multipart"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedStorage records a span for each upload and delete of another
+// Storage
+type tracedStorage struct {
+	Storage
+	tracer trace.Tracer
+}
+
+// WithTracing wraps storage so its uploads and deletes show in traces as
+// storage.upload and storage.delete spans
+func WithTracing(storage Storage) Storage {
+	return &tracedStorage{
+		Storage: storage,
+		tracer:  otel.Tracer("github.com/falasefemi2/vendorhub/internal/storage"),
+	}
+}
+
+func (s *tracedStorage) SaveFile(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	var attrs []attribute.KeyValue
+	if file != nil {
+		attrs = append(attrs, attribute.Int64("file.size", file.Size))
+	}
+	ctx, span := s.tracer.Start(ctx, "storage.upload", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	url, err := s.Storage.SaveFile(ctx, file)
+	recordSpanError(span, err)
+	return url, err
+}
+
+func (s *tracedStorage) DeleteFile(ctx context.Context, filename string) error {
+	ctx, span := s.tracer.Start(ctx, "storage.delete", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	err := s.Storage.DeleteFile(ctx, filename)
+	recordSpanError(span, err)
+	return err
+}
+
+func recordSpanError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
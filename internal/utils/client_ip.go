@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns the address of the client that sent r. trustedHeader
+// names the header a trusted reverse proxy puts the client address in, e.g.
+// X-Forwarded-For (CLIENT_IP_HEADER); its last address is used, as proxies
+// append the one they saw and anything before it came from the client. A
+// header sent on several lines is read as one list, so a client can't add
+// a later line of its own.
+// Without one, or when it holds no valid address, the connection's address
+// is used, so clients can't pick their own.
+func ClientIP(r *http.Request, trustedHeader string) string {
+	if trustedHeader != "" {
+		values := strings.Split(strings.Join(r.Header.Values(trustedHeader), ","), ",")
+		if ip := net.ParseIP(strings.TrimSpace(values[len(values)-1])); ip != nil {
+			return ip.String()
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	return host
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		header        map[string][]string
+		trustedHeader string
+		want          string
+	}{
+		{
+			name:       "connection address",
+			remoteAddr: "203.0.113.7:52311",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "forwarding headers ignored without a trusted header",
+			remoteAddr: "203.0.113.7:52311",
+			header:     map[string][]string{"X-Forwarded-For": {"198.51.100.1"}, "X-Real-IP": {"198.51.100.2"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:          "last address in the trusted header",
+			remoteAddr:    "10.0.0.2:443",
+			header:        map[string][]string{"X-Forwarded-For": {"198.51.100.1, 203.0.113.9"}},
+			trustedHeader: "X-Forwarded-For",
+			want:          "203.0.113.9",
+		},
+		{
+			// The proxy appends its own line after a spoofed one, so only
+			// the last address across every line can be trusted
+			name:          "trusted header on several lines",
+			remoteAddr:    "10.0.0.2:443",
+			header:        map[string][]string{"X-Forwarded-For": {"198.51.100.1", "203.0.113.9"}},
+			trustedHeader: "X-Forwarded-For",
+			want:          "203.0.113.9",
+		},
+		{
+			name:          "invalid trusted header falls back to the connection",
+			remoteAddr:    "10.0.0.2:443",
+			header:        map[string][]string{"X-Forwarded-For": {"198.51.100.1, not-an-ip"}},
+			trustedHeader: "X-Forwarded-For",
+			want:          "10.0.0.2",
+		},
+		{
+			name:          "missing trusted header falls back to the connection",
+			remoteAddr:    "10.0.0.2:443",
+			trustedHeader: "X-Forwarded-For",
+			want:          "10.0.0.2",
+		},
+		{
+			name:       "IPv6 connection address",
+			remoteAddr: "[2001:db8::1]:443",
+			want:       "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/events", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for name, values := range tt.header {
+				for _, value := range values {
+					r.Header.Add(name, value)
+				}
+			}
+
+			if got := ClientIP(r, tt.trustedHeader); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package utils
 
-import "context"
+import (
+	"context"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
 
 type contextKey string
 
 const (
 	UserIDKey contextKey = "userID"
 	RoleKey   contextKey = "role"
+	// APIKeyKey holds the *models.APIKey of requests authenticated with one
+	APIKeyKey contextKey = "apiKey"
+	// RequestIDKey holds the ID of the request, also sent as X-Request-ID
+	RequestIDKey contextKey = "requestID"
 )
 
 func GetUserIDFromContext(ctx context.Context) (string, error) {
@@ -24,3 +32,17 @@ func GetRoleFromContext(ctx context.Context) (string, error) {
 	}
 	return role, nil
 }
+
+// GetAPIKeyFromContext returns the API key the request was authenticated
+// with, if it wasn't a JWT
+func GetAPIKeyFromContext(ctx context.Context) (*models.APIKey, bool) {
+	key, ok := ctx.Value(APIKeyKey).(*models.APIKey)
+	return key, ok && key != nil
+}
+
+// GetRequestIDFromContext returns the ID of the request ctx belongs to, or
+// "" outside of a request such as in background jobs
+func GetRequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
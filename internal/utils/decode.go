@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
+	"github.com/falasefemi2/vendorhub/internal/validate"
+)
+
+// maxJSONBodyBytes is the largest JSON request body DecodeJSON reads
+const maxJSONBodyBytes = 1 << 20
+
+// DecodeJSON decodes the request body into dst, a pointer to a request
+// struct, and checks it against its validate tags. Bodies over 1MB, with
+// fields dst doesn't have or with more than one value are rejected. The
+// errors are apperr errors for HandleServiceError; an empty body is
+// ErrEmptyBody, for handlers where it's optional.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if err := decoder.Decode(&json.RawMessage{}); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return decodeError(err)
+		}
+		return fmt.Errorf("%w: body must hold a single JSON value", ErrMalformedBody)
+	}
+
+	return validate.Struct(dst)
+}
+
+func decodeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrEmptyBody
+	case errors.As(err, &maxBytesErr):
+		return fmt.Errorf("%w: at most %d bytes are accepted", ErrBodyTooLarge, maxBytesErr.Limit)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return apperr.Invalid(apperr.FieldError{Field: typeErr.Field, Message: "must be " + jsonType(typeErr.Type)})
+	}
+
+	// encoding/json has no error type for unknown fields
+	if quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if field, unquoteErr := strconv.Unquote(quoted); unquoteErr == nil {
+			return apperr.Invalid(apperr.FieldError{Field: field, Message: "is not a known field"})
+		}
+	}
+	return fmt.Errorf("%w: %v", ErrMalformedBody, err)
+}
+
+// jsonType names the JSON type that decodes into t
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return "a string"
+	}
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
+)
+
+type decodeTestRequest struct {
+	Name     string   `json:"name" validate:"required"`
+	Quantity int      `json:"quantity"`
+	Tags     []string `json:"tags"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       decodeTestRequest
+		wantErr    error
+		wantFields []apperr.FieldError
+	}{
+		{
+			name: "valid",
+			body: `{"name":"Ada","quantity":2,"tags":["new"]}`,
+			want: decodeTestRequest{Name: "Ada", Quantity: 2, Tags: []string{"new"}},
+		},
+		{
+			name: "trailing whitespace",
+			body: "{\"name\":\"Ada\"}\n\n",
+			want: decodeTestRequest{Name: "Ada"},
+		},
+		{name: "empty body", body: "", wantErr: ErrEmptyBody},
+		{name: "malformed", body: `{"name":`, wantErr: ErrMalformedBody},
+		{name: "not an object", body: `"Ada"`, wantErr: ErrMalformedBody},
+		{name: "second value", body: `{"name":"Ada"}{"name":"Grace"}`, wantErr: ErrMalformedBody},
+		{name: "trailing garbage", body: `{"name":"Ada"} x`, wantErr: ErrMalformedBody},
+		{
+			name:       "unknown field",
+			body:       `{"name":"Ada","colour":"red"}`,
+			wantFields: []apperr.FieldError{{Field: "colour", Message: "is not a known field"}},
+		},
+		{
+			name:       "wrong type",
+			body:       `{"name":"Ada","quantity":"two"}`,
+			wantFields: []apperr.FieldError{{Field: "quantity", Message: "must be a number"}},
+		},
+		{
+			name:       "wrong type for a slice",
+			body:       `{"name":"Ada","tags":"new"}`,
+			wantFields: []apperr.FieldError{{Field: "tags", Message: "must be an array"}},
+		},
+		{
+			name:       "validate tags checked",
+			body:       `{"quantity":2}`,
+			wantFields: []apperr.FieldError{{Field: "name", Message: "is required"}},
+		},
+		{
+			name:    "too large",
+			body:    `{"name":"` + strings.Repeat("a", maxJSONBodyBytes) + `"}`,
+			wantErr: ErrBodyTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			var got decodeTestRequest
+			err := DecodeJSON(w, r, &got)
+
+			switch {
+			case tt.wantFields != nil:
+				var appErr *apperr.Error
+				if !errors.As(err, &appErr) || appErr.Kind != apperr.KindValidation {
+					t.Fatalf("DecodeJSON() = %v, want a validation error", err)
+				}
+				if !reflect.DeepEqual(appErr.Fields, tt.wantFields) {
+					t.Errorf("DecodeJSON() fields = %+v, want %+v", appErr.Fields, tt.wantFields)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DecodeJSON() = %v, want %v", err, tt.wantErr)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("DecodeJSON() = %v, want nil", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("DecodeJSON() decoded %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -1,14 +1,39 @@
 package utils
 
-import "errors"
+import "github.com/falasefemi2/vendorhub/internal/apperr"
 
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrAccountNotActive   = errors.New("account not active")
-	ErrPasswordTooShort   = errors.New("password must be at least 8 characters long")
-	ErrInvalidToken       = errors.New("invalid token")
-	ErrUnauthorized       = errors.New("unauthorized user")
-	ErrInvalidOperation   = errors.New("invalid operation")
-	ErrWeakPassword       = errors.New("password too weak")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials   = apperr.New(apperr.KindUnauthorized, "invalid_credentials", "invalid email or password")
+	ErrAccountNotActive     = apperr.New(apperr.KindForbidden, "account_not_active", "account not active")
+	ErrPasswordTooShort     = apperr.New(apperr.KindValidation, "password_too_short", "password must be at least 8 characters long")
+	ErrInvalidToken         = apperr.New(apperr.KindUnauthorized, "invalid_token", "invalid token")
+	ErrUnauthorized         = apperr.New(apperr.KindUnauthorized, "unauthorized", "unauthorized user")
+	ErrInvalidOperation     = apperr.New(apperr.KindValidation, "invalid_operation", "invalid operation")
+	ErrInvalidFile          = apperr.New(apperr.KindValidation, "invalid_file", "invalid file")
+	ErrEmptyBody            = apperr.New(apperr.KindValidation, "empty_body", "request body is required")
+	ErrMalformedBody        = apperr.New(apperr.KindValidation, "malformed_body", "invalid request body")
+	ErrBodyTooLarge         = apperr.New(apperr.KindTooLarge, "body_too_large", "request body too large")
+	ErrWeakPassword         = apperr.New(apperr.KindValidation, "weak_password", "password too weak")
+	ErrUserNotFound         = apperr.New(apperr.KindNotFound, "user_not_found", "user not found")
+	ErrForbidden            = apperr.New(apperr.KindForbidden, "forbidden", "forbidden")
+	ErrStoreMemberNotFound  = apperr.New(apperr.KindNotFound, "store_member_not_found", "store member not found")
+	ErrInviteNotFound       = apperr.New(apperr.KindNotFound, "invite_not_found", "invite not found")
+	ErrStoreNotFound        = apperr.New(apperr.KindNotFound, "store_not_found", "store not found")
+	ErrProductNotFound      = apperr.New(apperr.KindNotFound, "product_not_found", "product not found")
+	ErrProductImageNotFound = apperr.New(apperr.KindNotFound, "product_image_not_found", "product image not found")
+	ErrFileNotFound         = apperr.New(apperr.KindNotFound, "file_not_found", "file not found")
+	ErrReviewNotFound       = apperr.New(apperr.KindNotFound, "review_not_found", "review not found")
+	ErrSlugTaken            = apperr.New(apperr.KindConflict, "slug_taken", "slug is already taken")
+	ErrEmailTaken           = apperr.New(apperr.KindConflict, "email_taken", "email already exists")
+	ErrUsernameTaken        = apperr.New(apperr.KindConflict, "username_taken", "username is already taken")
+	ErrAlreadyInvited       = apperr.New(apperr.KindConflict, "already_invited", "email is already a member of or invited to this store")
+	ErrCouponNotFound       = apperr.New(apperr.KindNotFound, "coupon_not_found", "coupon not found")
+	ErrCouponCodeTaken      = apperr.New(apperr.KindConflict, "coupon_code_taken", "coupon code is already in use in this store")
+	ErrPricesNotConvertible = apperr.New(apperr.KindConflict, "currency_not_convertible", "prices can't be converted exactly to the new currency")
+	ErrOrderNotFound        = apperr.New(apperr.KindNotFound, "order_not_found", "order not found")
+	ErrPaymentNotFound      = apperr.New(apperr.KindNotFound, "payment_not_found", "payment not found")
+	ErrWebhookNotFound      = apperr.New(apperr.KindNotFound, "webhook_not_found", "webhook not found")
+	ErrDeliveryNotFound     = apperr.New(apperr.KindNotFound, "webhook_delivery_not_found", "webhook delivery not found")
+	ErrAPIKeyNotFound       = apperr.New(apperr.KindNotFound, "api_key_not_found", "api key not found")
+	ErrImportJobNotFound    = apperr.New(apperr.KindNotFound, "import_job_not_found", "import job not found")
 )
@@ -1,12 +1,27 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
 )
 
+// ErrorResponse is an RFC 7807 problem details body. Code identifies the
+// problem for clients; Error repeats Detail for clients written before
+// errors were problems.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Type   string              `json:"type" example:"about:blank"`
+	Title  string              `json:"title" example:"Not Found"`
+	Status int                 `json:"status" example:"404"`
+	Detail string              `json:"detail" example:"product not found"`
+	Code   string              `json:"code" example:"product_not_found"`
+	Errors []apperr.FieldError `json:"errors,omitempty"`
+	Error  string              `json:"error" example:"product not found"`
 }
 
 func WriteJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -18,8 +33,87 @@ func WriteJSON(w http.ResponseWriter, status int, data interface{}) {
 	}
 }
 
+// WriteError writes a problem with status, coded after the status text,
+// e.g. "not_found"
 func WriteError(w http.ResponseWriter, status int, message string) {
-	WriteJSON(w, status, ErrorResponse{
-		Error: message,
+	title := http.StatusText(status)
+	writeProblem(w, ErrorResponse{
+		Type:   "about:blank",
+		Title:  title,
+		Status: status,
+		Detail: message,
+		Code:   strings.ToLower(strings.ReplaceAll(title, " ", "_")),
 	})
 }
+
+func writeProblem(w http.ResponseWriter, problem ErrorResponse) {
+	problem.Error = problem.Detail
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// WriteCacheableJSON writes data like WriteJSON with an ETag of the body,
+// answering 304 Not Modified when the request's If-None-Match already has
+// it. Clients and CDNs may store the response but must revalidate it each
+// time. lastModified is sent when known, and without If-None-Match a
+// request whose If-Modified-Since isn't before it gets 304 too.
+func WriteCacheableJSON(w http.ResponseWriter, r *http.Request, data any, lastModified time.Time) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		if recorder, ok := w.(ErrorRecorder); ok {
+			recorder.RecordError(err)
+		}
+		WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	body = append(body, '\n')
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := w.Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", "public, no-cache")
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// notModified evaluates a GET's conditional headers as RFC 9110 orders
+// them: If-None-Match when present, otherwise If-Modified-Since, which is
+// compared at the one-second precision of HTTP dates
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing
+// weakly as conditional GETs do
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
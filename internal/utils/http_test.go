@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWriteCacheableJSON(t *testing.T) {
+	data := map[string]string{"name": "Ada"}
+	modified := time.Date(2026, 1, 5, 10, 0, 0, 0, time.FixedZone("WAT", 3600))
+
+	first := httptest.NewRecorder()
+	WriteCacheableJSON(first, httptest.NewRequest("GET", "/", nil), data, modified)
+
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != "{\"name\":\"Ada\"}\n" {
+		t.Fatalf("WriteCacheableJSON() = %d %q, ETag %q", first.Code, first.Body.String(), etag)
+	}
+	if got := first.Header().Get("Cache-Control"); got != "public, no-cache" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if got := first.Header().Get("Last-Modified"); got != "Mon, 05 Jan 2026 09:00:00 GMT" {
+		t.Errorf("Last-Modified = %q", got)
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		data        any
+		wantStatus  int
+	}{
+		{name: "no validator", wantStatus: http.StatusOK},
+		{name: "same etag", ifNoneMatch: etag, data: data, wantStatus: http.StatusNotModified},
+		{name: "weak etag", ifNoneMatch: "W/" + etag, data: data, wantStatus: http.StatusNotModified},
+		{name: "one of a list", ifNoneMatch: `"stale", ` + etag, data: data, wantStatus: http.StatusNotModified},
+		{name: "any", ifNoneMatch: "*", data: data, wantStatus: http.StatusNotModified},
+		{name: "stale etag", ifNoneMatch: `"stale"`, data: data, wantStatus: http.StatusOK},
+		{name: "data changed", ifNoneMatch: etag, data: map[string]string{"name": "Grace"}, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.data == nil {
+				tt.data = data
+			}
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+
+			WriteCacheableJSON(w, r, tt.data, time.Time{})
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Header().Get("ETag") == "" {
+				t.Error("ETag not set")
+			}
+			if w.Header().Get("Last-Modified") != "" {
+				t.Error("Last-Modified set without a modification time")
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 has a body %q", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWriteCacheableJSONIfModifiedSince(t *testing.T) {
+	data := map[string]string{"name": "Ada"}
+	// HTTP dates have no fractions of a second, so this is sent as 10:00:00
+	modified := time.Date(2026, 1, 5, 10, 0, 0, 500_000_000, time.UTC)
+
+	first := httptest.NewRecorder()
+	WriteCacheableJSON(first, httptest.NewRequest("GET", "/", nil), data, modified)
+	etag := first.Header().Get("ETag")
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		ifNoneMatch     string
+		lastModified    time.Time
+		wantStatus      int
+	}{
+		{name: "same second", ifModifiedSince: "Mon, 05 Jan 2026 10:00:00 GMT", lastModified: modified, wantStatus: http.StatusNotModified},
+		{name: "later", ifModifiedSince: "Mon, 05 Jan 2026 11:00:00 GMT", lastModified: modified, wantStatus: http.StatusNotModified},
+		{name: "earlier", ifModifiedSince: "Mon, 05 Jan 2026 09:59:59 GMT", lastModified: modified, wantStatus: http.StatusOK},
+		{name: "unparseable", ifModifiedSince: "yesterday", lastModified: modified, wantStatus: http.StatusOK},
+		{name: "no modification time", ifModifiedSince: "Mon, 05 Jan 2026 11:00:00 GMT", wantStatus: http.StatusOK},
+		{
+			name:            "stale etag wins",
+			ifModifiedSince: "Mon, 05 Jan 2026 11:00:00 GMT",
+			ifNoneMatch:     `"stale"`,
+			lastModified:    modified,
+			wantStatus:      http.StatusOK,
+		},
+		{
+			name:            "matching etag wins",
+			ifModifiedSince: "Mon, 05 Jan 2026 09:00:00 GMT",
+			ifNoneMatch:     etag,
+			lastModified:    modified,
+			wantStatus:      http.StatusNotModified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+
+			WriteCacheableJSON(w, r, data, tt.lastModified)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 has a body %q", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWriteCacheableJSONUnencodable(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteCacheableJSON(w, httptest.NewRequest("GET", "/", nil), map[string]any{"bad": make(chan int)}, time.Time{})
+
+	if w.Code != http.StatusInternalServerError || w.Header().Get("ETag") != "" {
+		t.Errorf("WriteCacheableJSON() = %d, ETag %q, want a 500 without an ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
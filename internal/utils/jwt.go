@@ -8,15 +8,14 @@ import (
 	"github.com/falasefemi2/vendorhub/internal/models"
 )
 
-var jwtSecret = []byte("supersecretkey")
-
 type Claims struct {
 	UserID string `json:"user_id"`
 	Role   string `json:"role"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJwt(user *models.User) (string, error) {
+// GenerateJwt signs a day-long auth token for user with secret
+func GenerateJwt(user *models.User, secret string) (string, error) {
 	claims := Claims{
 		UserID: user.ID,
 		Role:   user.Role,
@@ -25,14 +24,16 @@ func GenerateJwt(user *models.User) (string, error) {
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return token.SignedString([]byte(secret))
 }
 
-func ValidateJWT(tokenString string) (*Claims, error) {
+// ValidateJWT checks a token signed by GenerateJwt with the same secret
+// and returns its claims
+func ValidateJWT(tokenString, secret string) (*Claims, error) {
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
-		return jwtSecret, nil
-	})
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		return nil, err
 	}
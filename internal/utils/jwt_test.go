@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/falasefemi2/vendorhub/internal/models"
+)
+
+func TestJWTRoundTrip(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	user := &models.User{ID: "user-1", Role: "vendor"}
+
+	token, err := GenerateJwt(user, secret)
+	if err != nil {
+		t.Fatalf("GenerateJwt() error = %v", err)
+	}
+
+	claims, err := ValidateJWT(token, secret)
+	if err != nil {
+		t.Fatalf("ValidateJWT() error = %v", err)
+	}
+	if claims.UserID != user.ID || claims.Role != user.Role {
+		t.Errorf("claims = %q/%q, want %q/%q", claims.UserID, claims.Role, user.ID, user.Role)
+	}
+}
+
+func TestValidateJWTRejects(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	user := &models.User{ID: "user-1", Role: "admin"}
+
+	signed := func(method jwt.SigningMethod, key any, expiresAt time.Time) string {
+		t.Helper()
+		token := jwt.NewWithClaims(method, Claims{
+			UserID:           user.ID,
+			Role:             user.Role,
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)},
+		})
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return s
+	}
+	otherSecret, err := GenerateJwt(user, "supersecretkey")
+	if err != nil {
+		t.Fatalf("GenerateJwt() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "other secret", token: otherSecret},
+		{name: "expired", token: signed(jwt.SigningMethodHS256, []byte(secret), time.Now().Add(-time.Minute))},
+		{name: "other algorithm", token: signed(jwt.SigningMethodHS512, []byte(secret), time.Now().Add(time.Hour))},
+		{name: "unsigned", token: signed(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, time.Now().Add(time.Hour))},
+		{name: "garbage", token: "not.a.token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if claims, err := ValidateJWT(tt.token, secret); err == nil {
+				t.Errorf("ValidateJWT() = %+v, want an error", claims)
+			}
+		})
+	}
+}
@@ -2,42 +2,37 @@ package utils
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
-	"strconv"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
 )
 
-func HandleServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, ErrUnauthorized):
-		WriteError(w, http.StatusUnauthorized, err.Error())
-	case errors.Is(err, ErrInvalidCredentials):
-		WriteError(w, http.StatusUnauthorized, err.Error())
-	case errors.Is(err, ErrAccountNotActive):
-		WriteError(w, http.StatusForbidden, err.Error())
-	case errors.Is(err, ErrInvalidOperation):
-		WriteError(w, http.StatusBadRequest, err.Error())
-	case errors.Is(err, ErrUserNotFound):
-		WriteError(w, http.StatusNotFound, err.Error())
-	default:
-		WriteError(w, http.StatusInternalServerError, "internal server error")
-	}
+// ErrorRecorder is implemented by response writers that log the error
+// behind an internal server error, which isn't shown to the client
+type ErrorRecorder interface {
+	RecordError(err error)
 }
 
-// ParseFloat64 parses a string to float64
-func ParseFloat64(value string) (float64, error) {
-	if value == "" {
-		return 0, errors.New("value cannot be empty")
-	}
-
-	f, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid float value: %w", err)
-	}
-
-	if f < 0 {
-		return 0, errors.New("value cannot be negative")
+// HandleServiceError writes err as a problem: with the status and code of
+// the apperr.Error it wraps, or as an internal server error, recorded but
+// not shown, when it wraps none
+func HandleServiceError(w http.ResponseWriter, err error) {
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		if recorder, ok := w.(ErrorRecorder); ok {
+			recorder.RecordError(err)
+		}
+		WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
 	}
 
-	return f, nil
+	status := appErr.Kind.Status()
+	writeProblem(w, ErrorResponse{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+		Code:   appErr.Code,
+		Errors: appErr.Fields,
+	})
 }
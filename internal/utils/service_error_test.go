@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
+)
+
+// errorRecorder is a response writer that keeps the recorded error
+type errorRecorder struct {
+	*httptest.ResponseRecorder
+	err error
+}
+
+func (r *errorRecorder) RecordError(err error) { r.err = err }
+
+func TestHandleServiceError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorResponse
+	}{
+		{
+			name: "wrapped sentinel",
+			err:  fmt.Errorf("%w: coupon SAVE10 has expired", ErrInvalidOperation),
+			want: ErrorResponse{
+				Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+				Detail: "invalid operation: coupon SAVE10 has expired", Code: "invalid_operation",
+			},
+		},
+		{
+			name: "not found",
+			err:  ErrStoreNotFound,
+			want: ErrorResponse{
+				Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound,
+				Detail: ErrStoreNotFound.Error(), Code: "store_not_found",
+			},
+		},
+		{
+			name: "field errors",
+			err:  apperr.Invalid(apperr.FieldError{Field: "email", Message: "is required"}),
+			want: ErrorResponse{
+				Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+				Detail: "invalid request: email is required", Code: "validation_failed",
+				Errors: []apperr.FieldError{{Field: "email", Message: "is required"}},
+			},
+		},
+		{
+			name: "internal errors are hidden",
+			err:  errors.New("pq: connection reset"),
+			want: ErrorResponse{
+				Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				Detail: "internal server error", Code: "internal_server_error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &errorRecorder{ResponseRecorder: httptest.NewRecorder()}
+			HandleServiceError(w, tt.err)
+
+			if w.Code != tt.want.Status || w.Header().Get("Content-Type") != "application/problem+json" {
+				t.Fatalf("status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+			}
+			var got ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			tt.want.Error = tt.want.Detail
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problem = %+v, want %+v", got, tt.want)
+			}
+
+			if internal := tt.want.Status == http.StatusInternalServerError; internal != (w.err != nil) {
+				t.Errorf("recorded error %v", w.err)
+			}
+		})
+	}
+}
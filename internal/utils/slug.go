@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -14,3 +16,61 @@ func GenerateSlug(s string) string {
 	s = regexp.MustCompile(`-+`).ReplaceAllString(s, "-")
 	return s
 }
+
+const (
+	MinSlugLength = 3
+	MaxSlugLength = 60
+)
+
+var validSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// reservedSlugs collide with fixed /stores routes or could be mistaken for
+// platform pages, so no store may use them.
+var reservedSlugs = map[string]bool{
+	"admin":     true,
+	"api":       true,
+	"auth":      true,
+	"help":      true,
+	"login":     true,
+	"me":        true,
+	"my":        true,
+	"new":       true,
+	"search":    true,
+	"settings":  true,
+	"signup":    true,
+	"store":     true,
+	"stores":    true,
+	"support":   true,
+	"vendor":    true,
+	"vendorhub": true,
+	"vendors":   true,
+}
+
+func IsReservedSlug(slug string) bool {
+	return reservedSlugs[slug]
+}
+
+// ValidateSlug checks a vendor-chosen store slug: the format accepted by
+// ValidateSlugFormat, and not a reserved word.
+func ValidateSlug(slug string) error {
+	if err := ValidateSlugFormat(slug); err != nil {
+		return err
+	}
+	if IsReservedSlug(slug) {
+		return fmt.Errorf("slug %q is reserved", slug)
+	}
+	return nil
+}
+
+// ValidateSlugFormat checks that a slug is lowercase letters, digits and
+// single hyphens, between MinSlugLength and MaxSlugLength characters.
+// Product slugs only need this; they can't collide with routes.
+func ValidateSlugFormat(slug string) error {
+	if len(slug) < MinSlugLength || len(slug) > MaxSlugLength {
+		return fmt.Errorf("slug must be between %d and %d characters", MinSlugLength, MaxSlugLength)
+	}
+	if !validSlug.MatchString(slug) {
+		return errors.New("slug may only contain lowercase letters, numbers and single hyphens")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateSlug(t *testing.T) {
+	tests := map[string]string{
+		"Pizza Hut Lagos":        "pizza-hut-lagos",
+		"  Mama's   Kitchen!!  ": "mama-s-kitchen",
+		"--Ada & Co--":           "ada-co",
+		"Café Ọlá":               "caf-l",
+		"100% Organic":           "100-organic",
+		"!!!":                    "",
+	}
+	for name, want := range tests {
+		if got := GenerateSlug(name); got != want {
+			t.Errorf("GenerateSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestValidateSlug(t *testing.T) {
+	tests := []struct {
+		slug    string
+		wantErr string
+	}{
+		{slug: "pizza-hut-lagos"},
+		{slug: "abc"},
+		{slug: strings.Repeat("a", MaxSlugLength)},
+		{slug: "ab", wantErr: "between 3 and 60 characters"},
+		{slug: strings.Repeat("a", MaxSlugLength+1), wantErr: "between 3 and 60 characters"},
+		{slug: "Pizza", wantErr: "lowercase letters"},
+		{slug: "pizza--hut", wantErr: "single hyphens"},
+		{slug: "-pizza", wantErr: "single hyphens"},
+		{slug: "pizza_hut", wantErr: "lowercase letters"},
+		{slug: "admin", wantErr: `slug "admin" is reserved`},
+		{slug: "stores", wantErr: `slug "stores" is reserved`},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSlug(tt.slug)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateSlug(%q) = %v, want nil", tt.slug, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateSlug(%q) = %v, want an error containing %q", tt.slug, err, tt.wantErr)
+		}
+	}
+
+	// Product slugs can't collide with routes, so reserved words are fine
+	if err := ValidateSlugFormat("admin"); err != nil {
+		t.Errorf("ValidateSlugFormat(%q) = %v, want nil", "admin", err)
+	}
+}
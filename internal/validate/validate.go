@@ -0,0 +1,249 @@
+// Package validate checks request structs against the rules in their
+// validate tags, e.g. `validate:"required,email,max=100"`:
+//
+//	required  the field is set: not blank, zero or nil
+//	email     an email address, without a display name
+//	e164      a phone number in E.164 form, e.g. +2348012345678
+//	username  letters, digits and underscores
+//	url       an http or https URL
+//	min=N     at least N characters or items, or at least N
+//	max=N     at most N characters or items, or at most N
+//	oneof=a b one of the values listed
+//
+// Rules other than required skip empty fields, so optional fields are
+// checked only when set. Nested structs, and pointers and slices of them,
+// are checked too, their fields named like items[0].quantity.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
+)
+
+var (
+	e164     = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	username = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+)
+
+type rule struct {
+	name  string
+	param string
+	limit int
+}
+
+type field struct {
+	index    int
+	name     string
+	embedded bool
+	required bool
+	rules    []rule
+}
+
+// fieldCache holds the fields of each struct type checked, by reflect.Type
+var fieldCache sync.Map
+
+// Struct checks v, a struct or a pointer to one, returning an
+// apperr.Invalid error listing every invalid field, or nil
+func Struct(v any) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var invalid []apperr.FieldError
+	checkStruct(value, "", &invalid)
+	if len(invalid) > 0 {
+		return apperr.Invalid(invalid...)
+	}
+	return nil
+}
+
+func checkStruct(value reflect.Value, prefix string, invalid *[]apperr.FieldError) {
+	for _, f := range fieldsOf(value.Type()) {
+		fieldValue := value.Field(f.index)
+		if f.embedded {
+			checkNested(fieldValue, strings.TrimSuffix(prefix, "."), invalid)
+			continue
+		}
+
+		name := prefix + f.name
+		if message := checkField(fieldValue, f); message != "" {
+			*invalid = append(*invalid, apperr.FieldError{Field: name, Message: message})
+			continue
+		}
+		checkNested(fieldValue, name, invalid)
+	}
+}
+
+func checkNested(value reflect.Value, name string, invalid *[]apperr.FieldError) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if !value.IsNil() {
+			checkNested(value.Elem(), name, invalid)
+		}
+	case reflect.Struct:
+		prefix := ""
+		if name != "" {
+			prefix = name + "."
+		}
+		checkStruct(value, prefix, invalid)
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			checkNested(value.Index(i), fmt.Sprintf("%s[%d]", name, i), invalid)
+		}
+	}
+}
+
+// checkField returns what's wrong with value, or "" when it passes f's rules
+func checkField(value reflect.Value, f field) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return requiredMessage(f.required)
+		}
+		value = value.Elem()
+	}
+	if isEmpty(value) {
+		return requiredMessage(f.required)
+	}
+
+	for _, r := range f.rules {
+		if message := checkRule(value, r); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+func requiredMessage(required bool) string {
+	if required {
+		return "is required"
+	}
+	return ""
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+func checkRule(value reflect.Value, r rule) string {
+	switch r.name {
+	case "email":
+		address, err := mail.ParseAddress(value.String())
+		if err != nil || address.Address != value.String() {
+			return "must be a valid email address"
+		}
+	case "e164":
+		if !e164.MatchString(value.String()) {
+			return "must be a phone number in international format, e.g. +2348012345678"
+		}
+	case "username":
+		if !username.MatchString(value.String()) {
+			return "may only contain letters, digits and underscores"
+		}
+	case "url":
+		parsed, err := url.Parse(value.String())
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "must be an http or https URL"
+		}
+	case "oneof":
+		options := strings.Fields(r.param)
+		if !slices.Contains(options, fmt.Sprint(value.Interface())) {
+			return "must be one of " + strings.Join(options, ", ")
+		}
+	case "min":
+		if size, unit := sizeOf(value); size < r.limit {
+			return fmt.Sprintf("must be at least %d%s", r.limit, unit)
+		}
+	case "max":
+		if size, unit := sizeOf(value); size > r.limit {
+			return fmt.Sprintf("must be at most %d%s", r.limit, unit)
+		}
+	}
+	return ""
+}
+
+// sizeOf returns what min and max compare: the characters of a string,
+// the items of a slice, or a number itself
+func sizeOf(value reflect.Value) (int, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len(), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(value.Uint()), ""
+	default:
+		panic(fmt.Sprintf("validate: min and max don't apply to %s", value.Type()))
+	}
+}
+
+// fieldsOf returns the exported fields of t with their rules, parsing the
+// tags the first time t is seen. A malformed tag is a bug, so it panics.
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	var fields []field
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+
+		f := field{index: i, name: name, embedded: structField.Anonymous && structField.Tag.Get("json") == ""}
+		for _, spec := range strings.Split(structField.Tag.Get("validate"), ",") {
+			if spec == "" {
+				continue
+			}
+			ruleName, param, _ := strings.Cut(spec, "=")
+			r := rule{name: ruleName, param: param}
+			switch ruleName {
+			case "required":
+				f.required = true
+				continue
+			case "min", "max":
+				limit, err := strconv.Atoi(param)
+				if err != nil {
+					panic(fmt.Sprintf("validate: %s.%s: %s needs a number, got %q", t.Name(), structField.Name, ruleName, param))
+				}
+				r.limit = limit
+			case "email", "e164", "username", "url", "oneof":
+			default:
+				panic(fmt.Sprintf("validate: %s.%s: unknown rule %q", t.Name(), structField.Name, ruleName))
+			}
+			f.rules = append(f.rules, r)
+		}
+		fields = append(fields, f)
+	}
+
+	fieldCache.Store(t, fields)
+	return fields
+}
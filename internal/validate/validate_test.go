@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/falasefemi2/vendorhub/internal/apperr"
+)
+
+type testItem struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"min=1,max=100"`
+}
+
+type testAddress struct {
+	City string `json:"city" validate:"required,max=10"`
+}
+
+// Location is exported so that it's checked when embedded
+type Location struct {
+	Country string `json:"country" validate:"required"`
+}
+
+type testRequest struct {
+	Email    string       `json:"email" validate:"required,email"`
+	Phone    string       `json:"phone" validate:"e164"`
+	Username string       `json:"username" validate:"username,min=3"`
+	Website  *string      `json:"website" validate:"url"`
+	Status   string       `json:"status" validate:"oneof=active draft"`
+	Tags     []string     `json:"tags" validate:"max=2"`
+	Items    []testItem   `json:"items" validate:"required"`
+	Address  *testAddress `json:"address"`
+	Internal string       `json:"-" validate:"required"`
+	Location
+}
+
+func validRequest() testRequest {
+	website := "https://shop.example.com"
+	return testRequest{
+		Email:    "ada@example.com",
+		Phone:    "+2348012345678",
+		Username: "ada_l",
+		Website:  &website,
+		Status:   "active",
+		Tags:     []string{"new"},
+		Items:    []testItem{{ProductID: "p1", Quantity: 2}},
+		Location: Location{Country: "NG"},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	badWebsite := "ftp://shop.example.com"
+	blank := "   "
+
+	tests := []struct {
+		name   string
+		modify func(*testRequest)
+		want   []apperr.FieldError
+	}{
+		{name: "valid", modify: func(r *testRequest) {}},
+		{
+			name:   "optional fields left empty",
+			modify: func(r *testRequest) { r.Phone, r.Username, r.Website, r.Status, r.Tags = "", "", nil, "", nil },
+		},
+		{
+			name:   "blank required string",
+			modify: func(r *testRequest) { r.Email = "  " },
+			want:   []apperr.FieldError{{Field: "email", Message: "is required"}},
+		},
+		{
+			name:   "email with display name",
+			modify: func(r *testRequest) { r.Email = "Ada <ada@example.com>" },
+			want:   []apperr.FieldError{{Field: "email", Message: "must be a valid email address"}},
+		},
+		{
+			name:   "local phone number",
+			modify: func(r *testRequest) { r.Phone = "08012345678" },
+			want:   []apperr.FieldError{{Field: "phone", Message: "must be a phone number in international format, e.g. +2348012345678"}},
+		},
+		{
+			name:   "username with spaces",
+			modify: func(r *testRequest) { r.Username = "ada l" },
+			want:   []apperr.FieldError{{Field: "username", Message: "may only contain letters, digits and underscores"}},
+		},
+		{
+			name:   "short username counts characters",
+			modify: func(r *testRequest) { r.Username = "ab" },
+			want:   []apperr.FieldError{{Field: "username", Message: "must be at least 3 characters"}},
+		},
+		{
+			name:   "non-http url",
+			modify: func(r *testRequest) { r.Website = &badWebsite },
+			want:   []apperr.FieldError{{Field: "website", Message: "must be an http or https URL"}},
+		},
+		{
+			name:   "blank optional pointer",
+			modify: func(r *testRequest) { r.Website = &blank },
+		},
+		{
+			name:   "not one of",
+			modify: func(r *testRequest) { r.Status = "archived" },
+			want:   []apperr.FieldError{{Field: "status", Message: "must be one of active, draft"}},
+		},
+		{
+			name:   "too many items",
+			modify: func(r *testRequest) { r.Tags = []string{"a", "b", "c"} },
+			want:   []apperr.FieldError{{Field: "tags", Message: "must be at most 2 items"}},
+		},
+		{
+			name:   "empty required slice",
+			modify: func(r *testRequest) { r.Items = []testItem{} },
+			want:   []apperr.FieldError{{Field: "items", Message: "is required"}},
+		},
+		{
+			name: "nested slice items",
+			modify: func(r *testRequest) {
+				r.Items = []testItem{{ProductID: "p1", Quantity: 1}, {Quantity: 101}}
+			},
+			want: []apperr.FieldError{
+				{Field: "items[1].product_id", Message: "is required"},
+				{Field: "items[1].quantity", Message: "must be at most 100"},
+			},
+		},
+		{
+			name:   "nested pointer",
+			modify: func(r *testRequest) { r.Address = &testAddress{City: "Port Harcourt"} },
+			want:   []apperr.FieldError{{Field: "address.city", Message: "must be at most 10 characters"}},
+		},
+		{
+			name:   "embedded struct fields are not prefixed",
+			modify: func(r *testRequest) { r.Country = "" },
+			want:   []apperr.FieldError{{Field: "country", Message: "is required"}},
+		},
+		{
+			name: "every invalid field is listed",
+			modify: func(r *testRequest) {
+				r.Email = ""
+				r.Status = "archived"
+			},
+			want: []apperr.FieldError{
+				{Field: "email", Message: "is required"},
+				{Field: "status", Message: "must be one of active, draft"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := validRequest()
+			tt.modify(&request)
+
+			err := Struct(&request)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Struct() = %v, want nil", err)
+				}
+				return
+			}
+
+			var appErr *apperr.Error
+			if !errors.As(err, &appErr) || appErr.Kind != apperr.KindValidation {
+				t.Fatalf("Struct() = %v, want a validation error", err)
+			}
+			if !reflect.DeepEqual(appErr.Fields, tt.want) {
+				t.Errorf("Struct() fields = %+v, want %+v", appErr.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructIgnoresNonStructs(t *testing.T) {
+	if err := Struct("not a struct"); err != nil {
+		t.Errorf("Struct(string) = %v, want nil", err)
+	}
+}
+
+func TestStructPanicsOnMalformedTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "unknown rule", value: &struct {
+			Name string `validate:"required,alpha"`
+		}{}},
+		{name: "limit not a number", value: &struct {
+			Name string `validate:"max=ten"`
+		}{}},
+		{name: "limit on a bool", value: &struct {
+			Active bool `validate:"min=1"`
+		}{Active: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Struct() didn't panic")
+				}
+			}()
+			Struct(tt.value)
+		})
+	}
+}